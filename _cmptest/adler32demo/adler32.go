@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"hash/adler32"
+)
+
+func main() {
+	inputs := [][]byte{
+		nil,
+		[]byte("a"),
+		[]byte("abc"),
+		[]byte("Wikipedia"),
+		[]byte("The quick brown fox jumps over the lazy dog"),
+		// Large enough, and all 0xff, for both sums to wrap mod 65521 many times.
+		bytes.Repeat([]byte{0xff}, 1<<20),
+		bytes.Repeat([]byte("0123456789"), 100000),
+	}
+	for _, in := range inputs {
+		sum := adler32.Checksum(in)
+
+		// Streaming in uneven pieces must give the one-shot result.
+		h := adler32.New()
+		for rest, n := in, 1; len(rest) > 0; n = n*3 + 1 {
+			if n > len(rest) {
+				n = len(rest)
+			}
+			h.Write(rest[:n])
+			rest = rest[n:]
+		}
+		name := string(in)
+		if len(in) > 64 {
+			name = fmt.Sprintf("%d bytes", len(in))
+		}
+		fmt.Printf("%08x %v %q\n", sum, h.Sum32() == sum, name)
+	}
+}
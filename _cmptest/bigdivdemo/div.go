@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+)
+
+func divByZero(name string, f func()) {
+	defer func() {
+		r := recover()
+		fmt.Printf("%s: %#v\n", name, r)
+	}()
+	f()
+}
+
+func main() {
+	for _, a := range []int64{7, -7} {
+		for _, b := range []int64{3, -3} {
+			x, y := big.NewInt(a), big.NewInt(b)
+			q, r := new(big.Int).QuoRem(x, y, new(big.Int))
+			d, m := new(big.Int).DivMod(x, y, new(big.Int))
+			fmt.Println(a, b, q, r, d, m,
+				new(big.Int).Quo(x, y), new(big.Int).Rem(x, y),
+				new(big.Int).Div(x, y), new(big.Int).Mod(x, y))
+		}
+	}
+
+	x, zero := big.NewInt(1), new(big.Int)
+	divByZero("Quo", func() { new(big.Int).Quo(x, zero) })
+	divByZero("Rem", func() { new(big.Int).Rem(x, zero) })
+	divByZero("QuoRem", func() { new(big.Int).QuoRem(x, zero, new(big.Int)) })
+	divByZero("Div", func() { new(big.Int).Div(x, zero) })
+	divByZero("Mod", func() { new(big.Int).Mod(x, zero) })
+	divByZero("DivMod", func() { new(big.Int).DivMod(x, zero, new(big.Int)) })
+}
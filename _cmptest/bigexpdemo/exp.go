@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+)
+
+func exp(x, y int64, m *big.Int) {
+	z := new(big.Int).Exp(big.NewInt(x), big.NewInt(y), m)
+	fmt.Printf("(%d)**%d mod %v = %v\n", x, y, m, z)
+}
+
+func main() {
+	for _, m := range []*big.Int{big.NewInt(5), big.NewInt(-5), big.NewInt(12), big.NewInt(1), new(big.Int), nil} {
+		for _, x := range []int64{-2, 2, -12, 0} {
+			for _, y := range []int64{3, 2, 0, -1, -3} {
+				exp(x, y, m)
+			}
+		}
+	}
+
+	// A residue that isn't reduced, and z aliasing the base and the modulus.
+	m, _ := new(big.Int).SetString("170141183460469231731687303715884105727", 10)
+	x, _ := new(big.Int).SetString("-340282366920938463463374607431768211507", 10)
+	fmt.Println(new(big.Int).Exp(x, big.NewInt(65537), m))
+	fmt.Println(x.Exp(x, big.NewInt(3), m), m.Exp(big.NewInt(-7), big.NewInt(5), m))
+}
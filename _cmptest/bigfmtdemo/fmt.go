@@ -0,0 +1,17 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+)
+
+func main() {
+	x, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	y := big.NewInt(255)
+	for _, v := range []*big.Int{x, y, new(big.Int), nil} {
+		fmt.Printf("%v|%+v|%#v|%d|%x|%#X|%#o|%O|%b\n", v, v, v, v, v, v, v, v, v)
+		fmt.Printf("[%12d][%-12d][%012d][%.5d][%+.0d][%q]\n", v, v, v, v, v, v)
+	}
+	fmt.Println(x, y)
+	fmt.Printf("%v %v\n", []*big.Int{x, y}, struct{ N *big.Int }{y})
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Prints big.Int.Format over the cross product of verbs, flags, widths and
+// precisions, so any padding or sign difference from math/big shows up in
+// the comparison.
+func main() {
+	values := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(-1),
+		big.NewInt(-42),
+		big.NewInt(123456789),
+	}
+	huge, _ := new(big.Int).SetString("-0x1fedcba9876543210fedcba9876543210", 0)
+	values = append(values, huge)
+
+	for _, v := range values {
+		fmt.Println(v)
+		for _, verb := range "boOdxXv" {
+			for _, flag := range []string{"", "+", " ", "#", "0", "-"} {
+				for _, width := range []string{"", "5", "10"} {
+					for _, prec := range []string{"", ".0", ".3", ".8"} {
+						f := "%" + flag + width + prec + string(verb)
+						fmt.Printf("%s[%s]\n", f, fmt.Sprintf(f, v))
+					}
+				}
+			}
+		}
+	}
+}
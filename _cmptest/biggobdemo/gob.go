@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+)
+
+// Gob encodings captured from the standard math/big on a little-endian
+// machine (linux/amd64).
+var streams = []struct {
+	value string
+	data  []byte
+}{
+	{"0", []byte{0x2}},
+	{"1", []byte{0x2, 0x1}},
+	{"-1", []byte{0x3, 0x1}},
+	{"123456789012345678901234567890", []byte{0x2, 0x1, 0x8e, 0xe9, 0xf, 0xf6, 0xc3, 0x73, 0xe0, 0xee, 0x4e, 0x3f, 0xa, 0xd2}},
+	{"-18446744073709551617", []byte{0x3, 0x1, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1}},
+}
+
+func main() {
+	for _, s := range streams {
+		x := big.NewInt(0)
+		if err := x.GobDecode(s.data); err != nil {
+			fmt.Println("decode error:", err)
+			continue
+		}
+		enc, err := x.GobEncode()
+		if err != nil {
+			fmt.Println("encode error:", err)
+			continue
+		}
+		fmt.Println(x, x.String() == s.value, bytes.Equal(enc, s.data))
+	}
+
+	x := big.NewInt(0)
+	fmt.Println(x.GobDecode([]byte{0x4, 0x1}))
+}
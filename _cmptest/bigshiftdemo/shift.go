@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+)
+
+func main() {
+	values := []int64{0, 1, -1, 2, -2, 3, -3, 255, -255, 256, -256, 1<<62 + 12345, -(1<<62 + 12345)}
+	shifts := []uint{0, 1, 2, 7, 8, 63, 64, 65, 128, 200}
+	for _, v := range values {
+		x := big.NewInt(v)
+		x.Lsh(x, 70) // span several words
+		x.Add(x, big.NewInt(v))
+		for _, n := range shifts {
+			l := new(big.Int).Lsh(x, n)
+			r := new(big.Int).Rsh(x, n)
+			fmt.Println(x, n, l, r)
+		}
+	}
+
+	// Aliased destination.
+	x := big.NewInt(-5)
+	fmt.Println(x.Rsh(x, 1))
+	fmt.Println(x.Lsh(x, 64))
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+func main() {
+	x := big.NewInt(1)
+	x.Lsh(x, 200)
+	s := x.String()
+	fmt.Println(s, s == x.String())
+
+	// Every kind of mutation must invalidate the previously formatted text.
+	fmt.Println(x.Add(x, big.NewInt(1)))
+	fmt.Println(x.Sub(x, big.NewInt(2)))
+	fmt.Println(x.Neg(x))
+	fmt.Println(x.Abs(x))
+	fmt.Println(x.Rsh(x, 190))
+	fmt.Println(x.SetInt64(-42))
+	fmt.Println(x.SetUint64(42))
+	fmt.Println(x.Set(big.NewInt(7)))
+	fmt.Println(x.SetBytes([]byte{1, 0}))
+
+	var zero big.Int
+	fmt.Println(&zero, (*big.Int)(nil))
+
+	// Concurrent reads of an unchanged value.
+	var wg sync.WaitGroup
+	results := make([]string, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				results[i] = x.String()
+			}
+		}(i)
+	}
+	wg.Wait()
+	fmt.Println(results)
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+)
+
+func main() {
+	for _, s := range []string{"123", "-0x1f", "0b1_01", "12a", "", "0x", "1__0"} {
+		var x big.Int
+		if err := x.UnmarshalText([]byte(s)); err != nil {
+			fmt.Println(err, err.Error() != "")
+			continue
+		}
+		text, _ := x.MarshalText()
+		fmt.Printf("%s\n", text)
+	}
+
+	x, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	for _, base := range []int{2, 8, 10, 16, 36, 62} {
+		fmt.Println(base, x.Text(base), string(x.Append([]byte("x="), base)))
+	}
+	fmt.Println(new(big.Int).Text(16), (*big.Int)(nil).Text(16))
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"hash"
+	"hash/fnv"
+)
+
+var hashes = []struct {
+	name string
+	new  func() hash.Hash
+}{
+	{"fnv32", func() hash.Hash { return fnv.New32() }},
+	{"fnv32a", func() hash.Hash { return fnv.New32a() }},
+	{"fnv64", func() hash.Hash { return fnv.New64() }},
+	{"fnv64a", func() hash.Hash { return fnv.New64a() }},
+	{"fnv128", func() hash.Hash { return fnv.New128() }},
+	{"fnv128a", func() hash.Hash { return fnv.New128a() }},
+}
+
+func main() {
+	for _, h := range hashes {
+		for _, s := range []string{"", "a", "foobar", "hello, world"} {
+			one := h.new()
+			one.Write([]byte(s))
+			sum := one.Sum(nil)
+
+			// Feeding the input byte by byte must fold to the same state.
+			stream := h.new()
+			for i := 0; i < len(s); i++ {
+				stream.Write([]byte{s[i]})
+			}
+			fmt.Printf("%-7s %-14q %x %v\n", h.name, s, sum, bytes.Equal(stream.Sum(nil), sum))
+		}
+	}
+	h := fnv.New32a()
+	h.Write([]byte("foobar"))
+	fmt.Printf("%#08x %d %d\n", h.Sum32(), h.Size(), h.BlockSize())
+}
@@ -1,14 +1,42 @@
 package main
 
 import (
+	"bytes"
 	"crypto/sha512"
 	"fmt"
+	"hash"
 	"io"
 )
 
+func reuse(name string, newHash func() hash.Hash) {
+	msgs := []string{"The fog is getting thicker!", "And Leon's getting laaarger!"}
+	h := newHash()
+	for _, msg := range msgs {
+		h.Reset()
+		io.WriteString(h, msg)
+		got := h.Sum(nil)
+		fresh := newHash()
+		io.WriteString(fresh, msg)
+		want := fresh.Sum(nil)
+		fmt.Printf("%s reuse %q: %x %v\n", name, msg, got, bytes.Equal(got, want))
+	}
+
+	// Sum must not change the underlying hash state.
+	h.Reset()
+	io.WriteString(h, msgs[0])
+	h.Sum(nil)
+	io.WriteString(h, msgs[1])
+	fresh := newHash()
+	io.WriteString(fresh, msgs[0]+msgs[1])
+	fmt.Printf("%s sum then write: %v\n", name, bytes.Equal(h.Sum(nil), fresh.Sum(nil)))
+}
+
 func main() {
 	h := sha512.New()
 	io.WriteString(h, "The fog is getting thicker!")
 	io.WriteString(h, "And Leon's getting laaarger!")
-	fmt.Printf("%x", h.Sum(nil))
+	fmt.Printf("%x\n", h.Sum(nil))
+
+	reuse("sha512", sha512.New)
+	reuse("sha384", sha512.New384)
 }
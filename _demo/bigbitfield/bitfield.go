@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+)
+
+func main() {
+	// 0x0123456789abcdef_fedcba9876543210: fields spanning the word boundary.
+	x, _ := new(big.Int).SetString("0123456789abcdeffedcba9876543210", 16)
+	var f big.Int
+	fields := []struct{ shift, width uint }{
+		{0, 8}, {60, 8}, {56, 16}, {32, 64}, {100, 64}, {128, 8},
+	}
+	for _, fd := range fields {
+		fmt.Printf("x[%d:+%d] = %s\n", fd.shift, fd.width, f.BitField(x, fd.shift, fd.width).Text(16))
+	}
+
+	// Negative values read as two's complement.
+	y := big.NewInt(-2)
+	fmt.Println(f.BitField(y, 0, 4), f.BitField(y, 1, 4), f.BitField(y, 100, 4))
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"time"
+)
+
+func randInt(rnd *rand.Rand, bytes int) *big.Int {
+	buf := make([]byte, bytes)
+	rnd.Read(buf)
+	return new(big.Int).SetBytes(buf)
+}
+
+func main() {
+	rnd := rand.New(rand.NewSource(1))
+	mod := randInt(rnd, 256)
+	mod.Lsh(mod, 1).Add(mod, big.NewInt(1)) // Montgomery reduction needs an odd modulus
+	exp := big.NewInt(65537)
+	bases := make([]*big.Int, 1000)
+	for i := range bases {
+		bases[i] = randInt(rnd, 256)
+	}
+
+	start := time.Now()
+	want := make([]*big.Int, len(bases))
+	for i, x := range bases {
+		want[i] = new(big.Int).Exp(x, exp, mod)
+	}
+	loop := time.Since(start)
+
+	start = time.Now()
+	got := big.ExpModBatch(bases, exp, mod)
+	batch := time.Since(start)
+
+	for i := range got {
+		if got[i].Cmp(want[i]) != 0 {
+			fmt.Println("mismatch at", i)
+			return
+		}
+	}
+	fmt.Printf("%d exponentiations: Exp loop %v, ExpModBatch %v\n", len(bases), loop, batch)
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"runtime"
+)
+
+// Every iteration allocates a 4 KiB BIGNUM outside the Go heap: without
+// finalizers or Free the loops below would leak about 800 MiB.
+func main() {
+	one := big.NewInt(1)
+	for i := 0; i < 100000; i++ {
+		x := new(big.Int).Lsh(one, 32768)
+		if i%10000 == 0 {
+			runtime.GC()
+		}
+		_ = x
+	}
+	for i := 0; i < 100000; i++ {
+		x := new(big.Int).Lsh(one, 32768)
+		x.Free()
+	}
+
+	x := new(big.Int).Lsh(one, 100)
+	x.Free()
+	fmt.Println(x)
+	fmt.Println(x.Add(x, one))
+}
+
+/* Expected output:
+0
+1
+*/
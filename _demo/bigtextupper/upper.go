@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+)
+
+func main() {
+	x, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	for _, base := range []int{10, 16, 36, 62} {
+		fmt.Println(base, x.Text(base), x.TextUpper(base))
+	}
+	fmt.Println(big.NewInt(0xcafe).TextUpper(16), (*big.Int)(nil).TextUpper(16))
+}
+
+/* Expected output:
+10 -123456789012345678901234567890 -123456789012345678901234567890
+16 -18ee90ff6c373e0ee4e3f0ad2 -18EE90FF6C373E0EE4E3F0AD2
+36 -byw97um9s91dlz68tsi -BYW97UM9S91DLZ68TSI
+62 -2AyLS9BKAMjjsWHR0 -2AyLS9BKAMjjsWHR0
+CAFE <nil>
+*/
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+const script = `
+def add(a, b):
+    return a + b
+
+def greet(name, greeting="hello", *rest, loud=False):
+    pass
+
+class Counter:
+    def incr(self, by):
+        pass
+`
+
+func main() {
+	globals := py.NewDict()
+	defer globals.DecRef()
+	py.RunString(c.Str(script), py.FileInput, globals, globals)
+	for _, expr := range []string{
+		"add", "greet", "lambda: 0", "Counter.incr", "Counter().incr",
+		"len", "print", "Counter", "[].append",
+	} {
+		o := py.RunString(c.AllocaCStr(expr), py.EvalInput, globals, globals)
+		n, ok := o.ArgCount()
+		fmt.Println(expr, n, ok)
+		o.DecRef()
+	}
+	fmt.Println(py.ErrOccurred() == nil)
+}
+
+/* Expected output:
+add 2 true
+greet 2 true
+lambda: 0 0 true
+Counter.incr 2 true
+Counter().incr 1 true
+len 0 false
+print 0 false
+Counter 0 false
+[].append 0 false
+true
+*/
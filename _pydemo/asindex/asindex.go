@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+const classes = `
+class Index:
+    def __index__(self):
+        return 7
+
+class Real:
+    def __float__(self):
+        return 2.5
+`
+
+func main() {
+	globals := py.NewDict()
+	defer globals.DecRef()
+	py.RunString(c.Str(classes), py.FileInput, globals, globals).DecRef()
+	eval := func(expr string) *py.Object {
+		return py.RunString(c.AllocaCStr(expr), py.EvalInput, globals, globals)
+	}
+
+	numpy := py.ImportModule(c.Str("numpy"))
+	defer numpy.DecRef()
+	i64 := numpy.GetAttrString(c.Str("int64")).CallOneArg(py.Long(-42))
+	defer i64.DecRef()
+	fmt.Println(i64.AsIndex())
+	fmt.Println(i64.Float64Checked())
+
+	for _, expr := range []string{"Index()", "True", "2**63 - 1", "2**63", "1.5", "'3'"} {
+		o := eval(expr)
+		v, err := o.AsIndex()
+		fmt.Println(expr, v, err)
+		o.DecRef()
+	}
+
+	real := eval("Real()")
+	defer real.DecRef()
+	fmt.Println(real.Float64Checked())
+	fmt.Println(real.AsIndex())
+	fmt.Println(py.ErrOccurred() == nil)
+}
+
+/* Expected output:
+-42 <nil>
+-42 <nil>
+Index() 7 <nil>
+True 1 <nil>
+2**63 - 1 9223372036854775807 <nil>
+2**63 -1 OverflowError: int too big to convert
+1.5 -1 TypeError: 'float' object cannot be interpreted as an integer
+'3' -1 TypeError: 'str' object cannot be interpreted as an integer
+2.5 <nil>
+-1 TypeError: 'Real' object cannot be interpreted as an integer
+true
+*/
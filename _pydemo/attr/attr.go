@@ -0,0 +1,23 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+	"github.com/goplus/llgo/py/std"
+)
+
+func main() {
+	math := py.ImportModule(c.Str("math"))
+	name := py.Str("pi")
+	fmt.Println("has pi:", math.HasAttr(name), math.HasAttrString("pi"))
+	std.Print(math.GetAttr(name))
+
+	fmt.Println("has nope:", math.HasAttr(py.Str("nope")), math.HasAttrString("nope"))
+	fmt.Println("error set:", py.ErrOccurred() != nil)
+
+	mod := py.ImportModule(c.Str("__main__"))
+	fmt.Println("setattr:", mod.SetAttr(py.Str("answer"), py.Long(42)))
+	std.Print(mod.GetAttrString(c.Str("answer")))
+}
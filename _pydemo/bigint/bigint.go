@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/goplus/llgo/py"
+	"github.com/goplus/llgo/py/std"
+)
+
+func main() {
+	x, _ := new(big.Int).SetString("-"+strings.Repeat("1234567890", 20), 10)
+	o := py.LongFromBigInt(x)
+	std.Print(o)
+	y := py.LongToBigInt(o)
+	fmt.Println(len(y.String()), y.Cmp(x) == 0)
+
+	// Well past Python's 4300-digit limit on decimal conversion.
+	huge := new(big.Int).Exp(big.NewInt(7), big.NewInt(20000), nil)
+	fmt.Println(py.LongToBigInt(py.LongFromBigInt(huge)).Cmp(huge) == 0)
+
+	fmt.Println(py.LongToBigInt(py.Long(0)), py.LongToBigInt(py.Str("12")))
+	py.ErrClear()
+}
+
+/* Expected output:
+-12345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890
+201 true
+true
+0 <nil>
+*/
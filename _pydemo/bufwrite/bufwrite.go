@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+const script = `
+import numpy
+a = numpy.arange(4.0)
+frozen = numpy.arange(4.0)
+frozen.flags.writeable = False
+ba = bytearray(b"go")
+`
+
+func main() {
+	globals := py.NewDict()
+	defer globals.DecRef()
+	py.RunString(c.Str(script), py.FileInput, globals, globals)
+
+	// Write into the float64 array in place, then read it in Python.
+	a := eval(globals, "a")
+	defer a.DecRef()
+	buf, err := a.GetBuffer(py.BufWritable | py.BufFormat | py.BufCContiguous)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(buf.ReadOnly(), buf.ItemSize(), buf.Shape())
+	data := buf.Bytes()
+	vals := unsafe.Slice((*float64)(unsafe.Pointer(&data[0])), len(data)/8)
+	for i := range vals {
+		vals[i] = vals[i]*2 + 1
+	}
+	buf.Release()
+	run(globals, "print(a.tolist())")
+
+	// A read-only object can't be exported as writable.
+	for _, name := range []string{"frozen", "b'abc'"} {
+		o := eval(globals, name)
+		_, err := o.GetBuffer(py.BufWritable)
+		fmt.Println(err)
+		o.DecRef()
+	}
+
+	// The object can't be resized until the buffer is released.
+	ba := eval(globals, "ba")
+	defer ba.DecRef()
+	buf, _ = ba.GetBuffer(py.BufWritable)
+	buf.Bytes()[0] = 'G'
+	run(globals, "try:\n    ba.append(33)\nexcept BufferError as e:\n    print(e)")
+	buf.Release()
+	buf.Release()
+	run(globals, "ba.append(33); print(ba)")
+}
+
+func eval(globals *py.Object, expr string) *py.Object {
+	return py.RunString(c.AllocaCStr(expr), py.EvalInput, globals, globals)
+}
+
+func run(globals *py.Object, code string) {
+	py.RunString(c.AllocaCStr(code), py.FileInput, globals, globals).DecRef()
+}
+
+/* Expected output:
+false 8 [4]
+[1.0, 3.0, 5.0, 7.0]
+ValueError: buffer source array is read-only
+BufferError: Object is not writable.
+Existing exports of data: object cannot be re-sized
+bytearray(b'Go!')
+*/
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+func main() {
+	globals := py.NewDict()
+	defer globals.DecRef()
+	eval := func(expr string) *py.Object {
+		return py.RunString(c.AllocaCStr(expr), py.EvalInput, globals, globals)
+	}
+
+	b, err := eval(`b"hello\x00world"`).Bytes()
+	fmt.Printf("%q %v\n", b, err)
+	b, err = eval(`b""`).Bytes()
+	fmt.Println(b != nil, len(b), err)
+	_, err = eval(`"text"`).Bytes()
+	fmt.Println(err)
+
+	// The copy is independent of the bytearray it came from.
+	globals.DictSetItem(py.Str("ba"), eval(`bytearray(b"abc")`))
+	b, err = eval("ba").BufferBytes()
+	eval("ba.__setitem__(0, 0x7a)")
+	now, _ := eval("bytes(ba)").Bytes()
+	fmt.Printf("%q %q %v\n", b, now, err)
+
+	b, err = eval(`memoryview(b"abcdef")[1:4]`).BufferBytes()
+	fmt.Printf("%q %v\n", b, err)
+	_, err = eval(`memoryview(b"abcdef")[::2]`).BufferBytes()
+	fmt.Println(err)
+	_, err = eval("5").BufferBytes()
+	fmt.Println(err)
+}
+
+/* Expected output:
+"hello\x00world" <nil>
+true 0 <nil>
+TypeError: expected bytes, str found
+"abc" "zbc" <nil>
+"bcd" <nil>
+BufferError: memoryview: underlying buffer is not C-contiguous
+TypeError: a bytes-like object is required, not 'int'
+*/
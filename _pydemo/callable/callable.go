@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+const script = `
+class Greeter:
+    greeting = "hello"
+    def greet(self):
+        return self.greeting
+
+class Adder:
+    def __call__(self, x):
+        return x + 1
+
+def f():
+    pass
+
+g = Greeter()
+`
+
+func main() {
+	globals := py.NewDict()
+	defer globals.DecRef()
+	py.RunString(c.Str(script), py.FileInput, globals, globals)
+	for _, expr := range []string{
+		"f", "g.greet", "Greeter", "Adder()", "len", "(lambda: 0)",
+		"42", "g.greeting", "g", "None",
+	} {
+		o := py.RunString(c.AllocaCStr(expr), py.EvalInput, globals, globals)
+		fmt.Println(expr, o.Callable())
+		o.DecRef()
+	}
+}
+
+/* Expected output:
+f true
+g.greet true
+Greeter true
+Adder() true
+len true
+(lambda: 0) true
+42 false
+g.greeting false
+g false
+None false
+*/
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+const funcs = `
+import time
+
+def spin():
+    n = 0
+    while True:
+        n += 1
+
+def quick(x):
+    return x * 2
+
+def fail():
+    raise ValueError("bad input")
+
+def handled():
+    try:
+        spin()
+    except TimeoutError:
+        return "cleaned up"
+
+def sleepy():
+    time.sleep(0.3)
+    spin()
+`
+
+func main() {
+	py.Initialize()
+	mod := py.AddModule(c.Str("__main__"))
+	py.RunString(c.Str(funcs), py.FileInput, mod.ModuleGetDict(), mod.ModuleGetDict()).DecRef()
+	call := func(name string, args *py.Object) {
+		fn := mod.GetAttrString(c.AllocaCStr(name))
+		defer fn.DecRef()
+		start := time.Now()
+		ret, err := py.CallWithTimeout(fn, args, 100*time.Millisecond)
+		took := time.Since(start).Round(100 * time.Millisecond)
+		if err != nil {
+			fmt.Println(name, err, err == py.ErrTimeout, took)
+			return
+		}
+		s := ret.Str()
+		fmt.Println(name, c.GoString(s.CStr()), took)
+		s.DecRef()
+		ret.DecRef()
+	}
+	empty := py.NewTuple(0)
+	call("spin", empty)
+	call("quick", py.Tuple(21))
+	call("fail", empty)
+	call("handled", empty)
+	// The sleep isn't interrupted, only the loop after it.
+	call("sleepy", empty)
+	call("spin", empty)
+
+	fmt.Println(py.ErrOccurred() == nil)
+	py.Finalize()
+}
+
+/* Expected output:
+spin py: call timed out true 100ms
+quick 42 0s
+fail ValueError: bad input false 0s
+handled cleaned up 100ms
+sleepy py: call timed out true 300ms
+spin py: call timed out true 100ms
+true
+*/
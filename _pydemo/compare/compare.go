@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/py"
+)
+
+func row(a, b *py.Object) {
+	fmt.Println(a.Lt(b), a.Le(b), a.Eq(b), a.Ne(b), a.Gt(b), a.Ge(b))
+}
+
+func main() {
+	row(py.Long(1), py.Long(2))
+	row(py.Long(2), py.Long(2))
+	row(py.Float(2.5), py.Long(2))
+	row(py.Str("apple"), py.Str("banana"))
+	row(py.Str("pear"), py.Str("pear"))
+
+	// "a" < 1 raises a TypeError: the operators return false, and Compare
+	// reports the error.
+	row(py.Str("a"), py.Long(1))
+	fmt.Println(py.ErrOccurred() == nil)
+	fmt.Println(py.Str("a").Compare(py.Long(1), py.LT))
+	fmt.Println(py.Str("a").Compare(py.Long(1), py.NE))
+}
+
+/* Expected output:
+true true false true false false
+false true true false false true
+false false false true true true
+true true false true false false
+false true true false false true
+false false false true false false
+true
+false TypeError: '<' not supported between instances of 'str' and 'int'
+true <nil>
+*/
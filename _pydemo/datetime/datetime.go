@@ -0,0 +1,19 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/goplus/llgo/py"
+	"github.com/goplus/llgo/py/std"
+)
+
+func main() {
+	t := time.Date(2024, time.July, 9, 13, 45, 30, 123456789, time.FixedZone("CST", 8*3600))
+	dt := py.NewDateTime(t)
+	std.Print(dt)
+
+	back := dt.Time()
+	fmt.Println(back)
+	fmt.Println("round trip:", back.Equal(t.Truncate(time.Microsecond)))
+}
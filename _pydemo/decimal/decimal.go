@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+func main() {
+	for _, s := range []string{"0.1", "123456789012345678.901234567890", "-12.50", "1E+30"} {
+		d := py.NewDecimal(s)
+		fmt.Println(d.DecimalString(), d.DecimalString() == s)
+	}
+
+	// Arithmetic stays exact, unlike with floats: 0.1 + 0.2 == 0.3.
+	sum := py.NewDecimal("0.1").CallMethodObjArgs(py.Str("__add__"), py.NewDecimal("0.2"), (*py.Object)(nil))
+	fmt.Println(sum.DecimalString(), sum.Eq(py.NewDecimal("0.3")))
+	fsum := py.Float(0.1).CallMethodObjArgs(py.Str("__add__"), py.Float(0.2), (*py.Object)(nil))
+	fmt.Println(c.GoString(fsum.Str().CStr()))
+
+	// A float only approximates 0.1, and Decimal compares exactly.
+	fmt.Println(py.NewDecimal("0.1").Eq(py.Float(0.1)))
+
+	fmt.Println(py.NewDecimal("12.3.4") == nil)
+	py.ErrPrint()
+}
+
+/* Expected output:
+0.1 true
+123456789012345678.901234567890 true
+-12.50 true
+1E+30 true
+0.3 true
+0.30000000000000004
+false
+true
+decimal.InvalidOperation: [<class 'decimal.ConversionSyntax'>]
+*/
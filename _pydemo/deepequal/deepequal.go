@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+const script = `
+class Point:
+    # No __eq__: == only tests identity.
+    def __init__(self, x, y):
+        self.x, self.y = x, y
+    def __repr__(self):
+        return f"Point({self.x}, {self.y})"
+
+class Opaque:
+    pass
+
+class Strict:
+    def __eq__(self, other):
+        raise TypeError("no comparison")
+    def __repr__(self):
+        return "Strict()"
+
+o = Opaque()
+`
+
+func main() {
+	globals := py.NewDict()
+	defer globals.DecRef()
+	py.RunString(c.Str(script), py.FileInput, globals, globals)
+	for _, pair := range [][2]string{
+		{"[1, 2.0]", "[1, 2]"},
+		{"{'a': (1, 'x')}", "{'a': (1, 'x')}"},
+		{"[1]", "(1,)"},
+		{"Point(1, 2)", "Point(1, 2)"},
+		{"Point(1, 2)", "Point(2, 1)"},
+		{"Point(1, 2)", "'Point(1, 2)'"},
+		{"Opaque()", "Opaque()"},
+		{"o", "o"},
+		{"Strict()", "Strict()"},
+	} {
+		a := py.RunString(c.AllocaCStr(pair[0]), py.EvalInput, globals, globals)
+		b := py.RunString(c.AllocaCStr(pair[1]), py.EvalInput, globals, globals)
+		fmt.Println(pair[0], pair[1], py.DeepEqual(a, b))
+		a.DecRef()
+		b.DecRef()
+	}
+	fmt.Println(py.ErrOccurred() == nil)
+}
+
+/* Expected output:
+[1, 2.0] [1, 2] true
+{'a': (1, 'x')} {'a': (1, 'x')} true
+[1] (1,) false
+Point(1, 2) Point(1, 2) true
+Point(1, 2) Point(2, 1) false
+Point(1, 2) 'Point(1, 2)' false
+Opaque() Opaque() false
+o o true
+Strict() Strict() true
+true
+*/
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+const source = `
+import math as _math
+
+def greet(name):
+    return "hello " + name
+
+def area(r):
+    return _math.pi * r * r
+
+class Shape:
+    sides = 0
+    def describe(self):
+        return "shape"
+
+VERSION = "1.0"
+`
+
+func main() {
+	mod := py.AddModule(c.Str("shapes"))
+	dict := mod.ModuleGetDict()
+	ret := py.RunString(c.Str(source), py.FileInput, dict, dict)
+	if ret == nil {
+		py.ErrPrint()
+		return
+	}
+	ret.DecRef()
+
+	fmt.Println(public(mod.Dir()))
+
+	shape := mod.GetAttrString(c.Str("Shape"))
+	defer shape.DecRef()
+	fmt.Println(public(shape.Dir()))
+
+	names := py.Long(42).Dir()
+	fmt.Println(len(names) > 0, contains(names, "bit_length"), contains(names, "__add__"))
+}
+
+// public returns the names that don't start with an underscore.
+func public(names []string) []string {
+	var ret []string
+	for _, name := range names {
+		if !strings.HasPrefix(name, "_") {
+			ret = append(ret, name)
+		}
+	}
+	return ret
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+/* Expected output:
+[Shape VERSION area greet]
+[describe sides]
+true true true
+*/
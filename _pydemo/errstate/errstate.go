@@ -0,0 +1,23 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/_pydemo/errstate/newmath"
+	"github.com/goplus/llgo/py"
+	"github.com/goplus/llgo/py/std"
+)
+
+func main() {
+	// Loading the math symbols fails to find no_such_function; that must
+	// not leave an exception behind for the calls that follow.
+	std.Print(newmath.Sqrt(py.Float(2)))
+	fmt.Println("error pending:", py.ErrOccurred() != nil)
+
+	// A failure reported as a Go error doesn't affect the next call either.
+	_, err := py.List(1, 2).Hash()
+	fmt.Println(err)
+	h, err := py.Str("ok").Hash()
+	fmt.Println(h != -1, err)
+	std.Print(py.List(1, 2))
+}
@@ -0,0 +1,17 @@
+// Package newmath binds a function the math module doesn't have, like
+// bindings generated for a newer Python version would.
+package newmath
+
+import (
+	_ "unsafe"
+
+	"github.com/goplus/llgo/py"
+)
+
+const LLGoPackage = "py.math"
+
+//go:linkname Sqrt py.sqrt
+func Sqrt(x *py.Object) *py.Object
+
+//go:linkname NoSuchFunction py.no_such_function
+func NoSuchFunction(x *py.Object) *py.Object
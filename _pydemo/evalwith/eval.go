@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/py"
+	"github.com/goplus/llgo/py/std"
+)
+
+func main() {
+	vars := map[string]*py.Object{
+		"x": py.Long(6),
+		"y": py.Long(7),
+	}
+	ret, err := py.EvalWith("x*y+1", vars)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	std.Print(ret)
+
+	vars["xs"] = py.List(1, 2, 3)
+	ret, err = py.EvalWith("sum(v*x for v in xs)", vars)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	std.Print(ret)
+
+	_, err = py.EvalWith("x/z", vars)
+	fmt.Println(err)
+	_, err = py.EvalWith("x/(y-7)", vars)
+	fmt.Println(err)
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+func main() {
+	numpy := py.ImportModule(c.Str("numpy"))
+	f32 := numpy.GetAttrString(c.Str("float32")).CallOneArg(py.Float(1.5))
+	fmt.Println(f32.Float64Checked())
+
+	fmt.Println(py.Long(42).Float64Checked())
+
+	huge := py.LongFromCStr(c.AllocaCStr("1"+strings.Repeat("0", 400)), nil, 10)
+	fmt.Println(huge.Float64Checked())
+
+	fmt.Println(py.Str("1.5").Float64Checked())
+	fmt.Println("error cleared:", py.ErrOccurred() == nil)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+func main() {
+	in := map[string]any{
+		"name":  "llgo",
+		"ids":   []any{int64(1), int64(2), int64(3)},
+		"empty": []any{},
+		"meta": map[string]any{
+			"ok":    true,
+			"ratio": 0.5,
+			"none":  nil,
+			"tags":  []any{"a", "b"},
+		},
+		"raw": []byte{1, 2},
+	}
+	o, err := py.FromGo(in)
+	fmt.Println(err)
+	fmt.Println(c.GoString(o.GetAttrString(c.Str("__class__")).GetAttrString(c.Str("__name__")).CStr()), o.DictSize())
+
+	out, err := o.ToGo()
+	fmt.Println(err, reflect.DeepEqual(in, out))
+
+	// Go ints of any size become Python ints, and so int64s on the way back.
+	o, _ = py.FromGo([]any{int8(-1), uint64(1 << 63), float32(0.25)})
+	fmt.Println(c.GoString(o.Str().CStr()))
+
+	for _, v := range []any{struct{}{}, []int{1}, map[string]any{"x": []any{1, make(chan int)}}} {
+		_, err := py.FromGo(v)
+		fmt.Println(err)
+	}
+}
+
+/* Expected output:
+<nil>
+dict 5
+<nil> true
+[-1, 9223372036854775808, 0.25]
+py: cannot convert struct {} to a Python object
+py: cannot convert []int to a Python object
+py: cannot convert chan int to a Python object
+*/
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+const (
+	rounds  = 50
+	workers = 20
+)
+
+// Starts and ends rounds*workers goroutines calling Python, which release
+// their thread state in different ways, then checks that the interpreter
+// can still be used and finalized.
+func main() {
+	py.Initialize()
+	py.RunSimpleString(c.Str(`
+calls = 0
+def work(n):
+    global calls
+    calls += 1
+    return sum(range(n))
+`))
+	mod := py.AddModule(c.Str("__main__"))
+	work := mod.GetAttrString(c.Str("work"))
+
+	var bad atomic.Int32
+	call := func(i int) {
+		ret := work.CallOneArg(py.Long(c.Long(i)))
+		if ret == nil || ret.Long() != c.Long(i*(i-1)/2) {
+			bad.Add(1)
+		}
+		if ret != nil {
+			ret.DecRef()
+		}
+	}
+
+	tstate := py.SaveThread() // let the goroutines take the GIL
+	for r := 0; r < rounds; r++ {
+		var wg sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			if i%4 == 3 {
+				// Leaves GILEnsure unmatched: released as the thread exits.
+				go func(i int) {
+					defer wg.Done()
+					py.GILEnsure()
+					call(i)
+				}(i)
+				continue
+			}
+			go func(i int) {
+				defer wg.Done()
+				defer py.ReleaseThreadState()
+				g := py.GILEnsure()
+				switch i % 4 {
+				case 0: // matched
+					call(i)
+					py.GILRelease(g)
+				case 1: // nested, the inner call left unmatched
+					py.GILEnsure()
+					call(i)
+				case 2: // panics while holding the GIL
+					defer func() { recover() }()
+					call(i)
+					panic("boom")
+				}
+			}(i)
+		}
+		wg.Wait()
+	}
+	py.RestoreThread(tstate)
+
+	calls := mod.GetAttrString(c.Str("calls"))
+	fmt.Println("calls:", calls.Long(), "bad:", bad.Load())
+	calls.DecRef()
+	work.DecRef()
+	py.Finalize()
+	fmt.Println("finalized")
+}
+
+/* Expected output:
+calls: 1000 bad: 0
+finalized
+*/
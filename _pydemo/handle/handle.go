@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/py"
+)
+
+type account struct {
+	Name    string
+	Balance int
+	Tags    []string
+}
+
+func main() {
+	acct := &account{"alice", 42, []string{"vip"}}
+	h := py.NewHandle(acct)
+
+	// Let Python store the handle and hand it back.
+	ret, err := py.EvalWith("{'acct': h}['acct']", map[string]*py.Object{"h": h})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	back, ok := ret.HandleValue().(*account)
+	fmt.Println(ok, back == acct, back.Name, back.Balance, back.Tags)
+
+	fmt.Println(py.Str("not a handle").HandleValue())
+	ret.DecRef()
+	h.DecRef()
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/py"
+)
+
+func main() {
+	a := py.Str("hello")
+	b := py.FromGoString("hel" + "lo")
+	ha, errA := a.Hash()
+	hb, errB := b.Hash()
+	fmt.Println("equal strings hash equally:", ha == hb, errA, errB)
+
+	list := py.List(1, 2)
+	_, err := list.Hash()
+	fmt.Println("list:", err)
+	fmt.Println("error cleared:", py.ErrOccurred() == nil)
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+func name(o *py.Object) string {
+	return c.GoString(o.GetAttrString(c.Str("__name__")).CStr())
+}
+
+func main() {
+	// import email.mime: the top-level package is returned.
+	mod := py.ImportFrom("email.mime", nil, nil, nil, 0)
+	fmt.Println(name(mod))
+
+	// from os.path import join
+	mod = py.ImportFrom("os.path", nil, nil, py.List("join"), 0)
+	join := mod.GetAttrString(c.Str("join"))
+	fmt.Println(c.GoString(join.CallFunctionObjArgs(py.Str("a"), py.Str("b"), (*py.Object)(nil)).CStr()))
+
+	// from .decoder import JSONDecodeError, inside the json package.
+	globals := py.NewDict()
+	globals.DictSetItem(py.Str("__package__"), py.Str("json"))
+	mod = py.ImportFrom("decoder", globals, nil, py.List("JSONDecodeError"), 1)
+	fmt.Println(name(mod), name(mod.GetAttrString(c.Str("JSONDecodeError"))))
+
+	// A relative import from a script has no package to resolve against.
+	globals = py.NewDict()
+	globals.DictSetItem(py.Str("__name__"), py.Str("__main__"))
+	mod = py.ImportFrom("decoder", globals, nil, nil, 1)
+	fmt.Println(mod == nil)
+	py.ErrPrint()
+}
+
+/* Expected output:
+email
+a/b
+json.decoder JSONDecodeError
+true
+ImportError: attempted relative import with no known parent package
+*/
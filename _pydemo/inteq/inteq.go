@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+func main() {
+	big1 := py.LongFromCStr(c.Str("123456789012345678901234567890"), nil, 10)
+	big2 := py.LongFromCStr(c.Str("123456789012345678901234567890"), nil, 10)
+	big3 := py.LongFromCStr(c.Str("123456789012345678901234567891"), nil, 10)
+	tests := []struct {
+		name string
+		a, b *py.Object
+	}{
+		{"small", py.Long(7), py.Long(7)},
+		{"small ne", py.Long(7), py.Long(8)},
+		{"uncached", py.Long(1 << 40), py.Long(1 << 40)},
+		{"negative", py.Long(-1 << 40), py.Long(1 << 40)},
+		{"big", big1, big2},
+		{"big ne", big1, big3},
+		{"big vs small", big1, py.Long(7)},
+		{"int vs float", py.Long(3), py.Float(3)},
+		{"int vs str", py.Long(3), py.Str("3")},
+	}
+	for _, tt := range tests {
+		fmt.Println(tt.name, tt.a.Eq(tt.b), tt.a.Ne(tt.b))
+	}
+}
+
+/* Expected output:
+small true false
+small ne false true
+uncached true false
+negative false true
+big true false
+big ne false true
+big vs small false true
+int vs float true false
+int vs str false true
+*/
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+const script = `
+import collections, enum
+
+class MyList(list):
+    pass
+
+class Color(enum.IntEnum):
+    RED = 1
+
+Point = collections.namedtuple("Point", "x y")
+`
+
+func main() {
+	globals := py.NewDict()
+	defer globals.DecRef()
+	py.RunString(c.Str(script), py.FileInput, globals, globals)
+	for _, expr := range []string{
+		"[1, 2]", "MyList()", "(1, 2)", "Point(1, 2)", "{}",
+		"collections.OrderedDict()", "'go'", "b'go'", "bytearray(b'go')",
+		"42", "Color.RED", "True", "1.5", "None",
+	} {
+		o := py.RunString(c.AllocaCStr(expr), py.EvalInput, globals, globals)
+		fmt.Println(expr, o.IsList(), o.IsTuple(), o.IsDict(), o.IsStr(),
+			o.IsBytes(), o.IsInt(), o.IsFloat(), o.IsBool())
+		o.DecRef()
+	}
+}
+
+/* Expected output:
+[1, 2] true false false false false false false false
+MyList() true false false false false false false false
+(1, 2) false true false false false false false false
+Point(1, 2) false true false false false false false false
+{} false false true false false false false false
+collections.OrderedDict() false false true false false false false false
+'go' false false false true false false false false
+b'go' false false false false true false false false
+bytearray(b'go') false false false false false false false false
+42 false false false false false true false false
+Color.RED false false false false false true false false
+True false false false false false true false true
+1.5 false false false false false false true false
+None false false false false false false false false
+*/
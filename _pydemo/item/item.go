@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+func main() {
+	globals := py.NewDict()
+	defer globals.DecRef()
+	eval := func(expr string) *py.Object {
+		return py.RunString(c.AllocaCStr(expr), py.EvalInput, globals, globals)
+	}
+	str := func(o *py.Object) string {
+		s := o.Str()
+		defer s.DecRef()
+		return c.GoString(s.CStr())
+	}
+	list := eval("[10, 20, 30]")
+	defer list.DecRef()
+	dict := eval("{'a': 1, 'b': 2}")
+	defer dict.DecRef()
+
+	// The same accessor indexes the list and looks up the dict.
+	get := func(o *py.Object, key any) {
+		v, err := o.Item(key)
+		if err != nil {
+			fmt.Println(key, err)
+			return
+		}
+		fmt.Println(key, str(v))
+		v.DecRef()
+	}
+	get(list, 0)
+	get(list, -1)
+	get(dict, "b")
+	get(list, 3)
+	get(dict, "z")
+	get(list, "a")
+	get(dict, 1.5)
+
+	v := py.Long(99)
+	defer v.DecRef()
+	fmt.Println(list.SetItem(1, v), dict.SetItem("c", v), dict.SetItem(0, v))
+	fmt.Println(list.SetItem(5, v))
+	fmt.Println(str(list), str(dict))
+	fmt.Println(py.ErrOccurred() == nil)
+}
+
+/* Expected output:
+0 10
+-1 30
+b 2
+3 IndexError: list index out of range
+z KeyError: 'z'
+a TypeError: list indices must be integers or slices, not str
+1.5 py: cannot use float64 as an item key, want int, string or *Object
+<nil> <nil> <nil>
+IndexError: list assignment index out of range
+[10, 99, 30] {'a': 1, 'b': 2, 'c': 99, 0: 99}
+true
+*/
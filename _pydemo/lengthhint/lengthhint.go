@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+const script = `
+class Hinted:
+    def __iter__(self):
+        return iter(range(5))
+    def __length_hint__(self):
+        return 5
+
+class BadHint:
+    def __length_hint__(self):
+        raise ValueError("no idea")
+
+def gen():
+    yield 1
+`
+
+func main() {
+	globals := py.NewDict()
+	defer globals.DecRef()
+	py.RunString(c.Str(script), py.FileInput, globals, globals)
+	for _, expr := range []string{
+		"[1, 2, 3]", "iter([1, 2, 3, 4])", "range(10)", "Hinted()", "gen()", "BadHint()", "42",
+	} {
+		o := py.RunString(c.AllocaCStr(expr), py.EvalInput, globals, globals)
+		fmt.Println(expr, o.LengthHint(-1), py.ErrOccurred() == nil)
+		o.DecRef()
+	}
+}
+
+/* Expected output:
+[1, 2, 3] 3 true
+iter([1, 2, 3, 4]) 4 true
+range(10) 10 true
+Hinted() 5 true
+gen() -1 true
+BadHint() -1 true
+42 -1 true
+*/
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+func show(l *py.Object) {
+	fmt.Println(c.GoString(l.Str().CStr()))
+}
+
+func main() {
+	l := py.NewList(0)
+	for _, s := range []string{"a", "b", "c", "d", "e", "f"} {
+		l.ListAppend(py.FromGoString(s))
+	}
+	show(l.ListSlice(1, 4))
+
+	// l[1:3] = ["x", "y", "z"]
+	repl := py.NewList(0)
+	for _, s := range []string{"x", "y", "z"} {
+		repl.ListAppend(py.FromGoString(s))
+	}
+	l.ListSetSlice(1, 3, repl)
+	show(l)
+
+	// del l[4:6]
+	l.ListSetSlice(4, 6, nil)
+	show(l)
+
+	// del l[0]; del l[-1]
+	l.ListDelItem(0)
+	l.ListDelItem(-1)
+	show(l)
+
+	fmt.Println(l.ListDelItem(10))
+	py.ErrPrint()
+}
+
+/* Expected output:
+['b', 'c', 'd']
+['a', 'x', 'y', 'z', 'd', 'e', 'f']
+['a', 'x', 'y', 'z', 'f']
+['x', 'y', 'z']
+-1
+IndexError: list assignment index out of range
+*/
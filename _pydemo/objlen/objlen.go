@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/py"
+)
+
+func main() {
+	dict := py.NewDict()
+	dict.DictSetItem(py.Str("a"), py.Long(1))
+	dict.DictSetItem(py.Str("b"), py.Long(2))
+	objs := []*py.Object{
+		py.List(1, 2, 3),
+		dict,
+		py.Str("héllo"),
+		py.Long(42),
+	}
+	for _, o := range objs {
+		fmt.Println(o.Len())
+		py.ErrClear()
+	}
+	for _, o := range objs {
+		fmt.Println(o.LenErr())
+	}
+	fmt.Println(py.ErrOccurred() == nil)
+}
+
+/* Expected output:
+3
+2
+5
+-1
+3 <nil>
+2 <nil>
+5 <nil>
+-1 TypeError: object of type 'int' has no len()
+true
+*/
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+const script = `
+import sys
+released = 0
+
+class Tracked:
+    def __del__(self):
+        global released
+        released += 1
+`
+
+func main() {
+	globals := py.NewDict()
+	defer globals.DecRef()
+	py.RunString(c.Str(script), py.FileInput, globals, globals)
+	tracked := globals.DictGetItem(py.Str("Tracked"))
+	sys := globals.DictGetItem(py.Str("sys"))
+
+	// Release drops the reference right away, and only once.
+	obj := tracked.CallNoArgs()
+	obj.IncRef() // keep one for observing the count
+	p := py.Own(obj)
+	fmt.Println("owned:", refcount(sys, obj), p.Object() == obj)
+	p.Release()
+	p.Release()
+	fmt.Println("released:", refcount(sys, obj), p.Object() == nil)
+	obj.DecRef()
+	fmt.Println("deleted:", released(globals))
+
+	// Unreachable Owned values give up their objects once collected.
+	const n = 100
+	ownMany(tracked, n)
+	for i := 0; i < 5; i++ {
+		runtime.GC()
+	}
+	fmt.Println("collected most:", released(globals)-1 >= n*9/10)
+
+	fmt.Println(py.Own(nil) == nil)
+}
+
+//go:noinline
+func ownMany(tracked *py.Object, n int) {
+	for i := 0; i < n; i++ {
+		py.Own(tracked.CallNoArgs())
+	}
+}
+
+// refcount returns sys.getrefcount(o). Called through the C API, it takes o
+// as a borrowed argument, so the count is exact.
+func refcount(sys, o *py.Object) int {
+	ret := sys.CallMethodObjArgs(py.Str("getrefcount"), o, (*py.Object)(nil))
+	defer ret.DecRef()
+	return int(ret.Long())
+}
+
+func released(globals *py.Object) int {
+	return int(globals.DictGetItem(py.Str("released")).Long())
+}
+
+/* Expected output:
+owned: 2 true
+released: 1 true
+deleted: 1
+collected most: true
+true
+*/
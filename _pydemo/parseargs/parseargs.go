@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/py"
+)
+
+func main() {
+	args := eval(`(42, "gopher")`)
+	var n int64
+	var name string
+	err := py.ParseArgs(args, "is", &n, &name)
+	fmt.Println(n, name, err)
+
+	var x float64
+	var o *py.Object
+	err = py.ParseArgs(eval(`(7, [1, 2])`), "dO", &x, &o)
+	fmt.Println(x, o.ListLen(), err)
+
+	fmt.Println(py.ParseArgs(eval(`("42", "gopher")`), "is", &n, &name))
+	fmt.Println(py.ParseArgs(eval(`(42, 3.5)`), "is", &n, &name))
+	fmt.Println(py.ParseArgs(eval(`(1.5, "x")`), "is", &n, &name))
+	fmt.Println(py.ParseArgs(eval(`("x",)`), "d", &x))
+	fmt.Println(py.ParseArgs(eval(`(42,)`), "is", &n, &name))
+	fmt.Println(py.ParseArgs(args, "ss", &n, &name))
+	fmt.Println(py.ParseArgs(args, "iz", &n, &name))
+	fmt.Println(py.ErrOccurred() == nil)
+}
+
+func eval(expr string) *py.Object {
+	ret, err := py.EvalWith(expr, nil)
+	if err != nil {
+		panic(err)
+	}
+	return ret
+}
+
+/* Expected output:
+42 gopher <nil>
+7 2 <nil>
+py.ParseArgs: argument 1: TypeError: 'str' object cannot be interpreted as an integer
+py.ParseArgs: argument 2: TypeError: must be str, not float
+py.ParseArgs: argument 1: TypeError: 'float' object cannot be interpreted as an integer
+py.ParseArgs: argument 1: TypeError: must be real number, not str
+py.UnpackTuple: expected a tuple of 2 items, got 1
+py.ParseArgs: argument 1: format 's' doesn't take a *int64 destination
+py.ParseArgs: argument 2: unsupported format character 'z'
+true
+*/
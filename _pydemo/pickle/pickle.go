@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+func main() {
+	globals := py.NewDict()
+	defer globals.DecRef()
+	eval := func(expr string) *py.Object {
+		return py.RunString(c.AllocaCStr(expr), py.EvalInput, globals, globals)
+	}
+	repr := func(o *py.Object) string {
+		s := o.Repr()
+		defer s.DecRef()
+		return c.GoString(s.CStr())
+	}
+
+	dict := eval(`{"name": "llgo", "tags": ("go", "llvm"), "stars": 42, "ratio": 0.5, "raw": b"\x00\xff", "more": {1, 2}}`)
+	defer dict.DecRef()
+	data, err := dict.Pickle()
+	fmt.Println(len(data) > 0, err)
+
+	// The snapshot is restored to an equal, but distinct, object.
+	restored, err := py.Unpickle(data)
+	fmt.Println(repr(restored), err)
+	fmt.Println(py.DeepEqual(dict, restored), restored != dict)
+	restored.DecRef()
+
+	mod := eval("__import__('sys')")
+	defer mod.DecRef()
+	data, err = mod.Pickle()
+	fmt.Println(data, err)
+
+	_, err = py.Unpickle([]byte("not a pickle"))
+	fmt.Println(err)
+	_, err = py.Unpickle(nil)
+	fmt.Println(err)
+	fmt.Println(py.ErrOccurred() == nil)
+}
+
+/* Expected output:
+true <nil>
+{'name': 'llgo', 'tags': ('go', 'llvm'), 'stars': 42, 'ratio': 0.5, 'raw': b'\x00\xff', 'more': {1, 2}} <nil>
+true true
+[] TypeError: cannot pickle 'module' object
+UnpicklingError: invalid load key, 'n'.
+EOFError: Ran out of input
+true
+*/
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/goplus/llgo/py"
+)
+
+func main() {
+	var pe *py.PyError
+	_, err := py.EvalWith(`int("x")`, nil)
+	fmt.Println(err)
+	fmt.Println(errors.As(err, &pe), pe.Type, pe.Message)
+
+	// errors.As also finds a PyError wrapped by a Go error.
+	var n int64
+	err = py.ParseArgs(eval(`("x",)`), "i", &n)
+	fmt.Println(errors.As(err, &pe), pe.Type)
+
+	// An exception object that was never raised.
+	exc := eval(`KeyError("missing")`)
+	err = py.AsError(exc)
+	exc.DecRef()
+	fmt.Println(err, errors.As(err, &pe), pe.Type)
+	exc = eval(`StopIteration()`)
+	fmt.Printf("%q\n", py.AsError(exc))
+	exc.DecRef()
+	fmt.Println(py.AsError(nil) == nil)
+
+	// Errors of the Go side aren't PyErrors.
+	err = py.ParseArgs(eval(`(1,)`), "z", &n)
+	fmt.Println(err, errors.As(err, &pe))
+}
+
+func eval(expr string) *py.Object {
+	ret, err := py.EvalWith(expr, nil)
+	if err != nil {
+		panic(err)
+	}
+	return ret
+}
+
+/* Expected output:
+ValueError: invalid literal for int() with base 10: 'x'
+true ValueError invalid literal for int() with base 10: 'x'
+true TypeError
+KeyError: 'missing' true KeyError
+"StopIteration"
+true
+py.ParseArgs: argument 1: unsupported format character 'z' false
+*/
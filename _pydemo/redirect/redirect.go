@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+func main() {
+	var out strings.Builder
+	restore := py.RedirectStdout(&out)
+	py.RunSimpleString(c.Str(`print("hello")`))
+	py.RunSimpleString(c.Str(`print("héllo", 42, sep=", ", end="!\n")`))
+	restore()
+
+	fmt.Printf("captured: %q\n", out.String())
+	py.RunSimpleString(c.Str(`print("back on stdout")`))
+}
+
+/* Expected output:
+captured: "hello\nhéllo, 42!\n"
+back on stdout
+*/
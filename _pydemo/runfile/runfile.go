@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+const greet = `
+def greet(name):
+    return "hello, " + name
+
+message = greet("llgo")
+`
+
+const fail = `
+def check(n):
+    if n > 2:
+        raise ValueError("too big: %d" % n)
+
+for i in range(5):
+    check(i)
+`
+
+func main() {
+	dir, err := os.MkdirTemp("", "runfile")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(dir)
+	write := func(name, src string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+			panic(err)
+		}
+		return path
+	}
+
+	globals := py.NewDict()
+	defer globals.DecRef()
+	_, err = py.RunFile(write("greet.py", greet), globals, nil)
+	fmt.Println(err)
+	message := globals.DictGetItem(py.Str("message"))
+	fmt.Println(c.GoString(message.CStr()))
+
+	_, err = py.RunFile(write("fail.py", fail), nil, nil)
+	fmt.Println(strings.ReplaceAll(err.Error(), dir, "DIR"))
+
+	_, err = py.RunFile(write("syntax.py", "x = (1,\n"), nil, nil)
+	fmt.Println(strings.Contains(err.Error(), `syntax.py", line 1`), strings.Contains(err.Error(), "SyntaxError"))
+
+	_, err = py.RunFile(filepath.Join(dir, "missing.py"), nil, nil)
+	fmt.Println(os.IsNotExist(err))
+}
+
+/* Expected output:
+<nil>
+hello, llgo
+Traceback (most recent call last):
+  File "DIR/fail.py", line 7, in <module>
+    check(i)
+  File "DIR/fail.py", line 4, in check
+    raise ValueError("too big: %d" % n)
+ValueError: too big: 3
+true true
+true
+*/
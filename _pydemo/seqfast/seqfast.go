@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+func sumFast(o *py.Object) (sum int64) {
+	f, err := py.AsFast(o)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer f.DecRef()
+	for _, item := range f.FastItems() {
+		sum += int64(item.Long())
+	}
+	return
+}
+
+func sumGetItem(o *py.Object) (sum int64) {
+	for i, n := 0, o.SeqLen(); i < n; i++ {
+		item := o.SeqGetItem(i)
+		sum += int64(item.Long())
+		item.DecRef()
+	}
+	return
+}
+
+func main() {
+	list := py.NewList(0)
+	for i := 0; i < 100000; i++ {
+		item := py.Long(c.Long(i))
+		list.ListAppend(item)
+		item.DecRef()
+	}
+	tuple := py.Tuple(1, 2, 3)
+	fmt.Println(sumFast(list), sumGetItem(list), sumFast(tuple), sumGetItem(tuple))
+
+	_, err := py.AsFast(py.Long(1))
+	fmt.Println(err)
+
+	const rounds = 100
+	start := time.Now()
+	for i := 0; i < rounds; i++ {
+		sumFast(list)
+	}
+	fast := time.Since(start)
+	start = time.Now()
+	for i := 0; i < rounds; i++ {
+		sumGetItem(list)
+	}
+	getItem := time.Since(start)
+	fmt.Printf("%d sums of %d items: FastItems %v, SeqGetItem %v\n", rounds, list.SeqLen(), fast, getItem)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+const script = `
+import collections.abc
+
+class Base: pass
+class Derived(Base): pass
+class Unrelated: pass
+
+class Sized:
+    def __len__(self):
+        return 0
+
+abc_sized = collections.abc.Sized
+unrelated_or_base = (Unrelated, Base)
+`
+
+func main() {
+	globals := py.NewDict()
+	defer globals.DecRef()
+	py.RunString(c.Str(script), py.FileInput, globals, globals)
+	get := func(name string) *py.Object {
+		return globals.DictGetItem(py.FromGoString(name))
+	}
+	base, derived, unrelated := get("Base"), get("Derived"), get("Unrelated")
+
+	fmt.Println(derived.IsSubclass(base), base.IsSubclass(base), base.IsSubclass(derived))
+	fmt.Println(unrelated.IsSubclass(base))
+	fmt.Println(derived.IsSubclass(get("unrelated_or_base")))
+
+	// Subclass hooks of abstract base classes are honoured.
+	fmt.Println(get("Sized").IsSubclass(get("abc_sized")))
+
+	// A non-class argument is false, with the error cleared.
+	obj := derived.CallNoArgs()
+	fmt.Println(obj.IsSubclass(base), py.ErrOccurred() == nil)
+
+	fmt.Println(obj.IsInstance(base), obj.IsInstance(unrelated))
+	fmt.Println(obj.IsInstance(obj), py.ErrOccurred() == nil)
+}
+
+/* Expected output:
+true true false
+false
+true
+true
+false true
+true false
+false true
+*/
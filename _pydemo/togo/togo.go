@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+func main() {
+	globals := py.NewDict()
+	defer globals.DecRef()
+	eval := func(expr string) *py.Object {
+		return py.RunString(c.AllocaCStr(expr), py.EvalInput, globals, globals)
+	}
+
+	v, err := eval(`{"name": "llgo", "ids": [1, 2, 3], "meta": {"ok": True, "ratio": 0.5, "none": None}, "raw": b"\x01\x02", "pair": ("a", -7)}`).ToGo()
+	fmt.Println(err)
+	m := v.(map[string]any)
+	fmt.Println(m["name"], m["ids"], m["meta"], m["raw"], m["pair"])
+	ids := m["ids"].([]any)
+	fmt.Println(ids[0].(int64) + ids[1].(int64) + ids[2].(int64))
+	fmt.Printf("%T %T\n", m["meta"].(map[string]any)["ok"], m["meta"].(map[string]any)["none"])
+
+	for _, expr := range []string{`{1, 2}`, `{1: "a"}`, `[1, 2**64]`, `[1, object()]`} {
+		v, err := eval(expr).ToGo()
+		fmt.Println(v, err)
+	}
+}
+
+/* Expected output:
+<nil>
+llgo [1 2 3] map[none:<nil> ok:true ratio:0.5] [1 2] [a -7]
+6
+bool <nil>
+<nil> py: cannot convert set to a Go value
+<nil> py: cannot convert dict key of type int to a Go string
+<nil> OverflowError: int too big to convert
+<nil> py: cannot convert object to a Go value
+*/
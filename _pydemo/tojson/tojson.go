@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+func main() {
+	globals := py.NewDict()
+	defer globals.DecRef()
+	dict := py.RunString(c.Str(`{"name": "llgo", "tags": ["go", "llvm"], "stars": 42, "ok": True, "none": None}`), py.EvalInput, globals, globals)
+	fmt.Println(dict.ToJSON())
+
+	set := py.RunString(c.Str(`{1, 2, 3}`), py.EvalInput, globals, globals)
+	s, err := set.ToJSON()
+	fmt.Printf("%q %v\n", s, err)
+	fmt.Println(py.ErrOccurred() == nil)
+}
+
+/* Expected output:
+{"name": "llgo", "tags": ["go", "llvm"], "stars": 42, "ok": true, "none": null} <nil>
+"" TypeError: Object of type set is not JSON serializable
+true
+*/
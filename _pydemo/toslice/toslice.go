@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+func main() {
+	globals := py.NewDict()
+	defer globals.DecRef()
+	floats := func(expr string) {
+		o := py.RunString(c.AllocaCStr(expr), py.EvalInput, globals, globals)
+		defer o.DecRef()
+		fmt.Println(py.ToFloat64Slice(o))
+	}
+	strings := func(expr string) {
+		o := py.RunString(c.AllocaCStr(expr), py.EvalInput, globals, globals)
+		defer o.DecRef()
+		fmt.Printf("%q ", expr)
+		fmt.Println(py.ToStringSlice(o))
+	}
+
+	floats("[1.5, 2.25, -3.0]")
+	floats("(1, 2.5, 10**20)")
+	floats("x / 4 for x in range(5)")
+	floats("[]")
+	floats("[1.5, '2.5', 3.5]")
+	floats("[1.0, True]")
+	floats("[1.0, 10**400]")
+	floats("42")
+
+	strings("['a', 'bc', 'déf']")
+	strings("'xyz'")
+	strings("{'k': 1}")
+	strings("['a', b'b']")
+	fmt.Println(py.ErrOccurred() == nil)
+}
+
+/* Expected output:
+[1.5 2.25 -3] <nil>
+[1 2.5 1e+20] <nil>
+[0 0.25 0.5 0.75 1] <nil>
+[] <nil>
+[] py: cannot convert item 1 of type str to a Go float64
+[] py: cannot convert item 1 of type bool to a Go float64
+[] py: item 1: OverflowError: int too large to convert to float
+[] TypeError: py.AsFast: expected an iterable
+"['a', 'bc', 'déf']" [a bc déf] <nil>
+"'xyz'" [x y z] <nil>
+"{'k': 1}" [k] <nil>
+"['a', b'b']" [] py: cannot convert item 1 of type bytes to a Go string
+true
+*/
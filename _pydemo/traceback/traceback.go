@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+const script = `
+def outer():
+    middle()
+
+def middle():
+    inner()
+
+def inner():
+    raise ValueError("three frames deep")
+
+outer()
+`
+
+func main() {
+	globals := py.NewDict()
+	defer globals.DecRef()
+	if py.RunString(c.Str(script), py.FileInput, globals, globals) != nil {
+		fmt.Println("no exception")
+		return
+	}
+	var typ, val, tb *py.Object
+	py.ErrFetch(&typ, &val, &tb)
+	py.ErrNormalizeException(&typ, &val, &tb)
+	fmt.Print(py.FormatTraceback(typ, val, tb))
+	fmt.Print(py.FormatTraceback(typ, val, nil))
+}
+
+/* Expected output:
+Traceback (most recent call last):
+  File "<string>", line 11, in <module>
+  File "<string>", line 3, in outer
+  File "<string>", line 6, in middle
+  File "<string>", line 9, in inner
+ValueError: three frames deep
+ValueError: three frames deep
+*/
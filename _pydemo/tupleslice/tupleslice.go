@@ -0,0 +1,32 @@
+package main
+
+import (
+	"github.com/goplus/llgo/py"
+	"github.com/goplus/llgo/py/std"
+)
+
+func main() {
+	t := py.Tuple(1, 2, 3, 4, 5)
+	std.Print(t.TupleSlice(1, 3))
+	std.Print(t.TupleSlice(-2, 5))
+	std.Print(t.TupleSlice(0, -1))
+	std.Print(t.TupleSlice(-100, 100))
+	std.Print(t.TupleSlice(4, 2))
+
+	a := py.List(1, 2)
+	b := py.List(py.Str("x"), 3.5)
+	std.Print(a.ListConcat(b))
+	if a.ListConcat(t.TupleSlice(0, 1)) == nil {
+		py.ErrPrint()
+	}
+}
+
+/* Expected output:
+(2, 3)
+(4, 5)
+(1, 2, 3, 4)
+(1, 2, 3, 4, 5)
+()
+[1, 2, 'x', 3.5]
+TypeError: can only concatenate list (not "tuple") to list
+*/
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+	"github.com/goplus/llgo/py/std"
+)
+
+func main() {
+	py.RunSimpleString(c.Str(`
+def stats(xs):
+    return min(xs), max(xs), sum(xs) / len(xs)
+`))
+	mod := py.ImportModule(c.Str("__main__"))
+	stats := mod.GetAttrString(c.Str("stats"))
+	ret := stats.CallOneArg(py.List(3, 1, 4, 1, 5))
+
+	items, err := py.UnpackTuple(ret, 3)
+	if err != nil {
+		fmt.Println("unexpected error:", err)
+		return
+	}
+	std.Print(py.Str("min ="), items[0], py.Str("max ="), items[1], py.Str("mean ="), items[2])
+
+	_, err = py.UnpackTuple(ret, 2)
+	fmt.Println(err)
+	_, err = py.UnpackTuple(py.List(1, 2), 2)
+	fmt.Println(err)
+}
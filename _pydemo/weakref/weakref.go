@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+	"github.com/goplus/llgo/py/std"
+)
+
+func main() {
+	gc := py.ImportModule(c.Str("gc"))
+	collect := gc.GetAttrString(c.Str("collect"))
+
+	s := py.NewSet(py.List(1, 2, 3))
+	ref := py.NewWeakRef(s)
+	std.Print(py.Str("alive:"), ref.WeakRefGet())
+
+	s.DecRef()
+	collect.CallNoArgs()
+	std.Print(py.Str("dropped:"), ref.WeakRefGet())
+
+	fmt.Println("int:", py.NewWeakRef(py.Long(1)) == nil, py.ErrOccurred() != nil)
+	py.ErrClear()
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+const script = `
+class Recorder:
+    def __init__(self, suppress=False):
+        self.log = []
+        self.suppress = suppress
+
+    def __enter__(self):
+        self.log.append("enter")
+        return self
+
+    def __exit__(self, typ, val, tb):
+        self.log.append("exit " + (typ.__name__ + ": " + str(val) if typ else "ok"))
+        return self.suppress
+`
+
+func main() {
+	globals := py.NewDict()
+	defer globals.DecRef()
+	py.RunString(c.Str(script), py.FileInput, globals, globals)
+	recorder := globals.DictGetItem(py.Str("Recorder"))
+
+	cm := recorder.CallNoArgs()
+	err := py.With(cm, func(res *py.Object) error {
+		res.GetAttrString(c.Str("log")).ListAppend(py.Str("body"))
+		return nil
+	})
+	fmt.Println(err)
+	printLog(cm)
+
+	cm = recorder.CallNoArgs()
+	err = py.With(cm, func(res *py.Object) error { return errors.New("boom") })
+	fmt.Println(err)
+	printLog(cm)
+
+	cm = recorder.CallOneArg(py.Long(1))
+	err = py.With(cm, func(res *py.Object) error { return errors.New("ignored") })
+	fmt.Println(err)
+	printLog(cm)
+
+	cm = recorder.CallNoArgs()
+	func() {
+		defer func() { fmt.Println("recovered:", recover()) }()
+		py.With(cm, func(res *py.Object) error { panic("oops") })
+	}()
+	printLog(cm)
+}
+
+func printLog(cm *py.Object) {
+	log := cm.GetAttrString(c.Str("log"))
+	fmt.Println(c.GoString(log.Str().CStr()))
+}
+
+/* Expected output:
+<nil>
+['enter', 'body', 'exit ok']
+boom
+['enter', 'exit RuntimeError: boom']
+<nil>
+['enter', 'exit RuntimeError: ignored']
+recovered: oops
+['enter', 'exit RuntimeError: panic: oops']
+*/
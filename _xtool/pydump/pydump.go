@@ -48,7 +48,7 @@ func main() {
 		if doc != nil {
 			sym.SetItem(c.Str("doc"), cjson.String(doc.CStr()))
 		}
-		if val.Callable() != 0 {
+		if val.Callable() {
 			sig := inspect.Signature(val)
 			sym.SetItem(c.Str("sig"), cjson.String(sig.Str().CStr()))
 		}
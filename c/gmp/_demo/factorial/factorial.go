@@ -0,0 +1,33 @@
+package main
+
+import (
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/c/gmp"
+)
+
+func main() {
+	var f, g, q, r gmp.Int
+	f.Init()
+	g.Init()
+	q.Init()
+	r.Init()
+	defer f.Clear()
+	defer g.Clear()
+	defer q.Clear()
+	defer r.Clear()
+
+	// 1000! has multiplications large enough for GMP's subquadratic algorithms.
+	f.FacUi(1000)
+	c.Printf(c.Str("1000! has %d decimal digits\n"), f.Sizeinbase(10))
+
+	g.FacUi(998)
+	q.TdivQr(&r, &f, &g) // 1000!/998! == 1000*999
+	s := gmp.GetStr(nil, 10, &q)
+	c.Printf(c.Str("1000!/998! = %s, remainder sign %d\n"), s, r.Sgn())
+	c.Free(c.Pointer(s))
+}
+
+/* Expected output:
+1000! has 2568 decimal digits
+1000!/998! = 999000, remainder sign 0
+*/
@@ -0,0 +1,364 @@
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gmp
+
+import (
+	"unsafe"
+
+	"github.com/goplus/llgo/c"
+)
+
+const (
+	LLGoPackage = "link: $(pkg-config --libs gmp); -lgmp"
+)
+
+// The mpz_* names of the GMP API are macros for the __gmpz_* symbols, which
+// are the ones linked below.
+
+// -----------------------------------------------------------------------------
+
+// Int is GMP's mpz_t, a multiple precision signed integer. It must be
+// initialized with Init (or an InitSet* function) before use, and released
+// with Clear.
+type Int struct {
+	alloc c.Int
+	size  c.Int     // number of limbs, negated for a negative value
+	d     c.Pointer // limbs, one unsigned long each on LP64 targets
+}
+
+// void mpz_init(mpz_t x);
+//
+// llgo:link (*Int).Init C.__gmpz_init
+func (*Int) Init() {}
+
+// void mpz_clear(mpz_t x);
+//
+// llgo:link (*Int).Clear C.__gmpz_clear
+func (*Int) Clear() {}
+
+// void mpz_init_set(mpz_t rop, const mpz_t op);
+//
+// llgo:link (*Int).InitSet C.__gmpz_init_set
+func (*Int) InitSet(op *Int) {}
+
+// void mpz_init_set_si(mpz_t rop, signed long int op);
+//
+// llgo:link (*Int).InitSetSi C.__gmpz_init_set_si
+func (*Int) InitSetSi(op c.Long) {}
+
+// int mpz_init_set_str(mpz_t rop, const char *str, int base);
+//
+// llgo:link (*Int).InitSetStr C.__gmpz_init_set_str
+func (*Int) InitSetStr(str *c.Char, base c.Int) c.Int { return 0 }
+
+// void mpz_set(mpz_t rop, const mpz_t op);
+//
+// llgo:link (*Int).Set C.__gmpz_set
+func (*Int) Set(op *Int) {}
+
+// void mpz_set_si(mpz_t rop, signed long int op);
+//
+// llgo:link (*Int).SetSi C.__gmpz_set_si
+func (*Int) SetSi(op c.Long) {}
+
+// void mpz_set_ui(mpz_t rop, unsigned long int op);
+//
+// llgo:link (*Int).SetUi C.__gmpz_set_ui
+func (*Int) SetUi(op c.Ulong) {}
+
+// int mpz_set_str(mpz_t rop, const char *str, int base);
+//
+// llgo:link (*Int).SetStr C.__gmpz_set_str
+func (*Int) SetStr(str *c.Char, base c.Int) c.Int { return 0 }
+
+// void mpz_swap(mpz_t rop1, mpz_t rop2);
+//
+// llgo:link (*Int).Swap C.__gmpz_swap
+func (*Int) Swap(op *Int) {}
+
+// signed long int mpz_get_si(const mpz_t op);
+//
+// llgo:link (*Int).GetSi C.__gmpz_get_si
+func (*Int) GetSi() c.Long { return 0 }
+
+// unsigned long int mpz_get_ui(const mpz_t op);
+//
+// llgo:link (*Int).GetUi C.__gmpz_get_ui
+func (*Int) GetUi() c.Ulong { return 0 }
+
+// char *mpz_get_str(char *str, int base, const mpz_t op);
+//
+// If str is nil, the result is allocated with the GMP allocator and must be
+// released by the caller.
+//
+//go:linkname GetStr C.__gmpz_get_str
+func GetStr(str *c.Char, base c.Int, op *Int) *c.Char
+
+// size_t mpz_sizeinbase(const mpz_t op, int base);
+//
+// llgo:link (*Int).Sizeinbase C.__gmpz_sizeinbase
+func (*Int) Sizeinbase(base c.Int) uintptr { return 0 }
+
+// -----------------------------------------------------------------------------
+
+// void mpz_add(mpz_t rop, const mpz_t op1, const mpz_t op2);
+//
+// llgo:link (*Int).Add C.__gmpz_add
+func (*Int) Add(op1, op2 *Int) {}
+
+// void mpz_add_ui(mpz_t rop, const mpz_t op1, unsigned long int op2);
+//
+// llgo:link (*Int).AddUi C.__gmpz_add_ui
+func (*Int) AddUi(op1 *Int, op2 c.Ulong) {}
+
+// void mpz_sub(mpz_t rop, const mpz_t op1, const mpz_t op2);
+//
+// llgo:link (*Int).Sub C.__gmpz_sub
+func (*Int) Sub(op1, op2 *Int) {}
+
+// void mpz_sub_ui(mpz_t rop, const mpz_t op1, unsigned long int op2);
+//
+// llgo:link (*Int).SubUi C.__gmpz_sub_ui
+func (*Int) SubUi(op1 *Int, op2 c.Ulong) {}
+
+// void mpz_mul(mpz_t rop, const mpz_t op1, const mpz_t op2);
+//
+// llgo:link (*Int).Mul C.__gmpz_mul
+func (*Int) Mul(op1, op2 *Int) {}
+
+// void mpz_mul_ui(mpz_t rop, const mpz_t op1, unsigned long int op2);
+//
+// llgo:link (*Int).MulUi C.__gmpz_mul_ui
+func (*Int) MulUi(op1 *Int, op2 c.Ulong) {}
+
+// void mpz_mul_2exp(mpz_t rop, const mpz_t op1, mp_bitcnt_t op2);
+//
+// llgo:link (*Int).Mul2exp C.__gmpz_mul_2exp
+func (*Int) Mul2exp(op1 *Int, op2 c.Ulong) {}
+
+// void mpz_neg(mpz_t rop, const mpz_t op);
+//
+// llgo:link (*Int).Neg C.__gmpz_neg
+func (*Int) Neg(op *Int) {}
+
+// void mpz_abs(mpz_t rop, const mpz_t op);
+//
+// llgo:link (*Int).Abs C.__gmpz_abs
+func (*Int) Abs(op *Int) {}
+
+// -----------------------------------------------------------------------------
+
+// void mpz_tdiv_qr(mpz_t q, mpz_t r, const mpz_t n, const mpz_t d);
+//
+// llgo:link (*Int).TdivQr C.__gmpz_tdiv_qr
+func (*Int) TdivQr(r, n, d *Int) {}
+
+// void mpz_tdiv_q(mpz_t q, const mpz_t n, const mpz_t d);
+//
+// llgo:link (*Int).TdivQ C.__gmpz_tdiv_q
+func (*Int) TdivQ(n, d *Int) {}
+
+// void mpz_tdiv_r(mpz_t r, const mpz_t n, const mpz_t d);
+//
+// llgo:link (*Int).TdivR C.__gmpz_tdiv_r
+func (*Int) TdivR(n, d *Int) {}
+
+// unsigned long int mpz_tdiv_q_ui(mpz_t q, const mpz_t n, unsigned long int d);
+//
+// llgo:link (*Int).TdivQUi C.__gmpz_tdiv_q_ui
+func (*Int) TdivQUi(n *Int, d c.Ulong) c.Ulong { return 0 }
+
+// unsigned long int mpz_tdiv_ui(const mpz_t n, unsigned long int d);
+//
+// llgo:link (*Int).TdivUi C.__gmpz_tdiv_ui
+func (*Int) TdivUi(d c.Ulong) c.Ulong { return 0 }
+
+// void mpz_fdiv_q_2exp(mpz_t q, const mpz_t n, mp_bitcnt_t b);
+//
+// llgo:link (*Int).FdivQ2exp C.__gmpz_fdiv_q_2exp
+func (*Int) FdivQ2exp(n *Int, b c.Ulong) {}
+
+// void mpz_fdiv_r_2exp(mpz_t r, const mpz_t n, mp_bitcnt_t b);
+//
+// llgo:link (*Int).FdivR2exp C.__gmpz_fdiv_r_2exp
+func (*Int) FdivR2exp(n *Int, b c.Ulong) {}
+
+// void mpz_divexact(mpz_t q, const mpz_t n, const mpz_t d);
+//
+// llgo:link (*Int).Divexact C.__gmpz_divexact
+func (*Int) Divexact(n, d *Int) {}
+
+// void mpz_mod(mpz_t r, const mpz_t n, const mpz_t d);
+//
+// llgo:link (*Int).Mod C.__gmpz_mod
+func (*Int) Mod(n, d *Int) {}
+
+// void mpz_powm(mpz_t rop, const mpz_t base, const mpz_t exp, const mpz_t mod);
+//
+// llgo:link (*Int).Powm C.__gmpz_powm
+func (*Int) Powm(base, exp, mod *Int) {}
+
+// void mpz_powm_sec(mpz_t rop, const mpz_t base, const mpz_t exp, const mpz_t mod);
+//
+// llgo:link (*Int).PowmSec C.__gmpz_powm_sec
+func (*Int) PowmSec(base, exp, mod *Int) {}
+
+// void mpz_pow_ui(mpz_t rop, const mpz_t base, unsigned long int exp);
+//
+// llgo:link (*Int).PowUi C.__gmpz_pow_ui
+func (*Int) PowUi(base *Int, exp c.Ulong) {}
+
+// void mpz_ui_pow_ui(mpz_t rop, unsigned long int base, unsigned long int exp);
+//
+// llgo:link (*Int).UiPowUi C.__gmpz_ui_pow_ui
+func (*Int) UiPowUi(base, exp c.Ulong) {}
+
+// void mpz_sqrt(mpz_t rop, const mpz_t op);
+//
+// llgo:link (*Int).Sqrt C.__gmpz_sqrt
+func (*Int) Sqrt(op *Int) {}
+
+// void mpz_gcd(mpz_t rop, const mpz_t op1, const mpz_t op2);
+//
+// llgo:link (*Int).Gcd C.__gmpz_gcd
+func (*Int) Gcd(op1, op2 *Int) {}
+
+// void mpz_gcdext(mpz_t g, mpz_t s, mpz_t t, const mpz_t a, const mpz_t b);
+//
+// llgo:link (*Int).Gcdext C.__gmpz_gcdext
+func (*Int) Gcdext(s, t, a, b *Int) {}
+
+// void mpz_lcm(mpz_t rop, const mpz_t op1, const mpz_t op2);
+//
+// llgo:link (*Int).Lcm C.__gmpz_lcm
+func (*Int) Lcm(op1, op2 *Int) {}
+
+// int mpz_invert(mpz_t rop, const mpz_t op1, const mpz_t op2);
+//
+// llgo:link (*Int).Invert C.__gmpz_invert
+func (*Int) Invert(op1, op2 *Int) c.Int { return 0 }
+
+// int mpz_jacobi(const mpz_t a, const mpz_t b);
+//
+// llgo:link (*Int).Jacobi C.__gmpz_jacobi
+func (*Int) Jacobi(b *Int) c.Int { return 0 }
+
+// int mpz_probab_prime_p(const mpz_t n, int reps);
+//
+// llgo:link (*Int).ProbabPrimeP C.__gmpz_probab_prime_p
+func (*Int) ProbabPrimeP(reps c.Int) c.Int { return 0 }
+
+// void mpz_fac_ui(mpz_t rop, unsigned long int n);
+//
+// llgo:link (*Int).FacUi C.__gmpz_fac_ui
+func (*Int) FacUi(n c.Ulong) {}
+
+// -----------------------------------------------------------------------------
+
+// int mpz_cmp(const mpz_t op1, const mpz_t op2);
+//
+// llgo:link (*Int).Cmp C.__gmpz_cmp
+func (*Int) Cmp(op *Int) c.Int { return 0 }
+
+// int mpz_cmpabs(const mpz_t op1, const mpz_t op2);
+//
+// llgo:link (*Int).Cmpabs C.__gmpz_cmpabs
+func (*Int) Cmpabs(op *Int) c.Int { return 0 }
+
+// int mpz_cmp_ui(const mpz_t op1, unsigned long int op2);
+//
+// llgo:link (*Int).CmpUi C.__gmpz_cmp_ui
+func (*Int) CmpUi(op c.Ulong) c.Int { return 0 }
+
+// Sgn returns +1 if x > 0, 0 if x == 0, and -1 if x < 0. It is mpz_sgn,
+// which is a macro.
+func (x *Int) Sgn() c.Int {
+	switch {
+	case x.size < 0:
+		return -1
+	case x.size > 0:
+		return 1
+	}
+	return 0
+}
+
+// mp_bitcnt_t mpz_popcount(const mpz_t op);
+//
+// llgo:link (*Int).Popcount C.__gmpz_popcount
+func (*Int) Popcount() c.Ulong { return 0 }
+
+// int mpz_tstbit(const mpz_t op, mp_bitcnt_t bit_index);
+//
+// llgo:link (*Int).Tstbit C.__gmpz_tstbit
+func (*Int) Tstbit(bitIndex c.Ulong) c.Int { return 0 }
+
+// void mpz_setbit(mpz_t rop, mp_bitcnt_t bit_index);
+//
+// llgo:link (*Int).Setbit C.__gmpz_setbit
+func (*Int) Setbit(bitIndex c.Ulong) {}
+
+// void mpz_clrbit(mpz_t rop, mp_bitcnt_t bit_index);
+//
+// llgo:link (*Int).Clrbit C.__gmpz_clrbit
+func (*Int) Clrbit(bitIndex c.Ulong) {}
+
+// mp_bitcnt_t mpz_scan1(const mpz_t op, mp_bitcnt_t starting_bit);
+//
+// llgo:link (*Int).Scan1 C.__gmpz_scan1
+func (*Int) Scan1(startingBit c.Ulong) c.Ulong { return 0 }
+
+// void mpz_com(mpz_t rop, const mpz_t op);
+//
+// llgo:link (*Int).Com C.__gmpz_com
+func (*Int) Com(op *Int) {}
+
+// void mpz_and(mpz_t rop, const mpz_t op1, const mpz_t op2);
+//
+// llgo:link (*Int).And C.__gmpz_and
+func (*Int) And(op1, op2 *Int) {}
+
+// void mpz_ior(mpz_t rop, const mpz_t op1, const mpz_t op2);
+//
+// llgo:link (*Int).Ior C.__gmpz_ior
+func (*Int) Ior(op1, op2 *Int) {}
+
+// void mpz_xor(mpz_t rop, const mpz_t op1, const mpz_t op2);
+//
+// llgo:link (*Int).Xor C.__gmpz_xor
+func (*Int) Xor(op1, op2 *Int) {}
+
+// Wipe overwrites all the limbs allocated for x with zeros, and sets x to 0,
+// so that no trace of a secret value is left in memory when x is cleared.
+func (x *Int) Wipe() {
+	c.Memset(x.d, 0, uintptr(x.alloc)*unsafe.Sizeof(c.Ulong(0)))
+	x.size = 0
+}
+
+// -----------------------------------------------------------------------------
+
+// void *mpz_export(void *rop, size_t *countp, int order, size_t size, int endian, size_t nails, const mpz_t op);
+//
+//go:linkname Export C.__gmpz_export
+func Export(rop c.Pointer, countp *uintptr, order c.Int, size uintptr, endian c.Int, nails uintptr, op *Int) c.Pointer
+
+// void mpz_import(mpz_t rop, size_t count, int order, size_t size, int endian, size_t nails, const void *op);
+//
+// llgo:link (*Int).Import C.__gmpz_import
+func (*Int) Import(count uintptr, order c.Int, size uintptr, endian c.Int, nails uintptr, op c.Pointer) {
+}
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"github.com/goplus/llgo/c/openssl"
+)
+
+var vectors = []struct {
+	in, out string
+}{
+	{"", "9c1185a5c5e9fc54612808977ee8f548b2258d31"},
+	{"a", "0bdc9d2d256b3ee9daae347be6f4dc835a467ffe"},
+	{"abc", "8eb208f7e05d987a9b044a8e98c6b087f15a0bfc"},
+	{"message digest", "5d0689ef49d2fae572b881b123a85ffa21595f36"},
+	{"abcdefghijklmnopqrstuvwxyz", "f71c27109c692c1b56bbdceb5b9d2865b3708dbc"},
+	{strings.Repeat("a", 1000000), "52783243c1697bdbe16d37f97f68f08325dc1528"},
+}
+
+func main() {
+	md := openssl.EVP_ripemd160()
+	h := make([]byte, md.Size())
+	ctx := openssl.NewEVP_MD_CTX()
+	defer ctx.Free()
+	for _, v := range vectors {
+		openssl.EVP_DigestBytes([]byte(v.in), unsafe.SliceData(h), nil, md)
+		oneShot := fmt.Sprintf("%x", h)
+
+		// Stream the input in uneven chunks.
+		ctx.DigestInit(md, nil)
+		for s := v.in; len(s) > 0; {
+			n := 7
+			if n > len(s) {
+				n = len(s)
+			}
+			ctx.DigestUpdateString(s[:n])
+			s = s[n:]
+		}
+		ctx.DigestFinal(unsafe.SliceData(h), nil)
+		streamed := fmt.Sprintf("%x", h)
+
+		fmt.Println(len(v.in), oneShot == v.out, streamed == v.out)
+	}
+}
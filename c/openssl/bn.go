@@ -109,6 +109,25 @@ func (*BIGNUM) Copy(b *BIGNUM) *BIGNUM { return nil }
 // llgo:link (*BIGNUM).Swap C.BN_swap
 func (*BIGNUM) Swap(b *BIGNUM) {}
 
+const (
+	BN_FLG_MALLOCED    = 0x01
+	BN_FLG_STATIC_DATA = 0x02
+	// BN_FLG_CONSTTIME is used to notify BN functions that they are not to
+	// use the faster, but non constant-time, algorithms on this BIGNUM.
+	BN_FLG_CONSTTIME = 0x04
+	BN_FLG_SECURE    = 0x08
+)
+
+// void BN_set_flags(BIGNUM *b, int n);
+//
+// llgo:link (*BIGNUM).SetFlags C.BN_set_flags
+func (*BIGNUM) SetFlags(n c.Int) {}
+
+// int BN_get_flags(const BIGNUM *b, int n);
+//
+// llgo:link (*BIGNUM).GetFlags C.BN_get_flags
+func (*BIGNUM) GetFlags(n c.Int) c.Int { return 0 }
+
 // int BN_is_zero(const BIGNUM *a);
 //
 // llgo:link (*BIGNUM).IsZero C.BN_is_zero
@@ -300,12 +319,12 @@ func (*BIGNUM) Uadd(a, b *BIGNUM) c.Int { return 0 }
 // int BN_mul(BIGNUM *r, const BIGNUM *a, const BIGNUM *b, BN_CTX *ctx);
 //
 // llgo:link (*BIGNUM).Mul C.BN_mul
-func (*BIGNUM) Mul(r, a, b *BIGNUM, ctx *BN_CTX) c.Int { return 0 }
+func (*BIGNUM) Mul(a, b *BIGNUM, ctx *BN_CTX) c.Int { return 0 }
 
 // int BN_sqr(BIGNUM *r, const BIGNUM *a, BN_CTX *ctx);
 //
 // llgo:link (*BIGNUM).Sqr C.BN_sqr
-func (*BIGNUM) Sqr(r, a *BIGNUM, ctx *BN_CTX) c.Int { return 0 }
+func (*BIGNUM) Sqr(a *BIGNUM, ctx *BN_CTX) c.Int { return 0 }
 
 /** BN_set_negative sets sign of a BIGNUM
  * \param  b  pointer to the BIGNUM object
@@ -333,7 +352,7 @@ func (*BIGNUM) Div(rem, m, d *BIGNUM, ctx *BN_CTX) c.Int { return 0 }
 // int BN_nnmod(BIGNUM *r, const BIGNUM *m, const BIGNUM *d, BN_CTX *ctx);
 //
 // llgo:link (*BIGNUM).Nnmod C.BN_nnmod
-func (*BIGNUM) Nnmod(r, m, d *BIGNUM, ctx *BN_CTX) c.Int { return 0 }
+func (*BIGNUM) Nnmod(m, d *BIGNUM, ctx *BN_CTX) c.Int { return 0 }
 
 // int BN_cmp(const BIGNUM *a, const BIGNUM *b);
 //
@@ -345,6 +364,16 @@ func (*BIGNUM) Cmp(b *BIGNUM) c.Int { return 0 }
 // llgo:link (*BIGNUM).Ucmp C.BN_ucmp
 func (*BIGNUM) Ucmp(b *BIGNUM) c.Int { return 0 }
 
+// int BN_num_bits(const BIGNUM *a);
+//
+// llgo:link (*BIGNUM).NumBits C.BN_num_bits
+func (*BIGNUM) NumBits() c.Int { return 0 }
+
+// int BN_num_bytes(const BIGNUM *a);
+func (bn *BIGNUM) NumBytes() c.Int {
+	return (bn.NumBits() + 7) / 8
+}
+
 // int BN_is_bit_set(const BIGNUM *a, int n);
 //
 // llgo:link (*BIGNUM).IsBitSet C.BN_is_bit_set
@@ -360,6 +389,11 @@ func (*BIGNUM) SetBit(n c.Int) c.Int { return 0 }
 // llgo:link (*BIGNUM).ClearBit C.BN_clear_bit
 func (*BIGNUM) ClearBit(n c.Int) c.Int { return 0 }
 
+// int BN_mask_bits(BIGNUM *a, int n);
+//
+// llgo:link (*BIGNUM).MaskBits C.BN_mask_bits
+func (*BIGNUM) MaskBits(n c.Int) c.Int { return 0 }
+
 // int BN_lshift(BIGNUM *r, const BIGNUM *a, int n);
 //
 // llgo:link (*BIGNUM).Lshift C.BN_lshift
@@ -390,6 +424,23 @@ func (*BIGNUM) Exp(a, p *BIGNUM, ctx *BN_CTX) c.Int { return 0 }
 // llgo:link (*BIGNUM).ModExp C.BN_mod_exp
 func (*BIGNUM) ModExp(a, p, m *BIGNUM, ctx *BN_CTX) c.Int { return 0 }
 
+// int BN_mod_exp_mont(BIGNUM *r, const BIGNUM *a, const BIGNUM *p,
+// const BIGNUM *m, BN_CTX *ctx, BN_MONT_CTX *m_ctx);
+//
+// llgo:link (*BIGNUM).ModExpMont C.BN_mod_exp_mont
+func (*BIGNUM) ModExpMont(a, p, m *BIGNUM, ctx *BN_CTX, mont *BN_MONT_CTX) c.Int { return 0 }
+
+// int BN_mod_exp_mont_consttime(BIGNUM *rr, const BIGNUM *a, const BIGNUM *p,
+// const BIGNUM *m, BN_CTX *ctx, BN_MONT_CTX *in_mont);
+//
+// llgo:link (*BIGNUM).ModExpMontConsttime C.BN_mod_exp_mont_consttime
+func (*BIGNUM) ModExpMontConsttime(a, p, m *BIGNUM, ctx *BN_CTX, mont *BN_MONT_CTX) c.Int { return 0 }
+
+// BIGNUM *BN_mod_inverse(BIGNUM *ret, const BIGNUM *a, const BIGNUM *n, BN_CTX *ctx);
+//
+// llgo:link (*BIGNUM).ModInverse C.BN_mod_inverse
+func (*BIGNUM) ModInverse(a, n *BIGNUM, ctx *BN_CTX) *BIGNUM { return nil }
+
 // int BN_gcd(BIGNUM *r, const BIGNUM *a, const BIGNUM *b, BN_CTX *ctx);
 //
 // llgo:link (*BIGNUM).Gcd C.BN_gcd
@@ -400,10 +451,89 @@ func (*BIGNUM) Gcd(a, b *BIGNUM, ctx *BN_CTX) c.Int { return 0 }
 // llgo:link (*BIGNUM).AreCoprime C.BN_are_coprime
 func (*BIGNUM) AreCoprime(b *BIGNUM, ctx *BN_CTX) c.Int { return 0 }
 
+// int BN_kronecker(const BIGNUM *a, const BIGNUM *b, BN_CTX *ctx);
+//
+// llgo:link (*BIGNUM).Kronecker C.BN_kronecker
+func (*BIGNUM) Kronecker(b *BIGNUM, ctx *BN_CTX) c.Int { return 0 }
+
+// -----------------------------------------------------------------------------
+
+type BN_MONT_CTX struct {
+	Unused [0]byte
+}
+
+// BN_MONT_CTX *BN_MONT_CTX_new(void);
+//
+//go:linkname BN_MONT_CTXNew C.BN_MONT_CTX_new
+func BN_MONT_CTXNew() *BN_MONT_CTX
+
+// void BN_MONT_CTX_free(BN_MONT_CTX *mont);
+//
+// llgo:link (*BN_MONT_CTX).Free C.BN_MONT_CTX_free
+func (*BN_MONT_CTX) Free() {}
+
+// int BN_MONT_CTX_set(BN_MONT_CTX *mont, const BIGNUM *mod, BN_CTX *ctx);
+//
+// llgo:link (*BN_MONT_CTX).Set C.BN_MONT_CTX_set
+func (*BN_MONT_CTX) Set(mod *BIGNUM, ctx *BN_CTX) c.Int { return 0 }
+
+// int BN_mod_mul_montgomery(BIGNUM *r, const BIGNUM *a, const BIGNUM *b,
+// BN_MONT_CTX *mont, BN_CTX *ctx);
+//
+// llgo:link (*BIGNUM).ModMulMontgomery C.BN_mod_mul_montgomery
+func (*BIGNUM) ModMulMontgomery(a, b *BIGNUM, mont *BN_MONT_CTX, ctx *BN_CTX) c.Int { return 0 }
+
+// int BN_to_montgomery(BIGNUM *r, const BIGNUM *a, BN_MONT_CTX *mont, BN_CTX *ctx);
+//
+// llgo:link (*BIGNUM).ToMontgomery C.BN_to_montgomery
+func (*BIGNUM) ToMontgomery(a *BIGNUM, mont *BN_MONT_CTX, ctx *BN_CTX) c.Int { return 0 }
+
+// int BN_from_montgomery(BIGNUM *r, const BIGNUM *a, BN_MONT_CTX *mont, BN_CTX *ctx);
+//
+// llgo:link (*BIGNUM).FromMontgomery C.BN_from_montgomery
+func (*BIGNUM) FromMontgomery(a *BIGNUM, mont *BN_MONT_CTX, ctx *BN_CTX) c.Int { return 0 }
+
+// -----------------------------------------------------------------------------
+
+type BN_RECP_CTX struct {
+	Unused [0]byte
+}
+
+// BN_RECP_CTX *BN_RECP_CTX_new(void);
+//
+//go:linkname BN_RECP_CTXNew C.BN_RECP_CTX_new
+func BN_RECP_CTXNew() *BN_RECP_CTX
+
+// void BN_RECP_CTX_free(BN_RECP_CTX *recp);
+//
+// llgo:link (*BN_RECP_CTX).Free C.BN_RECP_CTX_free
+func (*BN_RECP_CTX) Free() {}
+
+// int BN_RECP_CTX_set(BN_RECP_CTX *recp, const BIGNUM *rdiv, BN_CTX *ctx);
+//
+// llgo:link (*BN_RECP_CTX).Set C.BN_RECP_CTX_set
+func (*BN_RECP_CTX) Set(rdiv *BIGNUM, ctx *BN_CTX) c.Int { return 0 }
+
+// int BN_div_recp(BIGNUM *dv, BIGNUM *rem, const BIGNUM *m, BN_RECP_CTX *recp, BN_CTX *ctx);
+//
+// llgo:link (*BIGNUM).DivRecp C.BN_div_recp
+func (*BIGNUM) DivRecp(rem, m *BIGNUM, recp *BN_RECP_CTX, ctx *BN_CTX) c.Int { return 0 }
+
+// int BN_mod_mul_reciprocal(BIGNUM *r, const BIGNUM *x, const BIGNUM *y,
+// BN_RECP_CTX *recp, BN_CTX *ctx);
+//
+// llgo:link (*BIGNUM).ModMulReciprocal C.BN_mod_mul_reciprocal
+func (*BIGNUM) ModMulReciprocal(x, y *BIGNUM, recp *BN_RECP_CTX, ctx *BN_CTX) c.Int { return 0 }
+
 // -----------------------------------------------------------------------------
 
 type BN_GENCB struct {
 	Unused [0]byte
 }
 
+// int BN_check_prime(const BIGNUM *p, BN_CTX *ctx, BN_GENCB *cb);
+//
+// llgo:link (*BIGNUM).CheckPrime C.BN_check_prime
+func (*BIGNUM) CheckPrime(ctx *BN_CTX, cb *BN_GENCB) c.Int { return 0 }
+
 // -----------------------------------------------------------------------------
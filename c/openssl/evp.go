@@ -0,0 +1,232 @@
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openssl
+
+import (
+	"unsafe"
+
+	"github.com/goplus/llgo/c"
+)
+
+// -----------------------------------------------------------------------------
+
+// const EVP_MD *EVP_ripemd160(void);
+//
+//go:linkname EVP_ripemd160 C.EVP_ripemd160
+func EVP_ripemd160() *EVP_MD
+
+// int EVP_MD_get_size(const EVP_MD *md);
+//
+// llgo:link (*EVP_MD).Size C.EVP_MD_get_size
+func (md *EVP_MD) Size() c.Int { return 0 }
+
+// int EVP_MD_get_block_size(const EVP_MD *md);
+//
+// llgo:link (*EVP_MD).BlockSize C.EVP_MD_get_block_size
+func (md *EVP_MD) BlockSize() c.Int { return 0 }
+
+// -----------------------------------------------------------------------------
+
+type EVP_MD_CTX struct {
+	Unused [0]byte
+}
+
+// EVP_MD_CTX *EVP_MD_CTX_new(void);
+//
+//go:linkname NewEVP_MD_CTX C.EVP_MD_CTX_new
+func NewEVP_MD_CTX() *EVP_MD_CTX
+
+// void EVP_MD_CTX_free(EVP_MD_CTX *ctx);
+//
+// llgo:link (*EVP_MD_CTX).Free C.EVP_MD_CTX_free
+func (ctx *EVP_MD_CTX) Free() {}
+
+// int EVP_MD_CTX_reset(EVP_MD_CTX *ctx);
+//
+// llgo:link (*EVP_MD_CTX).Reset C.EVP_MD_CTX_reset
+func (ctx *EVP_MD_CTX) Reset() c.Int { return 0 }
+
+// int EVP_MD_CTX_copy_ex(EVP_MD_CTX *out, const EVP_MD_CTX *in);
+//
+// llgo:link (*EVP_MD_CTX).Copy C.EVP_MD_CTX_copy_ex
+func (ctx *EVP_MD_CTX) Copy(in *EVP_MD_CTX) c.Int { return 0 }
+
+// int EVP_DigestInit_ex(EVP_MD_CTX *ctx, const EVP_MD *type, ENGINE *impl);
+//
+// llgo:link (*EVP_MD_CTX).DigestInit C.EVP_DigestInit_ex
+func (ctx *EVP_MD_CTX) DigestInit(md *EVP_MD, impl unsafe.Pointer) c.Int { return 0 }
+
+// int EVP_DigestUpdate(EVP_MD_CTX *ctx, const void *d, size_t cnt);
+//
+// llgo:link (*EVP_MD_CTX).DigestUpdate C.EVP_DigestUpdate
+func (ctx *EVP_MD_CTX) DigestUpdate(data unsafe.Pointer, n uintptr) c.Int { return 0 }
+
+func (ctx *EVP_MD_CTX) DigestUpdateBytes(data []byte) c.Int {
+	return ctx.DigestUpdate(unsafe.Pointer(unsafe.SliceData(data)), uintptr(len(data)))
+}
+
+func (ctx *EVP_MD_CTX) DigestUpdateString(data string) c.Int {
+	return ctx.DigestUpdate(unsafe.Pointer(unsafe.StringData(data)), uintptr(len(data)))
+}
+
+// int EVP_DigestFinal_ex(EVP_MD_CTX *ctx, unsigned char *md, unsigned int *s);
+//
+// llgo:link (*EVP_MD_CTX).DigestFinal C.EVP_DigestFinal_ex
+func (ctx *EVP_MD_CTX) DigestFinal(md *byte, s *c.Uint) c.Int { return 0 }
+
+// int EVP_Digest(const void *data, size_t count, unsigned char *md,
+// unsigned int *size, const EVP_MD *type, ENGINE *impl);
+//
+//go:linkname EVP_Digest C.EVP_Digest
+func EVP_Digest(data unsafe.Pointer, n uintptr, md *byte, size *c.Uint, typ *EVP_MD, impl unsafe.Pointer) c.Int
+
+func EVP_DigestBytes(data []byte, md *byte, size *c.Uint, typ *EVP_MD) c.Int {
+	return EVP_Digest(unsafe.Pointer(unsafe.SliceData(data)), uintptr(len(data)), md, size, typ, nil)
+}
+
+// -----------------------------------------------------------------------------
+
+type EVP_CIPHER struct {
+	Unused [0]byte
+}
+
+// const EVP_CIPHER *EVP_aes_128_ecb(void);
+//
+//go:linkname EVP_aes_128_ecb C.EVP_aes_128_ecb
+func EVP_aes_128_ecb() *EVP_CIPHER
+
+// const EVP_CIPHER *EVP_aes_192_ecb(void);
+//
+//go:linkname EVP_aes_192_ecb C.EVP_aes_192_ecb
+func EVP_aes_192_ecb() *EVP_CIPHER
+
+// const EVP_CIPHER *EVP_aes_256_ecb(void);
+//
+//go:linkname EVP_aes_256_ecb C.EVP_aes_256_ecb
+func EVP_aes_256_ecb() *EVP_CIPHER
+
+// const EVP_CIPHER *EVP_aes_128_cbc(void);
+//
+//go:linkname EVP_aes_128_cbc C.EVP_aes_128_cbc
+func EVP_aes_128_cbc() *EVP_CIPHER
+
+// const EVP_CIPHER *EVP_aes_192_cbc(void);
+//
+//go:linkname EVP_aes_192_cbc C.EVP_aes_192_cbc
+func EVP_aes_192_cbc() *EVP_CIPHER
+
+// const EVP_CIPHER *EVP_aes_256_cbc(void);
+//
+//go:linkname EVP_aes_256_cbc C.EVP_aes_256_cbc
+func EVP_aes_256_cbc() *EVP_CIPHER
+
+// const EVP_CIPHER *EVP_aes_128_ctr(void);
+//
+//go:linkname EVP_aes_128_ctr C.EVP_aes_128_ctr
+func EVP_aes_128_ctr() *EVP_CIPHER
+
+// const EVP_CIPHER *EVP_aes_192_ctr(void);
+//
+//go:linkname EVP_aes_192_ctr C.EVP_aes_192_ctr
+func EVP_aes_192_ctr() *EVP_CIPHER
+
+// const EVP_CIPHER *EVP_aes_256_ctr(void);
+//
+//go:linkname EVP_aes_256_ctr C.EVP_aes_256_ctr
+func EVP_aes_256_ctr() *EVP_CIPHER
+
+// const EVP_CIPHER *EVP_aes_128_gcm(void);
+//
+//go:linkname EVP_aes_128_gcm C.EVP_aes_128_gcm
+func EVP_aes_128_gcm() *EVP_CIPHER
+
+// const EVP_CIPHER *EVP_aes_192_gcm(void);
+//
+//go:linkname EVP_aes_192_gcm C.EVP_aes_192_gcm
+func EVP_aes_192_gcm() *EVP_CIPHER
+
+// const EVP_CIPHER *EVP_aes_256_gcm(void);
+//
+//go:linkname EVP_aes_256_gcm C.EVP_aes_256_gcm
+func EVP_aes_256_gcm() *EVP_CIPHER
+
+// -----------------------------------------------------------------------------
+
+type EVP_CIPHER_CTX struct {
+	Unused [0]byte
+}
+
+// EVP_CIPHER_CTX *EVP_CIPHER_CTX_new(void);
+//
+//go:linkname NewEVP_CIPHER_CTX C.EVP_CIPHER_CTX_new
+func NewEVP_CIPHER_CTX() *EVP_CIPHER_CTX
+
+// void EVP_CIPHER_CTX_free(EVP_CIPHER_CTX *ctx);
+//
+// llgo:link (*EVP_CIPHER_CTX).Free C.EVP_CIPHER_CTX_free
+func (ctx *EVP_CIPHER_CTX) Free() {}
+
+// int EVP_CIPHER_CTX_set_padding(EVP_CIPHER_CTX *x, int padding);
+//
+// llgo:link (*EVP_CIPHER_CTX).SetPadding C.EVP_CIPHER_CTX_set_padding
+func (ctx *EVP_CIPHER_CTX) SetPadding(padding c.Int) c.Int { return 0 }
+
+// int EVP_CipherInit_ex(EVP_CIPHER_CTX *ctx, const EVP_CIPHER *type,
+// ENGINE *impl, const unsigned char *key, const unsigned char *iv, int enc);
+//
+// enc is 1 to encrypt, 0 to decrypt, or -1 to keep the direction of a
+// previous call. A nil type, key or iv keeps the one already set, so that
+// the IV can be changed without setting up the key again.
+//
+// llgo:link (*EVP_CIPHER_CTX).CipherInit C.EVP_CipherInit_ex
+func (ctx *EVP_CIPHER_CTX) CipherInit(typ *EVP_CIPHER, impl unsafe.Pointer, key, iv *byte, enc c.Int) c.Int {
+	return 0
+}
+
+// int EVP_CipherUpdate(EVP_CIPHER_CTX *ctx, unsigned char *out, int *outl,
+// const unsigned char *in, int inl);
+//
+// llgo:link (*EVP_CIPHER_CTX).CipherUpdate C.EVP_CipherUpdate
+func (ctx *EVP_CIPHER_CTX) CipherUpdate(out *byte, outl *c.Int, in *byte, inl c.Int) c.Int { return 0 }
+
+// CipherUpdateBytes processes in, writing the output to out, which must be
+// at least as long as in for an unpadded cipher.
+func (ctx *EVP_CIPHER_CTX) CipherUpdateBytes(out, in []byte) c.Int {
+	var outl c.Int
+	return ctx.CipherUpdate(unsafe.SliceData(out), &outl, unsafe.SliceData(in), c.Int(len(in)))
+}
+
+// int EVP_CipherFinal_ex(EVP_CIPHER_CTX *ctx, unsigned char *outm, int *outl);
+//
+// For an AEAD cipher such as GCM, decryption fails here, without queuing
+// an error, if the tag set with EVP_CTRL_AEAD_SET_TAG doesn't match.
+//
+// llgo:link (*EVP_CIPHER_CTX).CipherFinal C.EVP_CipherFinal_ex
+func (ctx *EVP_CIPHER_CTX) CipherFinal(out *byte, outl *c.Int) c.Int { return 0 }
+
+const (
+	EVP_CTRL_AEAD_SET_IVLEN = 0x9
+	EVP_CTRL_AEAD_GET_TAG   = 0x10
+	EVP_CTRL_AEAD_SET_TAG   = 0x11
+)
+
+// int EVP_CIPHER_CTX_ctrl(EVP_CIPHER_CTX *ctx, int type, int arg, void *ptr);
+//
+// llgo:link (*EVP_CIPHER_CTX).Ctrl C.EVP_CIPHER_CTX_ctrl
+func (ctx *EVP_CIPHER_CTX) Ctrl(typ, arg c.Int, ptr unsafe.Pointer) c.Int { return 0 }
+
+// -----------------------------------------------------------------------------
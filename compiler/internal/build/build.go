@@ -37,6 +37,7 @@ import (
 	"golang.org/x/tools/go/ssa"
 
 	"github.com/goplus/llgo/compiler/cl"
+	"github.com/goplus/llgo/compiler/internal/buildtags"
 	"github.com/goplus/llgo/compiler/internal/env"
 	"github.com/goplus/llgo/compiler/internal/mockable"
 	"github.com/goplus/llgo/compiler/internal/packages"
@@ -123,7 +124,7 @@ const (
 
 func Do(args []string, conf *Config) ([]Package, error) {
 	flags, patterns, verbose := ParseArgs(args, buildFlags)
-	flags = append(flags, "-tags", "llgo")
+	flags = buildtags.AddTags(flags, "llgo")
 	cfg := &packages.Config{
 		Mode:       loadSyntax | packages.NeedDeps | packages.NeedModule | packages.NeedExportFile,
 		BuildFlags: flags,
@@ -79,6 +79,26 @@ type virtualFS struct {
 	files map[string]virtualFile
 }
 
+// AddTags returns buildFlags with tags added to the build tags they set. The go
+// command only honors the last -tags flag, so all the -tags flags of
+// buildFlags are replaced by a single one, at the end, listing every tag.
+func AddTags(buildFlags []string, tags ...string) []string {
+	all := parseBuildTags(append(buildFlags[:len(buildFlags):len(buildFlags)], "-tags", strings.Join(tags, ",")))
+	ret := make([]string, 0, len(buildFlags)+2)
+	for i := 0; i < len(buildFlags); i++ {
+		flag := buildFlags[i]
+		if flag == "-tags" && i+1 < len(buildFlags) {
+			i++
+			continue
+		}
+		if strings.HasPrefix(flag, "-tags=") {
+			continue
+		}
+		ret = append(ret, flag)
+	}
+	return append(ret, "-tags", strings.Join(all, ","))
+}
+
 func parseBuildTags(buildFlags []string) []string {
 	buildTags := make([]string, 0)
 	// Extract tags from buildFlags
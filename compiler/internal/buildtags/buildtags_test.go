@@ -154,3 +154,45 @@ func TestParseBuildTags(t *testing.T) {
 		})
 	}
 }
+
+func TestAddTags(t *testing.T) {
+	tests := []struct {
+		name       string
+		buildFlags []string
+		want       []string
+	}{
+		{
+			name:       "no tags",
+			buildFlags: []string{"-v"},
+			want:       []string{"-v", "-tags", "llgo"},
+		},
+		{
+			name:       "separate value",
+			buildFlags: []string{"-tags", "math_big_pure_go", "-v"},
+			want:       []string{"-v", "-tags", "math_big_pure_go,llgo"},
+		},
+		{
+			name:       "equals format",
+			buildFlags: []string{"-tags=nogc,math_big_pure_go"},
+			want:       []string{"-tags", "nogc,math_big_pure_go,llgo"},
+		},
+		{
+			name:       "multiple -tags flags and duplicates",
+			buildFlags: []string{"-tags", "nogc", "-x", "-tags=llgo nogc"},
+			want:       []string{"-x", "-tags", "nogc,llgo"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := append([]string(nil), tt.buildFlags...)
+			got := AddTags(in, "llgo")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AddTags(%q) = %q, want %q", tt.buildFlags, got, tt.want)
+			}
+			if !reflect.DeepEqual(in, tt.buildFlags) {
+				t.Errorf("AddTags modified its argument: %q", in)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/py"
+)
+
+func main() {
+	py.Initialize()
+	py.SetProgramName(*c.Argv)
+	list := py.List(py.Long(0), py.Long(1), py.Long(2), py.Long(3), py.Long(4), py.Long(5), py.Long(6))
+
+	sub := list.SeqGetSlice(1, 3)
+	c.Printf(c.Str("list[1:3] = %s\n"), sub.Str().CStr())
+
+	head := list.GetItem(py.NewSlice(nil, py.Long(5), nil))
+	c.Printf(c.Str("list[:5] = %s\n"), head.Str().CStr())
+
+	even := list.GetItem(py.NewSlice(nil, nil, py.Long(2)))
+	c.Printf(c.Str("list[::2] = %s\n"), even.Str().CStr())
+
+	even.DecRef()
+	head.DecRef()
+	sub.DecRef()
+	list.DecRef()
+	py.Finalize()
+}
@@ -0,0 +1,52 @@
+#include <Python.h>
+#include <datetime.h>
+
+// PyDateTime_IMPORT loads the datetime C API capsule into the static
+// PyDateTimeAPI of this file. It needs a running interpreter, so it can't
+// be done from a Go init function; instead every helper imports lazily.
+static int llgoPyDateTimeImport(void) {
+    if (PyDateTimeAPI == NULL) {
+        PyDateTime_IMPORT;
+    }
+    return PyDateTimeAPI != NULL ? 0 : -1;
+}
+
+PyObject* llgoPyDateTimeFromDateAndTime(
+    int year, int month, int day, int hour, int minute, int second, int usecond) {
+    if (llgoPyDateTimeImport() != 0) {
+        return NULL;
+    }
+    return PyDateTimeAPI->DateTime_FromDateAndTime(
+        year, month, day, hour, minute, second, usecond,
+        PyDateTime_TimeZone_UTC, PyDateTimeAPI->DateTimeType);
+}
+
+// fields receives year, month, day, hour, minute, second, microsecond and
+// the UTC offset in seconds (0 for naive datetimes).
+int llgoPyDateTimeFields(PyObject* o, int* fields) {
+    if (llgoPyDateTimeImport() != 0) {
+        return -1;
+    }
+    if (!PyDateTime_Check(o)) {
+        PyErr_SetString(PyExc_TypeError, "expected a datetime.datetime object");
+        return -1;
+    }
+    fields[0] = PyDateTime_GET_YEAR(o);
+    fields[1] = PyDateTime_GET_MONTH(o);
+    fields[2] = PyDateTime_GET_DAY(o);
+    fields[3] = PyDateTime_DATE_GET_HOUR(o);
+    fields[4] = PyDateTime_DATE_GET_MINUTE(o);
+    fields[5] = PyDateTime_DATE_GET_SECOND(o);
+    fields[6] = PyDateTime_DATE_GET_MICROSECOND(o);
+    fields[7] = 0;
+    PyObject* offset = PyObject_CallMethod(o, "utcoffset", NULL);
+    if (offset == NULL) {
+        return -1;
+    }
+    if (offset != Py_None) {
+        fields[7] = PyDateTime_DELTA_GET_DAYS(offset) * 86400 +
+                    PyDateTime_DELTA_GET_SECONDS(offset);
+    }
+    Py_DECREF(offset);
+    return 0;
+}
@@ -8,8 +8,15 @@
 typedef struct PyObject PyObject;
 
 PyObject* PyObject_GetAttrString(PyObject* mod, const char* attrName);
+void PyErr_Clear(void);
 
+// A symbol missing from the module (e.g. bindings generated for a newer
+// version) is left NULL. Its AttributeError is cleared, as a pending
+// exception would make the next unrelated call fail.
 void llgoLoadPyModSyms(PyObject* mod, ...) {
+    if (mod == NULL) {
+        return;
+    }
     va_list ap;
     va_start(ap, mod);
     for (;;) {
@@ -20,6 +27,9 @@ void llgoLoadPyModSyms(PyObject* mod, ...) {
         PyObject** pfunc = va_arg(ap, PyObject**);
         if (*pfunc == NULL) {
             *pfunc = PyObject_GetAttrString(mod, name);
+            if (*pfunc == NULL) {
+                PyErr_Clear();
+            }
         }
     }
     va_end(ap);
@@ -0,0 +1,12 @@
+#include <Python.h>
+
+// PySequence_Fast_GET_SIZE and PySequence_Fast_ITEMS are macros, so they are
+// wrapped here for Go. o must be a result of PySequence_Fast.
+
+Py_ssize_t llgoPySequenceFastSize(PyObject* o) {
+    return PySequence_Fast_GET_SIZE(o);
+}
+
+PyObject** llgoPySequenceFastItems(PyObject* o) {
+    return PySequence_Fast_ITEMS(o);
+}
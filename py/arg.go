@@ -17,6 +17,8 @@
 package py
 
 import (
+	"fmt"
+	"strings"
 	_ "unsafe"
 
 	"github.com/goplus/llgo/c"
@@ -31,3 +33,77 @@ import (
 //
 //go:linkname BuildValue C.Py_BuildValue
 func BuildValue(format *c.Char, __llgo_va_list ...any) *Object
+
+// ParseArgs parses the tuple args, such as the positional arguments of a Go
+// function called from Python, into dests, in the manner of PyArg_ParseTuple.
+// Each character of format converts the item of args at the same position
+// into the destination at the same position of dests:
+//
+//	"i"  an int, or any object with __index__, into an *int64
+//	"d"  a float, or any object with __float__ or __index__, into a *float64
+//	"s"  a str into a *string
+//	"O"  any object into an **Object, as a borrowed reference
+//
+// An error is returned, and the error indicator left clear, if args isn't a
+// tuple of exactly len(format) items or an item can't be converted, e.g. a
+// str for "i". An unsupported format character, or a destination of the
+// wrong type for its character, is reported as an error too.
+func ParseArgs(args *Object, format string, dests ...any) error {
+	if len(dests) != len(format) {
+		return fmt.Errorf("py.ParseArgs: format %q needs %d destinations, got %d", format, len(format), len(dests))
+	}
+	items, err := UnpackTuple(args, len(format))
+	if err != nil {
+		return err
+	}
+	for i, item := range items {
+		if err := parseArg(item, format[i], dests[i]); err != nil {
+			return fmt.Errorf("py.ParseArgs: argument %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func parseArg(item *Object, code byte, dest any) error {
+	switch p := dest.(type) {
+	case *int64:
+		if code == 'i' {
+			v := item.LongLong()
+			if v == -1 && ErrOccurred() != nil {
+				return fetchError()
+			}
+			*p = int64(v)
+			return nil
+		}
+	case *float64:
+		if code == 'd' {
+			v := item.Float64()
+			if v == -1 && ErrOccurred() != nil {
+				return fetchError()
+			}
+			*p = v
+			return nil
+		}
+	case *string:
+		if code == 's' {
+			if !item.IsInstance(&unicodeType) {
+				return &PyError{Type: "TypeError", Message: "must be str, not " + typeName(item)}
+			}
+			s, n := item.CStrAndLen()
+			if s == nil {
+				return fetchError()
+			}
+			*p = c.GoString(s, n)
+			return nil
+		}
+	case **Object:
+		if code == 'O' {
+			*p = item
+			return nil
+		}
+	}
+	if !strings.ContainsRune("idsO", rune(code)) {
+		return fmt.Errorf("unsupported format character %q", code)
+	}
+	return fmt.Errorf("format %q doesn't take a %T destination", code, dest)
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package py
+
+import (
+	"math/big"
+	_ "unsafe"
+
+	"github.com/goplus/llgo/c"
+)
+
+// LongFromBigInt returns a new int object with the value of x, or nil with an
+// exception set on failure. x must not be nil.
+//
+// The value is passed as hexadecimal text: unlike decimal, conversions in a
+// power-of-two base aren't subject to Python's integer string conversion
+// length limit (sys.set_int_max_str_digits), so integers of any size convert
+// exactly.
+func LongFromBigInt(x *big.Int) *Object {
+	s := FromGoString(x.Text(16))
+	if s == nil {
+		return nil
+	}
+	defer s.DecRef()
+	return LongFromUnicode(s, 16)
+}
+
+// LongToBigInt returns the value of o, an int object or an object with an
+// __index__ method, as a big.Int. Return nil with an exception set on
+// failure.
+func LongToBigInt(o *Object) *big.Int {
+	s := numberToBase(o, 16)
+	if s == nil {
+		return nil
+	}
+	defer s.DecRef()
+	text, n := s.CStrAndLen()
+	if text == nil {
+		return nil
+	}
+	x, _ := new(big.Int).SetString(c.GoString(text, n), 0) // "0x1f" or "-0x1f"
+	return x
+}
+
+//go:linkname numberToBase C.PyNumber_ToBase
+func numberToBase(o *Object, base c.Int) *Object
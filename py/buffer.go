@@ -0,0 +1,162 @@
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package py
+
+import (
+	"unsafe"
+
+	"github.com/goplus/llgo/c"
+)
+
+// https://docs.python.org/3/c-api/bytes.html
+// https://docs.python.org/3/c-api/buffer.html
+
+// Bytes returns a copy of the contents of the bytes object o. A TypeError is
+// returned as an error if o is not a bytes object; use BufferBytes for other
+// objects holding binary data, such as a bytearray.
+func (o *Object) Bytes() ([]byte, error) {
+	var data *c.Char
+	var n int
+	if bytesAsStringAndSize(o, &data, &n) != 0 {
+		return nil, fetchError()
+	}
+	return c.GoBytes(c.Pointer(data), n), nil
+}
+
+//go:linkname bytesAsStringAndSize C.PyBytes_AsStringAndSize
+func bytesAsStringAndSize(o *Object, buffer **c.Char, length *int) c.Int
+
+// BufferBytes returns a copy of the memory exported by o through the buffer
+// protocol, as bytes(memoryview(o)) would, so it works for bytes, bytearray,
+// memoryview, array.array and the like. A BufferError is returned as an error
+// if the buffer is not contiguous, and a TypeError if o doesn't support the
+// buffer protocol.
+func (o *Object) BufferBytes() ([]byte, error) {
+	var view buffer
+	if objectGetBuffer(o, &view, c.Int(BufSimple)) != 0 {
+		return nil, fetchError()
+	}
+	defer bufferRelease(&view)
+	return c.GoBytes(view.buf, view.len), nil
+}
+
+// buffer is Py_buffer.
+type buffer struct {
+	buf        c.Pointer
+	obj        *Object
+	len        int
+	itemsize   int
+	readonly   c.Int
+	ndim       c.Int
+	format     *c.Char
+	shape      *int
+	strides    *int
+	suboffsets *int
+	internal   c.Pointer
+}
+
+// BufferFlags tells GetBuffer what the consumer of a buffer can handle.
+type BufferFlags c.Int
+
+const (
+	BufSimple        BufferFlags = 0                   // PyBUF_SIMPLE
+	BufWritable      BufferFlags = 0x0001              // PyBUF_WRITABLE
+	BufFormat        BufferFlags = 0x0004              // PyBUF_FORMAT
+	BufND            BufferFlags = 0x0008              // PyBUF_ND
+	BufStrides       BufferFlags = 0x0010 | BufND      // PyBUF_STRIDES
+	BufCContiguous   BufferFlags = 0x0020 | BufStrides // PyBUF_C_CONTIGUOUS
+	BufFContiguous   BufferFlags = 0x0040 | BufStrides // PyBUF_F_CONTIGUOUS
+	BufAnyContiguous BufferFlags = 0x0080 | BufStrides // PyBUF_ANY_CONTIGUOUS
+)
+
+// Buffer is a view of the memory of an object exporting the buffer
+// protocol, such as a bytearray, an array.array or a numpy array, as
+// returned by GetBuffer. It must be released with Release.
+type Buffer struct {
+	view buffer
+}
+
+// GetBuffer exports the memory of o as a Buffer, with the layout flags asks
+// for. With BufWritable, the exporter must allow writing to it, and a
+// read-only object is an error instead: a BufferError for bytes, or a
+// ValueError for a numpy array whose writeable flag is off. A TypeError is
+// returned as an error if o doesn't support the buffer protocol, and a
+// BufferError if it can't provide the layout asked for, e.g. a contiguous
+// one with BufCContiguous.
+//
+// Writes through the slice returned by Bytes change o in place. Release the
+// buffer before Python code uses o again: until then, o stays locked
+// against resizing, so that a bytearray raises BufferError if it is
+// appended to, for instance.
+func (o *Object) GetBuffer(flags BufferFlags) (*Buffer, error) {
+	b := new(Buffer)
+	if objectGetBuffer(o, &b.view, c.Int(flags)) != 0 {
+		return nil, fetchError()
+	}
+	return b, nil
+}
+
+// Bytes returns the memory of b, without copying it. The slice is writable
+// if b is, see ReadOnly, and must not be used once b is released. It covers
+// the whole of the data only for a contiguous buffer, the layout exported
+// unless the flags of GetBuffer include BufStrides but no contiguity
+// requirement.
+func (b *Buffer) Bytes() []byte {
+	if b.view.buf == nil {
+		return nil
+	}
+	return unsafe.Slice((*byte)(b.view.buf), b.view.len)
+}
+
+// ReadOnly reports whether the memory of b must not be written to. It is
+// false if b was got with BufWritable.
+func (b *Buffer) ReadOnly() bool { return b.view.readonly != 0 }
+
+// ItemSize returns the size in bytes of an item of b, such as 8 for a
+// buffer of float64.
+func (b *Buffer) ItemSize() int { return b.view.itemsize }
+
+// Format returns the struct module format of the items of b, such as "d"
+// for float64. It is "B", unsigned bytes, unless GetBuffer was called with
+// BufFormat.
+func (b *Buffer) Format() string {
+	if b.view.format == nil {
+		return "B"
+	}
+	return c.GoString(b.view.format)
+}
+
+// Shape returns the length of each dimension of b, or nil unless GetBuffer
+// was called with BufND, or a flag including it.
+func (b *Buffer) Shape() []int {
+	if b.view.shape == nil {
+		return nil
+	}
+	return unsafe.Slice(b.view.shape, b.view.ndim)
+}
+
+// Release releases b, unlocking the object it was got from. Releasing b
+// again has no effect.
+func (b *Buffer) Release() {
+	bufferRelease(&b.view)
+}
+
+//go:linkname objectGetBuffer C.PyObject_GetBuffer
+func objectGetBuffer(o *Object, view *buffer, flags c.Int) c.Int
+
+//go:linkname bufferRelease C.PyBuffer_Release
+func bufferRelease(view *buffer)
@@ -24,11 +24,43 @@ import (
 
 // https://docs.python.org/3/c-api/call.html
 
-// Determine if the object o is callable. Return 1 if the object is callable and
-// 0 otherwise. This function always succeeds.
-//
-// llgo:link (*Object).Callable C.PyCallable_Check
-func (o *Object) Callable() c.Int { return 0 }
+// Callable reports whether o can be called, as the Python expression
+// callable(o) does: true for functions, bound methods, classes and any
+// object whose type defines __call__, false for plain values such as an
+// int. It never raises an exception.
+func (o *Object) Callable() bool {
+	return callableCheck(o) != 0
+}
+
+//go:linkname callableCheck C.PyCallable_Check
+func callableCheck(o *Object) c.Int
+
+// ArgCount returns the number of positional parameters of fn and true, if fn
+// is a function defined in Python, or a method bound to one: it is read from
+// fn.__code__.co_argcount, less the bound self of a method. Parameters with
+// a default value are counted, *args and keyword-only parameters aren't.
+//
+// Builtins, functions written in C and other callables without a __code__,
+// such as classes, have no such count: ArgCount returns 0, false for them.
+func (fn *Object) ArgCount() (int, bool) {
+	code := fn.GetAttrString(c.Str("__code__"))
+	if code == nil {
+		ErrClear()
+		return 0, false
+	}
+	defer code.DecRef()
+	argc := code.GetAttrString(c.Str("co_argcount"))
+	if argc == nil {
+		ErrClear()
+		return 0, false
+	}
+	defer argc.DecRef()
+	n := int(argc.Long())
+	if n > 0 && fn.HasAttrString("__self__") {
+		n-- // a bound method
+	}
+	return n, true
+}
 
 // Call a callable Python object o, with arguments given by the tuple args, and
 // named arguments given by the dictionary kwargs.
@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package py
+
+import (
+	_ "unsafe"
+
+	"github.com/goplus/llgo/c"
+)
+
+// https://docs.python.org/3/c-api/capsule.html
+
+// The type of a destructor callback for a capsule.
+//
+// llgo:type C
+type CapsuleDestructor func(capsule *Object)
+
+// Create a capsule encapsulating the pointer. The pointer argument may not be
+// nil. On failure, set an exception and return nil.
+//
+// The name string may either be nil or a pointer to a valid C string. If
+// non-nil, this string must outlive the capsule.
+//
+// If the destructor argument is not nil, it will be called with the capsule as
+// its argument when it is destroyed.
+//
+//go:linkname NewCapsule C.PyCapsule_New
+func NewCapsule(pointer c.Pointer, name *c.Char, destructor CapsuleDestructor) *Object
+
+// Retrieve the pointer stored in the capsule. On failure, set an exception and
+// return nil.
+//
+// The name parameter must compare exactly to the name stored in the capsule.
+// If the name stored in the capsule is nil, the name passed in must also be
+// nil.
+//
+// llgo:link (*Object).CapsulePointer C.PyCapsule_GetPointer
+func (o *Object) CapsulePointer(name *c.Char) c.Pointer { return nil }
+
+// Determines whether or not o is a valid capsule with the given name. Return a
+// nonzero value if the object is valid and matches the name passed in, and 0
+// otherwise. This function will not fail.
+//
+// llgo:link (*Object).CapsuleIsValid C.PyCapsule_IsValid
+func (o *Object) CapsuleIsValid(name *c.Char) c.Int { return 0 }
@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package py
+
+import (
+	"fmt"
+	_ "unsafe"
+)
+
+// Enter calls cm.__enter__(), as the with statement does on entry, and
+// returns its result: the object bound by "as". A raised exception is
+// returned as an error.
+func (cm *Object) Enter() (*Object, error) {
+	ret := cm.CallMethodObjArgs(Str("__enter__"), (*Object)(nil))
+	if ret == nil {
+		return nil, fetchError()
+	}
+	return ret, nil
+}
+
+// Exit calls cm.__exit__(typ, val, tb), as the with statement does on exit,
+// and reports whether the context manager suppressed the exception. The
+// arguments describe the exception leaving the block, and are all nil if
+// there is none. If __exit__ itself raises, Exit returns false with that
+// exception set.
+func (cm *Object) Exit(typ, val, tb *Object) bool {
+	ret := cm.CallMethodObjArgs(Str("__exit__"), orNone(typ), orNone(val), orNone(tb), (*Object)(nil))
+	if ret == nil {
+		return false
+	}
+	defer ret.DecRef()
+	return ret.IsTrue() == 1
+}
+
+// With runs fn like the body of a Python with statement on the context
+// manager cm: fn is passed the result of __enter__, and __exit__ is called
+// when fn returns, even if it panics.
+//
+// If fn returns an error, __exit__ sees it as a RuntimeError carrying the
+// error text, so that e.g. a database connection rolls back instead of
+// committing; if the context manager suppresses it, With returns nil. An
+// exception raised by __enter__ or __exit__ is returned as an error.
+func With(cm *Object, fn func(resource *Object) error) (err error) {
+	res, err := cm.Enter()
+	if err != nil {
+		return err
+	}
+	defer res.DecRef()
+
+	exited := false
+	defer func() {
+		if exited {
+			return
+		}
+		// fn panicked: exit with an exception, then keep panicking.
+		r := recover()
+		exitWith(cm, fmt.Errorf("panic: %v", r))
+		ErrClear()
+		panic(r)
+	}()
+	err = fn(res)
+	exited = true
+	return exitWith(cm, err)
+}
+
+// exitWith calls cm.__exit__ for a block that ended with the Go error err,
+// or without error if err is nil, and returns the error With reports.
+func exitWith(cm *Object, err error) error {
+	if err == nil {
+		if !cm.Exit(nil, nil, nil) && ErrOccurred() != nil {
+			return fetchError()
+		}
+		return nil
+	}
+	msg := FromGoString(err.Error())
+	val := excRuntimeError.CallOneArg(msg)
+	msg.DecRef()
+	if val == nil {
+		ErrClear()
+		return err
+	}
+	defer val.DecRef()
+	if cm.Exit(excRuntimeError, val, nil) {
+		return nil
+	}
+	if ErrOccurred() != nil {
+		return fetchError()
+	}
+	return err
+}
+
+//go:linkname excRuntimeError PyExc_RuntimeError
+var excRuntimeError *Object
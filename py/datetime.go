@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package py
+
+import (
+	"time"
+	_ "unsafe"
+
+	"github.com/goplus/llgo/c"
+)
+
+// https://docs.python.org/3/c-api/datetime.html
+
+// NewDateTime returns a new timezone-aware datetime.datetime object holding t
+// converted to UTC. Python datetimes have microsecond resolution, so any finer
+// part of t is truncated. Return nil with an exception set on failure.
+//
+// The datetime C API is imported on first use, so NewDateTime and Time can
+// only be called once the interpreter is initialized.
+func NewDateTime(t time.Time) *Object {
+	t = t.UTC()
+	return dateTimeFromDateAndTime(
+		c.Int(t.Year()), c.Int(t.Month()), c.Int(t.Day()),
+		c.Int(t.Hour()), c.Int(t.Minute()), c.Int(t.Second()), c.Int(t.Nanosecond()/1000))
+}
+
+// Time returns the instant held by the datetime.datetime object o (or an
+// instance of a subtype such as pandas.Timestamp), in UTC. Aware datetimes are
+// adjusted by their utcoffset(); naive datetimes are interpreted as UTC. If o
+// is not a datetime, the zero Time is returned and an exception is set.
+func (o *Object) Time() time.Time {
+	var f [8]c.Int
+	if dateTimeFields(o, &f[0]) != 0 {
+		return time.Time{}
+	}
+	t := time.Date(
+		int(f[0]), time.Month(f[1]), int(f[2]),
+		int(f[3]), int(f[4]), int(f[5]), int(f[6])*1000, time.UTC)
+	return t.Add(-time.Duration(f[7]) * time.Second)
+}
+
+//go:linkname dateTimeFromDateAndTime C.llgoPyDateTimeFromDateAndTime
+func dateTimeFromDateAndTime(year, month, day, hour, minute, second, usecond c.Int) *Object
+
+//go:linkname dateTimeFields C.llgoPyDateTimeFields
+func dateTimeFields(o *Object, fields *c.Int) c.Int
@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package py
+
+import (
+	_ "unsafe"
+
+	"github.com/goplus/llgo/c"
+)
+
+// https://docs.python.org/3/library/decimal.html
+
+// NewDecimal returns a new decimal.Decimal object with the value of the
+// decimal string s, such as "-12.50" or "1E+30", as Decimal(s) does. The value
+// is exact: unlike a conversion through float64, no digits are rounded away,
+// and trailing zeros are kept. Return nil with an exception set on failure,
+// an InvalidOperation for a malformed s. The decimal module is imported on
+// first use.
+func NewDecimal(s string) *Object {
+	mod := ImportModule(c.Str("decimal"))
+	if mod == nil {
+		return nil
+	}
+	defer mod.DecRef()
+	arg := FromGoString(s)
+	if arg == nil {
+		return nil
+	}
+	defer arg.DecRef()
+	return mod.CallMethodObjArgs(Str("Decimal"), arg, (*Object)(nil))
+}
+
+// DecimalString returns str(o) for the decimal.Decimal object o, which is its
+// exact value: NewDecimal(o.DecimalString()) compares equal to o, with the
+// same exponent. Like Python, it uses scientific notation for very small or
+// large exponents, e.g. "1E+30". Return "" with an exception set on failure.
+func (o *Object) DecimalString() string {
+	s := o.Str()
+	if s == nil {
+		return ""
+	}
+	defer s.DecRef()
+	text, n := s.CStrAndLen()
+	if text == nil {
+		return ""
+	}
+	return c.GoString(text, n)
+}
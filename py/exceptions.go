@@ -17,7 +17,10 @@
 package py
 
 import (
+	"strings"
 	_ "unsafe"
+
+	"github.com/goplus/llgo/c"
 )
 
 // https://docs.python.org/3/c-api/exceptions.html
@@ -30,3 +33,172 @@ func ErrClear()
 
 //go:linkname ErrPrint C.PyErr_Print
 func ErrPrint()
+
+// Test whether the error indicator is set. If set, return the exception type
+// (the first argument to the last call to one of the ErrSet* functions or to
+// ErrRestore). If not set, return nil. You do not own a reference to the return
+// value, so you do not need to DecRef it.
+//
+//go:linkname ErrOccurred C.PyErr_Occurred
+func ErrOccurred() *Object
+
+// Retrieve the error indicator into three variables whose addresses are passed.
+// If the error indicator is not set, set all three variables to nil. If it is
+// set, it will be cleared and you own a reference to each object retrieved. The
+// value and traceback object may be nil even when the type object is not.
+//
+//go:linkname ErrFetch C.PyErr_Fetch
+func ErrFetch(ptype, pvalue, ptraceback **Object)
+
+// Under certain circumstances, the values returned by ErrFetch below can be
+// "unnormalized", meaning that *exc is a class object but *val is not an instance
+// of the same class. This function can be used to instantiate the class in that
+// case. If the values are already normalized, nothing happens.
+//
+//go:linkname ErrNormalizeException C.PyErr_NormalizeException
+func ErrNormalizeException(ptype, pvalue, ptraceback **Object)
+
+// Set the error indicator from the three objects. If the error indicator is
+// already set, it is cleared first. This call takes away a reference to each
+// object.
+//
+//go:linkname ErrRestore C.PyErr_Restore
+func ErrRestore(typ, value, traceback *Object)
+
+// -----------------------------------------------------------------------------
+
+// PyError is a Python exception converted to a Go error, as returned by the
+// functions of this package that report a Python failure. Use errors.As to
+// tell one from the errors of the Go side, and to get the exception type.
+type PyError struct {
+	Type      string // the name of the exception type, such as "ValueError"
+	Message   string // str() of the exception, often ""
+	Traceback string // the formatted traceback, if the error carries one
+}
+
+// Error returns the traceback of e if it has one, and "Type: Message", or
+// just Type for an exception without a message, otherwise.
+func (e *PyError) Error() string {
+	if e.Traceback != "" {
+		return e.Traceback
+	}
+	if e.Message == "" {
+		return e.Type
+	}
+	return e.Type + ": " + e.Message
+}
+
+// AsError converts the exception exc, such as the value retrieved by
+// ErrFetch after ErrNormalizeException, to a *PyError, without stealing the
+// reference to exc. AsError returns nil if exc is nil.
+func AsError(exc *Object) error {
+	if exc == nil {
+		return nil
+	}
+	typ := exc.Type()
+	defer typ.DecRef()
+	return &PyError{Type: attrString(typ, "__name__"), Message: strString(exc)}
+}
+
+// fetchError clears the error indicator and returns the pending exception as a
+// *PyError, or nil if no exception is set.
+func fetchError() error {
+	var typ, val, tb *Object
+	ErrFetch(&typ, &val, &tb)
+	if typ == nil {
+		return nil
+	}
+	ErrNormalizeException(&typ, &val, &tb)
+	err := AsError(val)
+	if val != nil {
+		val.DecRef()
+	} else {
+		err = &PyError{Type: attrString(typ, "__name__")}
+	}
+	if tb != nil {
+		tb.DecRef()
+	}
+	typ.DecRef()
+	return err
+}
+
+// fetchTraceback is like fetchError, but the *PyError returned carries the
+// traceback of the exception as formatted by FormatTraceback, which names
+// the file and line of each frame.
+func fetchTraceback() error {
+	var typ, val, tb *Object
+	ErrFetch(&typ, &val, &tb)
+	if typ == nil {
+		return nil
+	}
+	ErrNormalizeException(&typ, &val, &tb)
+	text := strings.TrimSuffix(FormatTraceback(typ, val, tb), "\n")
+	ErrRestore(typ, val, tb)
+	err := fetchError()
+	if e, ok := err.(*PyError); ok {
+		e.Traceback = text
+	}
+	return err
+}
+
+// FormatTraceback returns the text Python prints for the exception typ, val
+// with the traceback tb, as formatted by traceback.format_exception: the
+// "Traceback (most recent call last):" header, one entry per frame, then the
+// exception line. val and tb may be nil, and the arguments are typically the
+// result of ErrFetch, after ErrNormalizeException. The references aren't
+// stolen. FormatTraceback returns "" if the formatting itself fails.
+func FormatTraceback(typ, val, tb *Object) string {
+	mod := ImportModule(c.Str("traceback"))
+	if mod == nil {
+		ErrClear()
+		return ""
+	}
+	defer mod.DecRef()
+	lines := mod.CallMethodObjArgs(Str("format_exception"), typ, orNone(val), orNone(tb), (*Object)(nil))
+	if lines == nil {
+		ErrClear()
+		return ""
+	}
+	defer lines.DecRef()
+	sep := FromGoString("")
+	defer sep.DecRef()
+	text := sep.CallMethodObjArgs(Str("join"), lines, (*Object)(nil))
+	if text == nil {
+		ErrClear()
+		return ""
+	}
+	defer text.DecRef()
+	return c.GoString(text.CStr())
+}
+
+// orNone returns o, or None if o is nil.
+func orNone(o *Object) *Object {
+	if o == nil {
+		return &none
+	}
+	return o
+}
+
+// attrString returns the str() of the attribute name of o, or "" on failure.
+func attrString(o *Object, name string) string {
+	attr := o.GetAttrString(c.AllocaCStr(name))
+	if attr == nil {
+		ErrClear()
+		return ""
+	}
+	defer attr.DecRef()
+	return strString(attr)
+}
+
+// strString returns the str() of o as a Go string, or "" on failure.
+func strString(o *Object) string {
+	s := o.Str()
+	if s == nil {
+		ErrClear()
+		return ""
+	}
+	defer s.DecRef()
+	return c.GoString(s.CStr())
+}
+
+// -----------------------------------------------------------------------------
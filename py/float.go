@@ -30,3 +30,24 @@ func FloatFromSring(v *Object) *Object
 
 // llgo:link (*Object).Float64 C.PyFloat_AsDouble
 func (o *Object) Float64() float64 { return 0 }
+
+// Float64Checked returns the value of o as a float64, like the Python
+// expression float(o). Unlike Float64, it accepts any object implementing
+// __float__ or __index__ (such as a Python int or a numpy float32 scalar) and
+// reports a failed conversion, for example an int too large for a float64, as
+// an error instead of returning -1 with the error indicator left set.
+func (o *Object) Float64Checked() (float64, error) {
+	f := numberFloat(o)
+	if f == nil {
+		return -1, fetchError()
+	}
+	defer f.DecRef()
+	v := f.Float64()
+	if v == -1 && ErrOccurred() != nil {
+		return -1, fetchError()
+	}
+	return v, nil
+}
+
+//go:linkname numberFloat C.PyNumber_Float
+func numberFloat(o *Object) *Object
@@ -0,0 +1,162 @@
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package py
+
+import (
+	"unsafe"
+
+	"github.com/goplus/llgo/c"
+	"github.com/goplus/llgo/c/pthread"
+)
+
+// https://docs.python.org/3/c-api/init.html#thread-state-and-the-global-interpreter-lock
+
+// ThreadState represents the state of a thread running Python code.
+type ThreadState struct {
+	Unused [8]byte
+}
+
+// GILState is the state returned by GILEnsure, which is passed back to
+// GILRelease.
+type GILState c.Int
+
+const (
+	GILLocked   GILState = 0 // PyGILState_LOCKED, the GIL was already held
+	GILUnlocked GILState = 1 // PyGILState_UNLOCKED
+)
+
+// Release the global interpreter lock (if it has been created) and reset the
+// thread state to nil, returning the previous thread state (which is not nil).
+// The main goroutine, which holds the GIL after Initialize, calls it to let
+// other goroutines run Python code, and RestoreThread before calling Python
+// again or Finalize.
+//
+//go:linkname SaveThread C.PyEval_SaveThread
+func SaveThread() *ThreadState
+
+// Acquire the global interpreter lock (if it has been created) and set the
+// thread state to tstate, which must not be nil.
+//
+//go:linkname RestoreThread C.PyEval_RestoreThread
+func RestoreThread(tstate *ThreadState)
+
+// GILEnsure makes the calling goroutine ready to call Python: it acquires the
+// GIL and, on the first call in the goroutine, creates its thread state. Calls
+// may be nested, and each must be matched by a call of GILRelease with the
+// returned state, in reverse order.
+//
+// Each goroutine runs on a thread of its own, which keeps its thread state
+// until the calls are fully unwound. A goroutine that may end with calls left
+// unmatched, e.g. by panicking, should defer ReleaseThreadState.
+func GILEnsure() GILState {
+	state := gilStateEnsure()
+	gilFramesOf(true).push(state)
+	return state
+}
+
+// GILRelease releases the GIL as acquired by the GILEnsure call that returned
+// state, deleting the thread state of the goroutine if this matches its
+// outermost call.
+func GILRelease(state GILState) {
+	if f := gilFramesOf(false); f != nil && f.len > 0 {
+		f.len--
+	}
+	gilStateRelease(state)
+}
+
+// ReleaseThreadState calls GILRelease for each GILEnsure of the calling
+// goroutine that is still unmatched, innermost first, so that the goroutine
+// doesn't hold the GIL and its thread state is deleted. It does nothing if
+// there are none, so it is safe to defer at the start of a goroutine calling
+// Python whichever way the goroutine ends.
+//
+// A thread state left behind holds the GIL after its goroutine has ended,
+// making other goroutines and Finalize block or abort. If a goroutine ends
+// without calling ReleaseThreadState, its unmatched calls are released when
+// its thread exits, unless Python was finalized before.
+func ReleaseThreadState() {
+	if f := gilFramesOf(false); f != nil {
+		f.unwind()
+	}
+}
+
+//go:linkname gilStateEnsure C.PyGILState_Ensure
+func gilStateEnsure() GILState
+
+//go:linkname gilStateRelease C.PyGILState_Release
+func gilStateRelease(state GILState)
+
+//go:linkname isInitialized C.Py_IsInitialized
+func isInitialized() c.Int
+
+// -----------------------------------------------------------------------------
+
+// gilFrames records the states returned by the GILEnsure calls of a goroutine
+// that GILRelease hasn't matched yet. It is kept in thread-local storage,
+// which the garbage collector doesn't scan, so it is allocated in C memory.
+type gilFrames struct {
+	states   *GILState
+	len, cap uintptr
+}
+
+var gilKey pthread.Key
+
+func init() {
+	gilKey.Create(freeGILFrames)
+}
+
+// gilFramesOf returns the gilFrames of the calling goroutine, or nil if it
+// has none and create is false.
+func gilFramesOf(create bool) *gilFrames {
+	f := (*gilFrames)(gilKey.Get())
+	if f == nil && create {
+		f = (*gilFrames)(c.Calloc(1, unsafe.Sizeof(gilFrames{})))
+		gilKey.Set(c.Pointer(f))
+	}
+	return f
+}
+
+func (f *gilFrames) push(state GILState) {
+	if f.len == f.cap {
+		f.cap = f.cap*2 + 4
+		f.states = (*GILState)(c.Realloc(c.Pointer(f.states), f.cap*unsafe.Sizeof(state)))
+	}
+	*f.at(f.len) = state
+	f.len++
+}
+
+func (f *gilFrames) at(i uintptr) *GILState {
+	return (*GILState)(c.Advance(f.states, i))
+}
+
+func (f *gilFrames) unwind() {
+	for f.len > 0 {
+		f.len--
+		gilStateRelease(*f.at(f.len))
+	}
+}
+
+// freeGILFrames is the destructor of gilKey, called as the thread of a
+// goroutine that used GILEnsure exits.
+func freeGILFrames(p c.Pointer) {
+	f := (*gilFrames)(p)
+	if isInitialized() != 0 {
+		f.unwind()
+	}
+	c.Free(c.Pointer(f.states))
+	c.Free(p)
+}
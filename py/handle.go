@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package py
+
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/goplus/llgo/c"
+)
+
+// A handle boxes a Go value referenced by a capsule. Python memory isn't
+// scanned by the Go garbage collector, so each live handle is also kept in
+// the handles table until the capsule's destructor removes it.
+type handle struct {
+	v any
+}
+
+var handles struct {
+	sync.Mutex
+	live map[*handle]struct{}
+}
+
+// NewHandle returns a capsule object holding v, which lets Python code keep
+// and pass around a Go value it can't otherwise represent. v stays reachable
+// for as long as the capsule is alive; use HandleValue to get it back. Return
+// nil with an exception set on failure.
+func NewHandle(v any) *Object {
+	h := &handle{v}
+	handles.Lock()
+	if handles.live == nil {
+		handles.live = make(map[*handle]struct{})
+	}
+	handles.live[h] = struct{}{}
+	handles.Unlock()
+	o := NewCapsule(unsafe.Pointer(h), c.Str("llgo.handle"), releaseHandle)
+	if o == nil {
+		releaseHandleOf(h)
+	}
+	return o
+}
+
+// HandleValue returns the Go value held by the capsule o created by
+// NewHandle, or nil if o is not such a capsule.
+func (o *Object) HandleValue() any {
+	if o.CapsuleIsValid(c.Str("llgo.handle")) == 0 {
+		return nil
+	}
+	return (*handle)(o.CapsulePointer(c.Str("llgo.handle"))).v
+}
+
+func releaseHandle(capsule *Object) {
+	releaseHandleOf((*handle)(capsule.CapsulePointer(c.Str("llgo.handle"))))
+}
+
+func releaseHandleOf(h *handle) {
+	handles.Lock()
+	delete(handles.live, h)
+	handles.Unlock()
+}
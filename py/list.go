@@ -71,13 +71,29 @@ func (l *Object) ListAppend(item *Object) c.Int { return 0 }
 func (l *Object) ListSlice(low, high int) *Object { return nil }
 
 // Set the slice of list between low and high to the contents of itemlist. Analogous
-// to list[low:high] = itemlist. The itemlist may be NULL, indicating the assignment
+// to list[low:high] = itemlist. The itemlist may be nil, indicating the assignment
 // of an empty list (slice deletion). Return 0 on success, -1 on failure. Indexing
 // from the end of the list is not supported.
 //
 // llgo:link (*Object).ListSetSlice C.PyList_SetSlice
 func (l *Object) ListSetSlice(low, high int, itemlist *Object) c.Int { return 0 }
 
+// Delete the item at position index in list. Return 0 on success. If index is out
+// of bounds, return -1 and set an IndexError exception. Unlike the other list
+// functions, a negative index counts from the end of the list, as in the Python
+// statement del list[index].
+//
+// llgo:link (*Object).ListDelItem C.PySequence_DelItem
+func (l *Object) ListDelItem(index int) c.Int { return 0 }
+
+// Return a new list with the items of list followed by those of b, or nil on
+// failure. This is the equivalent of the Python expression list + b, so b must
+// be a list too (it works for any pair of sequences that can be added, such as
+// two tuples).
+//
+// llgo:link (*Object).ListConcat C.PySequence_Concat
+func (l *Object) ListConcat(b *Object) *Object { return nil }
+
 // Sort the items of list in place. Return 0 on success, -1 on failure. This is equivalent
 // to list.sort().
 //
@@ -71,3 +71,26 @@ func (l *Object) LongAsFloat64() float64 { return 0 }
 
 // llgo:link (*Object).LongAsVoidPtr C.PyLong_AsVoidPtr
 func (l *Object) LongAsVoidPtr() c.Pointer { return nil }
+
+// AsIndex returns the value of o as an int64, like the Python expression
+// operator.index(o). Unlike LongLong, it reports a failed conversion as an
+// error instead of returning -1 with the error indicator left set: a
+// TypeError if o is neither an int nor an object implementing __index__,
+// such as a numpy.int64 scalar, and an OverflowError if the value doesn't fit
+// an int64. A float is rejected, as it isn't an exact integer; use
+// Float64Checked for the conversion through __float__.
+func (o *Object) AsIndex() (int64, error) {
+	i := numberIndex(o)
+	if i == nil {
+		return -1, fetchError()
+	}
+	defer i.DecRef()
+	v := int64(i.LongLong())
+	if v == -1 && ErrOccurred() != nil {
+		return -1, fetchError()
+	}
+	return v, nil
+}
+
+//go:linkname numberIndex C.PyNumber_Index
+func numberIndex(o *Object) *Object
@@ -62,6 +62,28 @@ func ImportModule(name *c.Char) *Object
 //go:linkname Import C.PyImport_Import
 func Import(name *Object) *Object
 
+// ImportFrom imports a module the way the __import__() built-in does, and so
+// the import statement. globals supplies the __package__ (or __name__) that a
+// relative import is resolved against, and may be nil for an absolute import;
+// locals is unused. fromlist is a list of the names being imported, as in
+// "from name import a, b", and may be nil. level is the number of leading dots
+// of a relative import, or 0 for an absolute one.
+//
+// As with __import__(), the top-level package is returned when fromlist is
+// empty, and the named module itself otherwise. Returns nil with an exception
+// set on failure.
+func ImportFrom(name string, globals, locals, fromlist *Object, level int) *Object {
+	n := FromGoString(name)
+	if n == nil {
+		return nil
+	}
+	defer n.DecRef()
+	return importModuleLevelObject(n, globals, locals, fromlist, c.Int(level))
+}
+
+//go:linkname importModuleLevelObject C.PyImport_ImportModuleLevelObject
+func importModuleLevelObject(name, globals, locals, fromlist *Object, level c.Int) *Object
+
 // Return the dictionary object that implements module’s namespace; this object is the same
 // as the __dict__ attribute of the module object. If module is not a module object (or a
 // subtype of a module object), SystemError is raised and nil is returned.
@@ -17,7 +17,8 @@
 package py
 
 import (
-	_ "unsafe"
+	"fmt"
+	"unsafe"
 
 	"github.com/goplus/llgo/c"
 )
@@ -29,6 +30,9 @@ type Object struct {
 	Unused [8]byte
 }
 
+// llgo:link (*Object).IncRef C.Py_IncRef
+func (o *Object) IncRef() {}
+
 // llgo:link (*Object).DecRef C.Py_DecRef
 func (o *Object) DecRef() {}
 
@@ -42,6 +46,13 @@ func (o *Object) Type() *Object { return nil }
 // llgo:link (*Object).Str C.PyObject_Str
 func (o *Object) Str() *Object { return nil }
 
+// Compute a string representation of object o. Returns the string representation on
+// success, nil on failure. This is the equivalent of the Python expression repr(o).
+// Called by the repr() built-in function.
+//
+// llgo:link (*Object).Repr C.PyObject_Repr
+func (o *Object) Repr() *Object { return nil }
+
 // Returns 1 if the object o is considered to be true, and 0 otherwise. This is equivalent
 // to the Python expression not not o. On failure, return -1.
 //
@@ -54,6 +65,119 @@ func (o *Object) IsTrue() c.Int { return -1 }
 // llgo:link (*Object).NotTrue C.PyObject_Not
 func (o *Object) NotTrue() c.Int { return -1 }
 
+// Hash returns the hash value of o. This is the equivalent of the Python
+// expression hash(o). An error is returned, and the error indicator cleared,
+// if o is unhashable (e.g. a list).
+//
+// Equal objects have equal hashes, so the result can be used to key a Go map
+// of Python values; since distinct objects may collide, each map entry should
+// still be compared with the Python equality of its key.
+func (o *Object) Hash() (int64, error) {
+	h := objectHash(o)
+	if h == -1 {
+		return -1, fetchError()
+	}
+	return int64(h), nil
+}
+
+//go:linkname objectHash C.PyObject_Hash
+func objectHash(o *Object) int
+
+// Return the length of object o. If the object o provides either the sequence
+// and mapping protocols, the sequence length is returned. On error, -1 is
+// returned and an exception is set, e.g. a TypeError if o has no length. This
+// is the equivalent to the Python expression len(o).
+//
+// llgo:link (*Object).Len C.PyObject_Length
+func (o *Object) Len() int { return -1 }
+
+// LenErr is like Len, but reports a failure as an error, clearing the error
+// indicator.
+func (o *Object) LenErr() (int, error) {
+	n := o.Len()
+	if n == -1 {
+		return -1, fetchError()
+	}
+	return n, nil
+}
+
+// LengthHint returns an estimate of the number of items o holds or will
+// yield, for preallocating storage before iterating over it: len(o) if o has
+// a length, else the result of o.__length_hint__(), such as the number of
+// items left in a list iterator. defaultLen is returned if o provides
+// neither, as a generator doesn't. This is the equivalent of the Python
+// expression operator.length_hint(o, defaultLen), except that an exception
+// raised while computing the hint is cleared and defaultLen returned.
+func (o *Object) LengthHint(defaultLen int) int {
+	n := objectLengthHint(o, defaultLen)
+	if n < 0 {
+		ErrClear()
+		return defaultLen
+	}
+	return n
+}
+
+//go:linkname objectLengthHint C.PyObject_LengthHint
+func objectLengthHint(o *Object, defaultLen int) int
+
+// ToJSON returns the JSON text of o as produced by Python's json.dumps(o), or
+// the raised exception as an error, such as a TypeError for an object that
+// isn't JSON serializable (e.g. a set). The json module is imported on first
+// use.
+func (o *Object) ToJSON() (string, error) {
+	mod := ImportModule(c.Str("json"))
+	if mod == nil {
+		return "", fetchError()
+	}
+	defer mod.DecRef()
+	s := mod.CallMethodObjArgs(Str("dumps"), o, (*Object)(nil))
+	if s == nil {
+		return "", fetchError()
+	}
+	defer s.DecRef()
+	return c.GoString(s.CStr()), nil
+}
+
+// Pickle returns the pickled form of o as produced by Python's
+// pickle.dumps(o), or the raised exception as an error, such as a TypeError or
+// a pickle.PicklingError for an object that can't be pickled (e.g. a lambda or
+// a module). The pickle module is imported on first use.
+func (o *Object) Pickle() ([]byte, error) {
+	mod := ImportModule(c.Str("pickle"))
+	if mod == nil {
+		return nil, fetchError()
+	}
+	defer mod.DecRef()
+	b := mod.CallMethodObjArgs(Str("dumps"), o, (*Object)(nil))
+	if b == nil {
+		return nil, fetchError()
+	}
+	defer b.DecRef()
+	return b.Bytes()
+}
+
+// Unpickle returns a new object rebuilt from data, the output of Pickle or of
+// Python's pickle.dumps, as pickle.loads(data) does, or the raised exception
+// as an error, such as an UnpicklingError for data that isn't a pickle. Like
+// pickle.loads, it may run arbitrary code: never unpickle untrusted data.
+func Unpickle(data []byte) (*Object, error) {
+	mod := ImportModule(c.Str("pickle"))
+	if mod == nil {
+		return nil, fetchError()
+	}
+	defer mod.DecRef()
+	b := bytesFromStringAndSize((*c.Char)(unsafe.Pointer(unsafe.SliceData(data))), len(data))
+	if b == nil {
+		return nil, fetchError()
+	}
+	defer b.DecRef()
+	o := mod.CallMethodObjArgs(Str("loads"), b, (*Object)(nil))
+	if o == nil {
+		return nil, fetchError()
+	}
+	return o, nil
+}
+
 // -----------------------------------------------------------------------------
 
 // Retrieve an attribute named attrName from object o. Returns the attribute value on success,
@@ -65,4 +189,350 @@ func (o *Object) GetAttr(attrName *Object) *Object { return nil }
 // llgo:link (*Object).GetAttrString C.PyObject_GetAttrString
 func (o *Object) GetAttrString(attrName *c.Char) *Object { return nil }
 
+// Set the value of the attribute named attrName, for object o, to the value v.
+// Raise an exception and return -1 on failure; return 0 on success. This is the
+// equivalent of the Python statement o.attrName = v. If v is nil, the attribute
+// is deleted.
+//
+// llgo:link (*Object).SetAttr C.PyObject_SetAttr
+func (o *Object) SetAttr(attrName, v *Object) c.Int { return -1 }
+
+// llgo:link (*Object).SetAttrString C.PyObject_SetAttrString
+func (o *Object) SetAttrString(attrName *c.Char, v *Object) c.Int { return -1 }
+
+// HasAttr reports whether o has an attribute named attrName. This is equivalent
+// to the Python expression hasattr(o, attrName). Exceptions raised while looking
+// up the attribute are suppressed, so a missing attribute never leaves the error
+// indicator set.
+func (o *Object) HasAttr(attrName *Object) bool {
+	return objectHasAttr(o, attrName) != 0
+}
+
+// HasAttrString is like HasAttr, with the attribute name given as a Go string.
+func (o *Object) HasAttrString(attrName string) bool {
+	return objectHasAttrString(o, c.AllocaCStr(attrName)) != 0
+}
+
+//go:linkname objectHasAttr C.PyObject_HasAttr
+func objectHasAttr(o, attrName *Object) c.Int
+
+//go:linkname objectHasAttrString C.PyObject_HasAttrString
+func objectHasAttrString(o *Object, attrName *c.Char) c.Int
+
+// Dir returns the attribute names of o, sorted, as listed by the Python
+// expression dir(o). It returns nil, with the error indicator cleared, if
+// dir(o) raises an exception, such as one from a custom __dir__ method.
+func (o *Object) Dir() []string {
+	names := objectDir(o)
+	if names == nil {
+		ErrClear()
+		return nil
+	}
+	defer names.DecRef()
+	n := names.ListLen()
+	ret := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		s, size := names.ListItem(i).CStrAndLen()
+		if s == nil { // a non-str name returned by __dir__
+			ErrClear()
+			continue
+		}
+		ret = append(ret, c.GoString(s, size))
+	}
+	return ret
+}
+
+//go:linkname objectDir C.PyObject_Dir
+func objectDir(o *Object) *Object
+
+// -----------------------------------------------------------------------------
+
+// IsInstance reports whether o is an instance of the class cls or of a
+// subclass of it. This is equivalent to the Python expression
+// isinstance(o, cls), so cls may also be a tuple of classes. If cls is not a
+// class, the TypeError raised is cleared and false is returned.
+func (o *Object) IsInstance(cls *Object) bool {
+	return checkResult(objectIsInstance(o, cls))
+}
+
+// IsSubclass reports whether the class cls is derived from the class base,
+// or is base itself. This is equivalent to the Python expression
+// issubclass(cls, base), so base may also be a tuple of classes, and classes
+// registered with an abc.ABC count as subclasses of it. If cls or base is
+// not a class, the TypeError raised is cleared and false is returned.
+func (cls *Object) IsSubclass(base *Object) bool {
+	return checkResult(objectIsSubclass(cls, base))
+}
+
+// checkResult converts the 1, 0 or -1 returned by a C API predicate to a
+// bool, clearing the error indicator on failure.
+func checkResult(ret c.Int) bool {
+	if ret < 0 {
+		ErrClear()
+		return false
+	}
+	return ret != 0
+}
+
+//go:linkname objectIsInstance C.PyObject_IsInstance
+func objectIsInstance(o, cls *Object) c.Int
+
+//go:linkname objectIsSubclass C.PyObject_IsSubclass
+func objectIsSubclass(cls, base *Object) c.Int
+
+// The predicates below are the type checks of the C API, such as
+// PyList_Check: a test of the flags or the identity of the type of o,
+// cheaper than IsInstance, which dispatches through __instancecheck__. Like
+// them, they report true for instances of subclasses, so IsInt is true for a
+// bool, but not for objects that only register as virtual subclasses, such
+// as a collections.abc.Sequence.
+
+// IsList reports whether o is a list, or an instance of a subclass of list.
+func (o *Object) IsList() bool { return typeHasFlag(o, tpFlagsListSubclass) }
+
+// IsTuple reports whether o is a tuple, or an instance of a subclass of
+// tuple, such as a namedtuple.
+func (o *Object) IsTuple() bool { return typeHasFlag(o, tpFlagsTupleSubclass) }
+
+// IsDict reports whether o is a dict, or an instance of a subclass of dict,
+// such as a collections.OrderedDict.
+func (o *Object) IsDict() bool { return typeHasFlag(o, tpFlagsDictSubclass) }
+
+// IsStr reports whether o is a str, or an instance of a subclass of str.
+func (o *Object) IsStr() bool { return typeHasFlag(o, tpFlagsUnicodeSubclass) }
+
+// IsBytes reports whether o is a bytes object, or an instance of a subclass
+// of bytes; a bytearray is not.
+func (o *Object) IsBytes() bool { return typeHasFlag(o, tpFlagsBytesSubclass) }
+
+// IsInt reports whether o is an int, or an instance of a subclass of int,
+// such as a bool or an enum.IntEnum member.
+func (o *Object) IsInt() bool { return typeHasFlag(o, tpFlagsLongSubclass) }
+
+// IsFloat reports whether o is a float, or an instance of a subclass of
+// float. There is no type flag for float, so the type of o is checked by
+// PyType_IsSubtype, which walks its MRO instead.
+func (o *Object) IsFloat() bool {
+	typ := o.Type()
+	ret := typ == &floatType || typeIsSubtype(typ, &floatType) != 0
+	typ.DecRef()
+	return ret
+}
+
+// IsBool reports whether o is True or False; bool can't be subclassed.
+func (o *Object) IsBool() bool {
+	return o == &trueStruct || o == &falseStruct
+}
+
+// Bits of tp_flags, which CPython sets on the types deriving from the
+// built-in types they stand for.
+const (
+	tpFlagsLongSubclass    = 1 << 24
+	tpFlagsListSubclass    = 1 << 25
+	tpFlagsTupleSubclass   = 1 << 26
+	tpFlagsBytesSubclass   = 1 << 27
+	tpFlagsUnicodeSubclass = 1 << 28
+	tpFlagsDictSubclass    = 1 << 29
+)
+
+// typeHasFlag reports whether the type of o has flag set in its tp_flags.
+func typeHasFlag(o *Object, flag uint32) bool {
+	typ := o.Type()
+	ret := typ.TypeFlags()&flag != 0
+	typ.DecRef()
+	return ret
+}
+
+//go:linkname typeIsSubtype C.PyType_IsSubtype
+func typeIsSubtype(a, b *Object) c.Int
+
+// -----------------------------------------------------------------------------
+
+// CompareOp is a rich comparison operator.
+type CompareOp c.Int
+
+const (
+	LT CompareOp = iota // Py_LT, <
+	LE                  // Py_LE, <=
+	EQ                  // Py_EQ, ==
+	NE                  // Py_NE, !=
+	GT                  // Py_GT, >
+	GE                  // Py_GE, >=
+)
+
+// Compare the values of o1 and o2 using the operation specified by op, which
+// must be one of LT, LE, EQ, NE, GT, or GE. This is the equivalent of the
+// Python expression o1 op o2. Returns the value of the comparison on success,
+// or nil on failure.
+//
+// llgo:link (*Object).RichCompare C.PyObject_RichCompare
+func (o1 *Object) RichCompare(o2 *Object, op CompareOp) *Object { return nil }
+
+// Compare the values of o1 and o2 using the operation specified by op. Returns
+// -1 on error, 0 if the result is false, 1 otherwise. This is the equivalent of
+// the Python expression o1 op o2, followed by a truth test.
+//
+// Note: If o1 and o2 are the same object, RichCompareBool will always return 1
+// for EQ and 0 for NE.
+//
+// llgo:link (*Object).RichCompareBool C.PyObject_RichCompareBool
+func (o1 *Object) RichCompareBool(o2 *Object, op CompareOp) c.Int { return -1 }
+
+// Compare is like RichCompareBool, but reports a failed comparison, such as a
+// TypeError for "a" < 1, as an error, clearing the error indicator.
+func (a *Object) Compare(b *Object, op CompareOp) (bool, error) {
+	switch a.RichCompareBool(b, op) {
+	case -1:
+		return false, fetchError()
+	case 0:
+		return false, nil
+	}
+	return true, nil
+}
+
+// Lt reports whether a < b. Like the other comparison methods below, it
+// returns false with the error indicator cleared if the comparison raises an
+// exception, e.g. for unorderable operands; use Compare to tell such a
+// failure from a false result.
+func (a *Object) Lt(b *Object) bool { return checkResult(a.RichCompareBool(b, LT)) }
+
+// Le reports whether a <= b.
+func (a *Object) Le(b *Object) bool { return checkResult(a.RichCompareBool(b, LE)) }
+
+// Eq reports whether a == b.
+//
+// Eq has no Go-side fast path for ints: llgo calls RichCompareBool directly,
+// without a cgo-style transition, and CPython already short-circuits
+// identical objects, which include the cached small ints, and compares
+// two ints without dispatching through __eq__.
+func (a *Object) Eq(b *Object) bool { return checkResult(a.RichCompareBool(b, EQ)) }
+
+// Ne reports whether a != b.
+func (a *Object) Ne(b *Object) bool { return checkResult(a.RichCompareBool(b, NE)) }
+
+// Gt reports whether a > b.
+func (a *Object) Gt(b *Object) bool { return checkResult(a.RichCompareBool(b, GT)) }
+
+// Ge reports whether a >= b.
+func (a *Object) Ge(b *Object) bool { return checkResult(a.RichCompareBool(b, GE)) }
+
+// DeepEqual reports whether a and b are equal, for assertions over Python
+// values of mixed types. It is a == b where that comparison is defined, and
+// falls back to comparing repr(a) with repr(b) where it isn't:
+//
+//   - when a == b raises an exception, such as a numpy array's ambiguous
+//     truth value; the exception is cleared;
+//   - when neither type defines __eq__, so that == only tests identity.
+//
+// Equal reprs stand for equal values, so an instance of a class without
+// __eq__ only equals another one if the class defines a __repr__ showing its
+// state: the default repr includes the address of the object. DeepEqual
+// returns false if either repr fails.
+func DeepEqual(a, b *Object) bool {
+	switch a.RichCompareBool(b, EQ) {
+	case 1:
+		return true
+	case 0:
+		if definesEq(a) || definesEq(b) {
+			return false
+		}
+	default:
+		ErrClear()
+	}
+	ra, ok := reprString(a)
+	if !ok {
+		return false
+	}
+	rb, ok := reprString(b)
+	return ok && ra == rb
+}
+
+// definesEq reports whether the type of o has an __eq__ of its own, rather
+// than the one inherited from object.
+func definesEq(o *Object) bool {
+	typ := o.Type()
+	eq := typ.GetAttrString(c.Str("__eq__"))
+	typ.DecRef()
+	if eq == nil {
+		ErrClear()
+		return false
+	}
+	defer eq.DecRef()
+	objEq := baseObjectType.GetAttrString(c.Str("__eq__"))
+	if objEq == nil {
+		ErrClear()
+		return true
+	}
+	ret := eq != objEq
+	objEq.DecRef()
+	return ret
+}
+
+// reprString returns the repr() of o as a Go string.
+func reprString(o *Object) (string, bool) {
+	r := o.Repr()
+	if r == nil {
+		ErrClear()
+		return "", false
+	}
+	defer r.DecRef()
+	return c.GoString(r.CStr()), true
+}
+
+//go:linkname baseObjectType PyBaseObject_Type
+var baseObjectType Object
+
+// -----------------------------------------------------------------------------
+
+// Return element of o corresponding to the object key or nil on failure. This is
+// the equivalent of the Python expression o[key]. Passing a slice object created
+// by NewSlice as key slices o, which also works for objects such as numpy arrays
+// that implement their own indexing.
+//
+// llgo:link (*Object).GetItem C.PyObject_GetItem
+func (o *Object) GetItem(key *Object) *Object { return nil }
+
+// Item returns o[key], the same way for an index into a sequence and a key
+// of a mapping: key is a Go int or string, converted to a Python int or
+// str, or an *Object used as it is. An exception raised by o[key], such as
+// an IndexError or a KeyError, is returned as an error and cleared.
+func (o *Object) Item(key any) (*Object, error) {
+	k, err := itemKey(key)
+	if err != nil {
+		return nil, err
+	}
+	defer k.DecRef()
+	ret := o.GetItem(k)
+	if ret == nil {
+		return nil, fetchError()
+	}
+	return ret, nil
+}
+
+// SetItem sets o[key] = v, with key as for Item. It doesn't steal a reference
+// to v.
+func (o *Object) SetItem(key any, v *Object) error {
+	k, err := itemKey(key)
+	if err != nil {
+		return err
+	}
+	defer k.DecRef()
+	if objectSetItem(o, k, v) < 0 {
+		return fetchError()
+	}
+	return nil
+}
+
+// itemKey returns a new reference to the Python key of Item and SetItem.
+func itemKey(key any) (*Object, error) {
+	switch key.(type) {
+	case int, string, *Object:
+		return FromGo(key)
+	}
+	return nil, fmt.Errorf("py: cannot use %T as an item key, want int, string or *Object", key)
+}
+
+//go:linkname objectSetItem C.PyObject_SetItem
+func objectSetItem(o, key, v *Object) c.Int
+
 // -----------------------------------------------------------------------------
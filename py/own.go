@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package py
+
+import "runtime"
+
+// An Owned holds a reference to a Python object on behalf of Go code, and
+// releases it once the Owned itself is garbage collected, for exploratory
+// code that would rather not track each DecRef. An *Object points to Python
+// memory, which the Go garbage collector doesn't manage, so the reference is
+// boxed in a Go value that it does.
+//
+// The release happens at an unpredictable point, and on whichever goroutine
+// runs the collector's finalizers, which acquires the GIL for it. Programs
+// built without the garbage collector never run finalizers: Release is the
+// only way an Owned gives up its reference there.
+type Owned struct {
+	o *Object
+}
+
+// Own takes over the reference o, which must be a new (strong) reference,
+// such as the result of a call, and returns an Owned holding it. Own(nil)
+// returns nil, so that a failed call can be wrapped directly.
+func Own(o *Object) *Owned {
+	if o == nil {
+		return nil
+	}
+	p := &Owned{o}
+	runtime.SetFinalizer(p, releaseOwned)
+	return p
+}
+
+// Object returns the object held by p as a borrowed reference, valid as long
+// as p is reachable and not released. It returns nil after Release.
+func (p *Owned) Object() *Object {
+	return p.o
+}
+
+// Release gives up the reference held by p right away instead of when p is
+// collected. The calling goroutine must hold the GIL. Release may be called
+// more than once; the later calls do nothing.
+func (p *Owned) Release() {
+	if o := p.o; o != nil {
+		p.o = nil
+		o.DecRef()
+	}
+}
+
+// releaseOwned is the finalizer of an Owned. It may run on any goroutine, so
+// it takes the GIL first, and does nothing once Python is finalized.
+func releaseOwned(p *Owned) {
+	if p.o == nil || isInitialized() == 0 {
+		return
+	}
+	state := GILEnsure()
+	p.Release()
+	GILRelease(state)
+}
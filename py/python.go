@@ -17,13 +17,14 @@
 package py
 
 import (
-	_ "unsafe"
+	"os"
+	"unsafe"
 
 	"github.com/goplus/llgo/c"
 )
 
 const (
-	LLGoFiles   = "_pyg/module.c"
+	LLGoFiles   = "$(pkg-config --cflags python3-embed): _pyg/module.c; _pyg/datetime.c; _pyg/sequence.c"
 	LLGoPackage = "link: $LLGO_LIB_PYTHON; $(pkg-config --libs python3-embed)"
 )
 
@@ -59,6 +60,74 @@ func RunSimpleFile(fp c.FilePtr, filename *c.Char) c.Int
 //go:linkname RunSimpleFileFlags C.PyRun_SimpleFileFlags
 func RunSimpleFileFlags(fp c.FilePtr, filename *c.Char, flags *CompilerFlags) c.Int
 
+// Execute Python source code from str in the context specified by the objects
+// globals and locals. The parameter start specifies the start token that should
+// be used to parse the source code. Returns the result of executing the code as
+// a Python object, or nil if an exception was raised.
+//
+//go:linkname RunString C.PyRun_String
+func RunString(str *c.Char, start InputType, globals, locals *Object) *Object
+
+// EvalWith evaluates the Python expression expr with the variables in locals
+// in scope, and returns its value or the raised exception as a Go error.
+//
+// The variables are made available as the globals of the evaluation, so that
+// they are also visible inside lambdas and generator expressions such as
+// sum(x*k for x in xs).
+func EvalWith(expr string, locals map[string]*Object) (*Object, error) {
+	dict := NewDict()
+	defer dict.DecRef()
+	for name, v := range locals {
+		key := FromGoString(name)
+		dict.DictSetItem(key, v)
+		key.DecRef()
+	}
+	ret := RunString(c.AllocaCStr(expr), EvalInput, dict, dict)
+	if ret == nil {
+		return nil, fetchError()
+	}
+	return ret, nil
+}
+
+// RunFile executes the Python script at path in the context specified by the
+// objects globals and locals, and returns the result, which is None for a
+// script. The path is the file name of the compiled code, so it appears in
+// tracebacks and SyntaxError messages. If globals is nil, a new dictionary is
+// used, with __name__ set to "__main__" and __file__ to path, as when running
+// "python path"; if locals is nil, it defaults to globals.
+//
+// An exception raised while compiling or running the script is returned as an
+// error whose text is the traceback Python would print, naming the script
+// file and line of each frame. A failure to read the file is returned as is.
+func RunFile(path string, globals, locals *Object) (*Object, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	src = append(src, 0)
+	code := CompileString((*c.Char)(unsafe.Pointer(&src[0])), c.AllocaCStr(path), FileInput)
+	if code == nil {
+		return nil, fetchTraceback()
+	}
+	defer code.DecRef()
+	if globals == nil {
+		globals = NewDict()
+		defer globals.DecRef()
+		globals.DictSetItem(Str("__name__"), Str("__main__"))
+		file := FromGoString(path)
+		globals.DictSetItem(Str("__file__"), file)
+		file.DecRef()
+	}
+	if locals == nil {
+		locals = globals
+	}
+	ret := EvalCode(code, globals, locals)
+	if ret == nil {
+		return nil, fetchTraceback()
+	}
+	return ret, nil
+}
+
 // -----------------------------------------------------------------------------
 
 type InputType c.Int
@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package py
+
+import (
+	"io"
+	"unsafe"
+
+	"github.com/goplus/llgo/c"
+)
+
+// RedirectStdout replaces sys.stdout with a file-like object that forwards
+// what Python code writes, e.g. with print, to w as UTF-8. It returns a
+// function that puts the original sys.stdout back.
+//
+// If w.Write fails, the error is raised in Python as an OSError from the
+// write call.
+func RedirectStdout(w io.Writer) (restore func()) {
+	old := sysGetObject(c.Str("stdout"))
+	if old != nil {
+		old.IncRef()
+	}
+	file := newWriterFile(w)
+	sysSetObject(c.Str("stdout"), file)
+	file.DecRef()
+	return func() {
+		sysSetObject(c.Str("stdout"), old)
+		if old != nil {
+			old.DecRef()
+		}
+	}
+}
+
+// newWriterFile returns a module object with write and flush functions bound
+// to a handle of w: that is enough of a file for print and sys.stdout users.
+func newWriterFile(w io.Writer) *Object {
+	file := moduleNew(c.Str("llgo.writer"))
+	self := NewHandle(w)
+	write := cfunctionNew(&writerWriteDef, self, nil)
+	flush := cfunctionNew(&writerFlushDef, self, nil)
+	self.DecRef()
+	file.SetAttrString(c.Str("write"), write)
+	file.SetAttrString(c.Str("flush"), flush)
+	write.DecRef()
+	flush.DecRef()
+	return file
+}
+
+// a methodDef is a PyMethodDef, which describes a Python function
+// implemented in Go.
+type methodDef struct {
+	name  *c.Char
+	meth  c.Pointer // func(self, arg *Object) *Object, self being the bound object
+	flags c.Int
+	doc   *c.Char
+}
+
+const (
+	methNoArgs = 0x0004 // METH_NOARGS: arg is always nil
+	methO      = 0x0008 // METH_O: arg is the single positional argument
+)
+
+var (
+	writerWriteDef = methodDef{c.Str("write"), c.Func(writerWrite), methO, nil}
+	writerFlushDef = methodDef{c.Str("flush"), c.Func(writerFlush), methNoArgs, nil}
+)
+
+func writerWrite(self, arg *Object) *Object {
+	w := self.HandleValue().(io.Writer)
+	s, n := arg.CStrAndLen()
+	if s == nil {
+		return nil
+	}
+	if _, err := w.Write(unsafe.Slice((*byte)(unsafe.Pointer(s)), n)); err != nil {
+		errSetString(excOSError, c.AllocaCStr(err.Error()))
+		return nil
+	}
+	return Uintptr(uintptr(arg.SeqLen()))
+}
+
+func writerFlush(self, arg *Object) *Object {
+	if f, ok := self.HandleValue().(interface{ Flush() error }); ok {
+		if err := f.Flush(); err != nil {
+			errSetString(excOSError, c.AllocaCStr(err.Error()))
+			return nil
+		}
+	}
+	none.IncRef()
+	return &none
+}
+
+//go:linkname cfunctionNew C.PyCFunction_NewEx
+func cfunctionNew(ml *methodDef, self, module *Object) *Object
+
+//go:linkname moduleNew C.PyModule_New
+func moduleNew(name *c.Char) *Object
+
+//go:linkname sysGetObject C.PySys_GetObject
+func sysGetObject(name *c.Char) *Object
+
+//go:linkname sysSetObject C.PySys_SetObject
+func sysSetObject(name *c.Char, v *Object) c.Int
+
+//go:linkname errSetString C.PyErr_SetString
+func errSetString(typ *Object, msg *c.Char)
+
+//go:linkname excOSError PyExc_OSError
+var excOSError *Object
+
+//go:linkname none _Py_NoneStruct
+var none Object
@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package py
+
+import (
+	"unsafe"
+
+	"github.com/goplus/llgo/c"
+)
+
+// https://docs.python.org/3/c-api/sequence.html
+
+// Return the slice of sequence object o between lo and hi. Return nil on failure.
+// This is the equivalent of the Python expression o[lo:hi]; negative indices are
+// interpreted relative to the end of the sequence, as in Python.
+//
+// llgo:link (*Object).SeqGetSlice C.PySequence_GetSlice
+func (o *Object) SeqGetSlice(lo, hi int) *Object { return nil }
+
+// Returns the number of objects in sequence o on success, and -1 on failure.
+// This is equivalent to the Python expression len(o).
+//
+// llgo:link (*Object).SeqLen C.PySequence_Size
+func (o *Object) SeqLen() int { return 0 }
+
+// Return the ith element of o, or nil on failure. This is the equivalent of
+// the Python expression o[i].
+//
+// llgo:link (*Object).SeqGetItem C.PySequence_GetItem
+func (o *Object) SeqGetItem(i int) *Object { return nil }
+
+// AsFast returns the sequence or iterable o as a list or tuple, for use with
+// FastItems. A list or tuple is returned as is, with a new reference; other
+// iterables are collected into a new list. The result must be DecRef'd. An
+// error is returned, and the error indicator cleared, if o isn't iterable.
+func AsFast(o *Object) (*Object, error) {
+	f := sequenceFast(o, c.Str("py.AsFast: expected an iterable"))
+	if f == nil {
+		return nil, fetchError()
+	}
+	return f, nil
+}
+
+// FastItems returns the items of f, a result of AsFast, as a slice sharing the
+// underlying array of the list or tuple. The items are borrowed references.
+// The slice is only valid as long as f is alive and, for a list, isn't
+// resized; assign through ListSetItem rather than the slice.
+func (f *Object) FastItems() []*Object {
+	n := sequenceFastSize(f)
+	if n == 0 {
+		return nil
+	}
+	return unsafe.Slice(sequenceFastItems(f), n)
+}
+
+//go:linkname sequenceFast C.PySequence_Fast
+func sequenceFast(o *Object, msg *c.Char) *Object
+
+//go:linkname sequenceFastSize C.llgoPySequenceFastSize
+func sequenceFastSize(o *Object) int
+
+//go:linkname sequenceFastItems C.llgoPySequenceFastItems
+func sequenceFastItems(o *Object) **Object
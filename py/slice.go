@@ -0,0 +1,33 @@
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package py
+
+import (
+	_ "unsafe"
+)
+
+// https://docs.python.org/3/c-api/slice.html
+
+// Return a new slice object with the given values. The start, stop, and step
+// parameters are used as the values of the slice object attributes of the same
+// names. Any of the values may be nil, in which case None will be used for the
+// corresponding attribute, so an open-ended slice such as a[:5] is written as
+// NewSlice(nil, py.Long(5), nil). Return nil with an exception set if the new
+// object could not be allocated.
+//
+//go:linkname NewSlice C.PySlice_New
+func NewSlice(start, stop, step *Object) *Object
@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package py
+
+import (
+	"errors"
+	"time"
+	_ "unsafe"
+
+	"github.com/goplus/llgo/c"
+)
+
+// ErrTimeout is the error returned by CallWithTimeout for a call that was
+// interrupted, or never started, because it ran out of time.
+var ErrTimeout = errors.New("py: call timed out")
+
+// CallWithTimeout calls fn(*args) as fn.Call(args, nil) does, but on a
+// goroutine of its own, and interrupts the call if it hasn't returned after
+// d: it raises a TimeoutError in the thread running it, with
+// PyThreadState_SetAsyncExc, and returns ErrTimeout once the call has
+// unwound. The result, or the exception raised, is returned otherwise, as
+// by a call that returns or handles the TimeoutError.
+//
+// The calling goroutine must hold the GIL, as for any call to Python, and
+// releases it until the call is over. Like the KeyboardInterrupt of a
+// Ctrl-C, the TimeoutError is only raised as the interpreter runs Python
+// code: a call blocked in C code, such as time.sleep or a lock, is only
+// interrupted when that returns, and CallWithTimeout waits for it until then.
+func CallWithTimeout(fn, args *Object, d time.Duration) (*Object, error) {
+	type result struct {
+		ret *Object
+		err error
+	}
+	done := make(chan result, 1)
+	// Shared with the goroutine, under the GIL: the thread making the call,
+	// whether the call has returned, and whether it is too late to start it.
+	var tid c.Ulong
+	var returned, canceled bool
+
+	tstate := SaveThread()
+	go func() {
+		state := GILEnsure()
+		defer GILRelease(state)
+		if canceled {
+			done <- result{nil, ErrTimeout}
+			return
+		}
+		tid = threadGetIdent()
+		ret := fn.Call(args, nil)
+		returned = true
+		if ret == nil {
+			done <- result{nil, fetchError()}
+			return
+		}
+		done <- result{ret, nil}
+	}()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	var r result
+	interrupted := false
+	select {
+	case r = <-done:
+	case <-timer.C:
+		RestoreThread(tstate)
+		switch {
+		case tid == 0:
+			canceled = true
+		case !returned:
+			threadStateSetAsyncExc(tid, excTimeoutError)
+			interrupted = true
+		}
+		tstate = SaveThread()
+		r = <-done
+	}
+	RestoreThread(tstate)
+	if interrupted && r.err != nil {
+		return nil, ErrTimeout
+	}
+	return r.ret, r.err
+}
+
+//go:linkname threadGetIdent C.PyThread_get_thread_ident
+func threadGetIdent() c.Ulong
+
+//go:linkname threadStateSetAsyncExc C.PyThreadState_SetAsyncExc
+func threadStateSetAsyncExc(id c.Ulong, exc *Object) c.Int
+
+//go:linkname excTimeoutError PyExc_TimeoutError
+var excTimeoutError *Object
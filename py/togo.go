@@ -0,0 +1,327 @@
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package py
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/goplus/llgo/c"
+)
+
+// ToGo converts o to the equivalent Go value:
+//
+//	None        nil
+//	bool        bool
+//	int         int64
+//	float       float64
+//	str         string
+//	bytes       []byte
+//	list, tuple []any
+//	dict        map[string]any
+//
+// Instances of subclasses of these types, such as an enum.IntEnum member,
+// convert like the base type. The items of lists, tuples and dicts are
+// converted recursively; o must not contain itself. An error is returned for
+// an object of any other type, a dict key that isn't a str, or an int that
+// overflows int64.
+func (o *Object) ToGo() (any, error) {
+	switch {
+	case o == &none:
+		return nil, nil
+	case o.IsInstance(&boolType):
+		return o == &trueStruct, nil
+	case o.IsInstance(&longType):
+		v := int64(o.LongLong())
+		if v == -1 && ErrOccurred() != nil {
+			return nil, fetchError()
+		}
+		return v, nil
+	case o.IsInstance(&floatType):
+		v, err := o.Float64Checked()
+		if err != nil {
+			return nil, err
+		}
+		return v, nil
+	case o.IsInstance(&unicodeType):
+		s, n := o.CStrAndLen()
+		if s == nil {
+			return nil, fetchError()
+		}
+		return c.GoString(s, n), nil
+	case o.IsInstance(&bytesType):
+		v, err := o.Bytes()
+		if err != nil {
+			return nil, err
+		}
+		return v, nil
+	case o.IsInstance(&listType):
+		return seqToGo(o.ListLen(), o.ListItem)
+	case o.IsInstance(&tupleType):
+		return seqToGo(o.TupleLen(), o.TupleItem)
+	case o.IsInstance(&dictType):
+		return dictToGo(o)
+	}
+	return nil, fmt.Errorf("py: cannot convert %s to a Go value", typeName(o))
+}
+
+// seqToGo converts the n items returned by item to a []any.
+func seqToGo(n int, item func(int) *Object) (any, error) {
+	ret := make([]any, n)
+	for i := range ret {
+		v, err := item(i).ToGo()
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = v
+	}
+	return ret, nil
+}
+
+// dictToGo converts the dict d to a map[string]any.
+func dictToGo(d *Object) (any, error) {
+	ret := make(map[string]any, d.DictSize())
+	var pos int
+	var key, val *Object
+	for dictNext(d, &pos, &key, &val) != 0 {
+		if !key.IsInstance(&unicodeType) {
+			return nil, fmt.Errorf("py: cannot convert dict key of type %s to a Go string", typeName(key))
+		}
+		k, err := key.ToGo()
+		if err != nil {
+			return nil, err
+		}
+		v, err := val.ToGo()
+		if err != nil {
+			return nil, err
+		}
+		ret[k.(string)] = v
+	}
+	return ret, nil
+}
+
+// ToFloat64Slice returns the items of iterable, such as a list or a tuple, as
+// a []float64. The items must be floats or ints, not bools, and are converted
+// as by Float64Checked. An error naming its index is returned for the first
+// item of any other type, or that doesn't fit a float64.
+func ToFloat64Slice(iterable *Object) ([]float64, error) {
+	f, err := AsFast(iterable)
+	if err != nil {
+		return nil, err
+	}
+	defer f.DecRef()
+	items := f.FastItems()
+	ret := make([]float64, len(items))
+	for i, item := range items {
+		if !item.IsFloat() && (!item.IsInt() || item.IsBool()) {
+			return nil, fmt.Errorf("py: cannot convert item %d of type %s to a Go float64", i, typeName(item))
+		}
+		v, err := item.Float64Checked()
+		if err != nil {
+			return nil, fmt.Errorf("py: item %d: %w", i, err)
+		}
+		ret[i] = v
+	}
+	return ret, nil
+}
+
+// ToStringSlice returns the items of iterable, such as a list or a tuple, as a
+// []string. An error naming its index is returned for the first item that
+// isn't a str.
+func ToStringSlice(iterable *Object) ([]string, error) {
+	f, err := AsFast(iterable)
+	if err != nil {
+		return nil, err
+	}
+	defer f.DecRef()
+	items := f.FastItems()
+	ret := make([]string, len(items))
+	for i, item := range items {
+		if !item.IsStr() {
+			return nil, fmt.Errorf("py: cannot convert item %d of type %s to a Go string", i, typeName(item))
+		}
+		s, n := item.CStrAndLen()
+		if s == nil {
+			return nil, fmt.Errorf("py: item %d: %w", i, fetchError())
+		}
+		ret[i] = c.GoString(s, n)
+	}
+	return ret, nil
+}
+
+// FromGo returns a new Python object with the value of v, the inverse of ToGo:
+//
+//	nil                        None
+//	bool                       bool
+//	int, int8, …, uint64       int
+//	float32, float64           float
+//	string                     str
+//	[]byte                     bytes
+//	[]any                      list
+//	map[string]any             dict
+//	*Object                    the object itself, with a new reference
+//
+// The elements of slices and maps are converted recursively. An error is
+// returned for a value of any other type, such as a struct or a []int.
+func FromGo(v any) (*Object, error) {
+	var o *Object
+	switch v := v.(type) {
+	case nil:
+		o = &none
+		o.IncRef()
+	case *Object:
+		o = v
+		o.IncRef()
+	case bool:
+		o = boolFromLong(c.Long(b2i(v)))
+	case int:
+		o = LongLong(c.LongLong(v))
+	case int8:
+		o = LongLong(c.LongLong(v))
+	case int16:
+		o = LongLong(c.LongLong(v))
+	case int32:
+		o = LongLong(c.LongLong(v))
+	case int64:
+		o = LongLong(c.LongLong(v))
+	case uint:
+		o = UlongLong(c.UlongLong(v))
+	case uint8:
+		o = UlongLong(c.UlongLong(v))
+	case uint16:
+		o = UlongLong(c.UlongLong(v))
+	case uint32:
+		o = UlongLong(c.UlongLong(v))
+	case uint64:
+		o = UlongLong(c.UlongLong(v))
+	case uintptr:
+		o = UlongLong(c.UlongLong(v))
+	case float32:
+		o = Float(float64(v))
+	case float64:
+		o = Float(v)
+	case string:
+		o = FromGoString(v)
+	case []byte:
+		o = bytesFromStringAndSize((*c.Char)(unsafe.Pointer(unsafe.SliceData(v))), len(v))
+	case []any:
+		return listFromGo(v)
+	case map[string]any:
+		return dictFromGo(v)
+	default:
+		return nil, fmt.Errorf("py: cannot convert %T to a Python object", v)
+	}
+	if o == nil {
+		return nil, fetchError()
+	}
+	return o, nil
+}
+
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// listFromGo converts the items of s to a new list.
+func listFromGo(s []any) (*Object, error) {
+	l := NewList(len(s))
+	if l == nil {
+		return nil, fetchError()
+	}
+	for i, v := range s {
+		item, err := FromGo(v)
+		if err != nil {
+			l.DecRef()
+			return nil, err
+		}
+		l.ListSetItem(i, item) // steals item
+	}
+	return l, nil
+}
+
+// dictFromGo converts the entries of m to a new dict.
+func dictFromGo(m map[string]any) (*Object, error) {
+	d := NewDict()
+	if d == nil {
+		return nil, fetchError()
+	}
+	for k, v := range m {
+		val, err := FromGo(v)
+		if err != nil {
+			d.DecRef()
+			return nil, err
+		}
+		key := FromGoString(k)
+		if key == nil { // not valid UTF-8
+			val.DecRef()
+			d.DecRef()
+			return nil, fetchError()
+		}
+		d.DictSetItem(key, val)
+		key.DecRef()
+		val.DecRef()
+	}
+	return d, nil
+}
+
+// typeName returns the qualified name of the type of o.
+func typeName(o *Object) string {
+	typ := o.Type()
+	defer typ.DecRef()
+	return attrString(typ, "__qualname__")
+}
+
+//go:linkname boolFromLong C.PyBool_FromLong
+func boolFromLong(v c.Long) *Object
+
+//go:linkname bytesFromStringAndSize C.PyBytes_FromStringAndSize
+func bytesFromStringAndSize(s *c.Char, size int) *Object
+
+//go:linkname dictNext C.PyDict_Next
+func dictNext(d *Object, pos *int, key, value **Object) c.Int
+
+//go:linkname trueStruct _Py_TrueStruct
+var trueStruct Object
+
+//go:linkname falseStruct _Py_FalseStruct
+var falseStruct Object
+
+//go:linkname boolType PyBool_Type
+var boolType Object
+
+//go:linkname longType PyLong_Type
+var longType Object
+
+//go:linkname floatType PyFloat_Type
+var floatType Object
+
+//go:linkname unicodeType PyUnicode_Type
+var unicodeType Object
+
+//go:linkname bytesType PyBytes_Type
+var bytesType Object
+
+//go:linkname listType PyList_Type
+var listType Object
+
+//go:linkname tupleType PyTuple_Type
+var tupleType Object
+
+//go:linkname dictType PyDict_Type
+var dictType Object
@@ -17,6 +17,8 @@
 package py
 
 import (
+	"errors"
+	"fmt"
 	_ "unsafe"
 )
 
@@ -49,8 +51,56 @@ func (t *Object) TupleItem(index int) *Object { return nil }
 func (t *Object) TupleSetItem(index int, o *Object) int { return 0 }
 
 // Return the slice of the tuple pointed to by t between low and high,
-// or NULL on failure. This is the equivalent of the Python expression
-// p[low:high]. Indexing from the end of the tuple is not supported.
-//
-// llgo:link (*Object).TupleSlice C.PyTuple_GetSlice
-func (l *Object) TupleSlice(low, high int) *Object { return nil }
+// or nil on failure. This is the equivalent of the Python expression
+// t[low:high]: negative indices count from the end of the tuple, and
+// out-of-range indices are clamped.
+func (t *Object) TupleSlice(low, high int) *Object {
+	if low < 0 || high < 0 {
+		n := t.TupleLen()
+		if n < 0 {
+			return nil
+		}
+		low, high = sliceIndex(low, n), sliceIndex(high, n)
+	}
+	return tupleGetSlice(t, low, high)
+}
+
+// sliceIndex resolves a negative slice index i of a sequence of length n
+// the way Python does. Indices past the end are left to the C API, which
+// clamps them.
+func sliceIndex(i, n int) int {
+	if i < 0 {
+		if i += n; i < 0 {
+			i = 0
+		}
+	}
+	return i
+}
+
+//go:linkname tupleGetSlice C.PyTuple_GetSlice
+func tupleGetSlice(t *Object, low, high int) *Object
+
+// UnpackTuple returns the items of the tuple t, after checking that it has
+// exactly n of them. Like TupleItem, the returned items are borrowed references.
+// An error is returned if t isn't a tuple or has a different size; a nil t,
+// e.g. the result of a failed call, reports the pending exception if any.
+func UnpackTuple(t *Object, n int) ([]*Object, error) {
+	if t == nil {
+		if err := fetchError(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("py.UnpackTuple: nil tuple")
+	}
+	size := t.TupleLen()
+	if size < 0 {
+		return nil, fetchError()
+	}
+	if size != n {
+		return nil, fmt.Errorf("py.UnpackTuple: expected a tuple of %d items, got %d", n, size)
+	}
+	items := make([]*Object, n)
+	for i := range items {
+		items[i] = t.TupleItem(i)
+	}
+	return items, nil
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package py
+
+import (
+	_ "unsafe"
+)
+
+// https://docs.python.org/3/c-api/weakref.html
+
+// NewWeakRef returns a weak reference object for o, or nil with an exception
+// set if o doesn't support weak references (as for ints, strs and lists;
+// sets and instances of user-defined classes do). The weak reference doesn't
+// keep o alive, which makes it suitable for caches.
+func NewWeakRef(o *Object) *Object {
+	return weakrefNewRef(o, nil)
+}
+
+//go:linkname weakrefNewRef C.PyWeakref_NewRef
+func weakrefNewRef(o, callback *Object) *Object
+
+// Return the referenced object from the weak reference r. If the referent is
+// no longer live, return None.
+//
+// The result is a borrowed reference: it stays valid only as long as
+// something else keeps the referent alive, which a weak reference by
+// definition does not. Call IncRef on the result before running any Python
+// code that might drop the last strong reference, and DecRef it when done.
+//
+// llgo:link (*Object).WeakRefGet C.PyWeakref_GetObject
+func (r *Object) WeakRefGet() *Object { return nil }
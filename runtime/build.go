@@ -15,9 +15,12 @@ func HasAltPkg(path string) (b bool) {
 type none struct{}
 
 var hasAltPkg = map[string]none{
+	"compress/zlib":            {},
+	"crypto/aes":               {},
 	"crypto/hmac":              {},
 	"crypto/md5":               {},
 	"crypto/rand":              {},
+	"crypto/rsa":               {},
 	"crypto/sha1":              {},
 	"crypto/sha256":            {},
 	"crypto/sha512":            {},
@@ -52,4 +55,7 @@ var hasAltPkg = map[string]none{
 	"runtime/trace":            {},
 	"runtime/internal/syscall": {},
 	"io":                       {},
+
+	"golang.org/x/crypto/hkdf":      {},
+	"golang.org/x/crypto/ripemd160": {},
 }
@@ -188,6 +188,15 @@ func GoString(cstr *Char, __llgo_va_list /* n */ ...any) string
 //go:linkname GoStringData llgo.stringData
 func GoStringData(string) *Char
 
+// GoBytes copies the n bytes at ptr into a new Go byte slice, so that the
+// result doesn't change with the C memory it came from. A zero n gives a
+// non-nil empty slice.
+//
+// This package only has declarations, so the copy is done by the runtime.
+//
+//go:linkname GoBytes github.com/goplus/llgo/runtime/internal/runtime.BytesFrom
+func GoBytes(ptr Pointer, n int) []byte
+
 //go:linkname GoDeferData llgo.deferData
 func GoDeferData() Pointer
 
@@ -154,3 +154,46 @@ func ERRPrintErrorsCb(cb func(str *c.Char, len uintptr, u unsafe.Pointer) c.Int,
 func ERRPrintErrorsFp(fp c.FilePtr)
 
 // -----------------------------------------------------------------------------
+
+// Error is a failure reported by OpenSSL, as returned by the Go packages
+// built on it. Code is the earliest error of the OpenSSL error queue of the
+// calling thread; Msg describes all the queued errors.
+type Error struct {
+	Pkg  string // the Go package reporting the error, e.g. "crypto/aes"
+	Op   string // the failing OpenSSL function, e.g. "EVP_CipherInit_ex"
+	Code Errno  // 0 if the queue was empty
+	Msg  string
+}
+
+func (e *Error) Error() string {
+	msg := e.Op + ": " + e.Msg
+	if e.Pkg != "" {
+		msg = e.Pkg + ": " + msg
+	}
+	return msg
+}
+
+// NewError drains the OpenSSL error queue of the calling thread into an
+// *Error reported by the package pkg for the failed operation op.
+func NewError(pkg, op string) *Error {
+	err := &Error{Pkg: pkg, Op: op}
+	buf := (*c.Char)(c.Alloca(256)) // ERR_error_string needs at least 256 bytes
+	for {
+		code := ERRGetError()
+		if code == 0 {
+			break
+		}
+		if err.Code == 0 {
+			err.Code = code
+		} else {
+			err.Msg += "; "
+		}
+		err.Msg += c.GoString(ERRErrorString(code, buf))
+	}
+	if err.Msg == "" {
+		err.Msg = "unknown error"
+	}
+	return err
+}
+
+// -----------------------------------------------------------------------------
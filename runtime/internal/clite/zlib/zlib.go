@@ -258,3 +258,218 @@ negative, the result has no meaning or utility.
 func Adler32Combine(adler1 c.Ulong, adler2 c.Ulong, len2 int64) c.Ulong
 
 // -----------------------------------------------------------------------------
+
+// Stream is z_stream, the state of a deflate or inflate stream. The
+// application sets NextIn and AvailIn to the input, NextOut and AvailOut to
+// the room for the output, and calls Deflate or Inflate, which advance them.
+// zlib keeps a pointer to the Stream in its internal state, so a Stream must
+// not be moved or copied between its Init and End calls.
+type Stream struct {
+	NextIn   *byte   // next input byte
+	AvailIn  c.Uint  // number of bytes available at NextIn
+	TotalIn  c.Ulong // total number of input bytes read so far
+	NextOut  *byte   // next output byte will go here
+	AvailOut c.Uint  // remaining free space at NextOut
+	TotalOut c.Ulong // total number of bytes output so far
+	Msg      *c.Char // last error message, nil if no error
+	state    c.Pointer
+	Zalloc   c.Pointer // used to allocate the internal state, nil for malloc
+	Zfree    c.Pointer // used to free the internal state, nil for free
+	Opaque   c.Pointer // private data object passed to Zalloc and Zfree
+	DataType c.Int     // best guess about the data type: binary or text
+	Adler    c.Ulong   // Adler-32 or CRC-32 value of the uncompressed data
+	reserved c.Ulong
+}
+
+/*
+ZEXTERN const char * ZEXPORT zlibVersion OF((void));
+
+The application can compare zlibVersion and ZLIB_VERSION for consistency.
+If the first character differs, the library code actually used is not
+compatible with the zlib.h header file used by the application.
+*/
+//go:linkname Version C.zlibVersion
+func Version() *c.Char
+
+// llgo:link (*Stream).deflateInit_ C.deflateInit_
+func (*Stream) deflateInit_(level c.Int, version *c.Char, streamSize c.Int) c.Int { return 0 }
+
+// llgo:link (*Stream).deflateInit2_ C.deflateInit2_
+func (*Stream) deflateInit2_(level, method, windowBits, memLevel, strategy c.Int, version *c.Char, streamSize c.Int) c.Int {
+	return 0
+}
+
+// llgo:link (*Stream).inflateInit_ C.inflateInit_
+func (*Stream) inflateInit_(version *c.Char, streamSize c.Int) c.Int { return 0 }
+
+// llgo:link (*Stream).inflateInit2_ C.inflateInit2_
+func (*Stream) inflateInit2_(windowBits c.Int, version *c.Char, streamSize c.Int) c.Int { return 0 }
+
+/*
+ZEXTERN int ZEXPORT deflateInit OF((z_streamp strm, int level));
+
+Initializes the internal stream state for compression. The fields Zalloc,
+Zfree and Opaque must be initialized before by the caller. level is
+DEFAULT_COMPRESSION, or between 0 and 9: 1 gives best speed, 9 gives best
+compression, 0 gives no compression at all.
+
+deflateInit returns OK if success, MEM_ERROR if there was not enough memory,
+STREAM_ERROR if level is not a valid compression level, or VERSION_ERROR if
+the zlib library version is incompatible with the version assumed by the
+caller.
+*/
+func (s *Stream) DeflateInit(level c.Int) c.Int {
+	return s.deflateInit_(level, Version(), c.Int(unsafe.Sizeof(*s)))
+}
+
+/*
+ZEXTERN int ZEXPORT deflateInit2 OF((z_streamp strm, int level, int method, int windowBits, int memLevel, int strategy));
+
+This is another version of deflateInit with more compression options.
+method must be DEFLATED. windowBits is the base two logarithm of the window
+size, 8..15; add 16 to write a gzip header and trailer instead of the zlib
+wrapper, or negate it to write raw deflate data. memLevel, 1..9, sets how
+much memory to use for the internal compression state, 8 by default.
+strategy is DEFAULT_STRATEGY, FILTERED, HUFFMAN_ONLY, RLE or FIXED.
+*/
+func (s *Stream) DeflateInit2(level, method, windowBits, memLevel, strategy c.Int) c.Int {
+	return s.deflateInit2_(level, method, windowBits, memLevel, strategy, Version(), c.Int(unsafe.Sizeof(*s)))
+}
+
+/*
+ZEXTERN int ZEXPORT deflate OF((z_streamp strm, int flush));
+
+deflate compresses as much data as possible, and stops when the input
+buffer becomes empty or the output buffer becomes full. flush is NO_FLUSH to
+let deflate decide how much data to accumulate before producing output;
+SYNC_FLUSH to flush all pending output, aligned on a byte boundary and ended
+by an empty stored block, so that the decompressor can get all the input
+data available so far; FULL_FLUSH to also reset the compression state; or
+FINISH to process the pending input, flush the pending output and write the
+trailer. If deflate returns with AvailOut == 0, it must be called again
+with more output space, with the same flush value.
+
+deflate returns OK if some progress has been made, STREAM_END if all input
+has been consumed and all output has been produced with FINISH,
+STREAM_ERROR if the stream state was inconsistent, or BUF_ERROR if no
+progress was possible.
+*/
+// llgo:link (*Stream).Deflate C.deflate
+func (*Stream) Deflate(flush c.Int) c.Int { return 0 }
+
+/*
+ZEXTERN int ZEXPORT deflateEnd OF((z_streamp strm));
+
+All dynamically allocated data structures for this stream are freed.
+deflateEnd returns OK if success, STREAM_ERROR if the stream state was
+inconsistent, DATA_ERROR if the stream was freed prematurely (some input or
+output was discarded).
+*/
+// llgo:link (*Stream).DeflateEnd C.deflateEnd
+func (*Stream) DeflateEnd() c.Int { return 0 }
+
+/*
+ZEXTERN int ZEXPORT deflateReset OF((z_streamp strm));
+
+This function is equivalent to deflateEnd followed by deflateInit, but does
+not free and reallocate the internal compression state. The stream will
+leave the compression level and any other attributes that may have been set
+unchanged.
+*/
+// llgo:link (*Stream).DeflateReset C.deflateReset
+func (*Stream) DeflateReset() c.Int { return 0 }
+
+/*
+ZEXTERN int ZEXPORT deflateSetDictionary OF((z_streamp strm,
+	const Bytef *dictionary, uInt  dictLength));
+
+Initializes the compression dictionary from the given byte sequence without
+producing any compressed output. It must be called immediately after
+deflateInit, deflateInit2 or deflateReset, before any call of deflate. The
+Adler-32 value of the dictionary is written to the zlib header.
+*/
+// llgo:link (*Stream).DeflateSetDictionary C.deflateSetDictionary
+func (*Stream) DeflateSetDictionary(dictionary *byte, dictLength c.Uint) c.Int { return 0 }
+
+/*
+ZEXTERN int ZEXPORT inflateInit OF((z_streamp strm));
+
+Initializes the internal stream state for decompression. The fields
+NextIn, AvailIn, Zalloc, Zfree and Opaque must be initialized before by the
+caller. inflateInit does not perform any decompression: the zlib header is
+processed by the first call of Inflate.
+
+inflateInit returns OK if success, MEM_ERROR if there was not enough
+memory, or VERSION_ERROR if the zlib library version is incompatible with
+the version assumed by the caller.
+*/
+func (s *Stream) InflateInit() c.Int {
+	return s.inflateInit_(Version(), c.Int(unsafe.Sizeof(*s)))
+}
+
+/*
+ZEXTERN int ZEXPORT inflateInit2 OF((z_streamp strm, int windowBits));
+
+This is another version of inflateInit with an extra parameter, the base
+two logarithm of the maximum window size, 8..15. Add 16 to decode only the
+gzip format, or 32 to detect a zlib or gzip header automatically; negate it
+to decode raw deflate data.
+*/
+func (s *Stream) InflateInit2(windowBits c.Int) c.Int {
+	return s.inflateInit2_(windowBits, Version(), c.Int(unsafe.Sizeof(*s)))
+}
+
+/*
+ZEXTERN int ZEXPORT inflate OF((z_streamp strm, int flush));
+
+inflate decompresses as much data as possible, and stops when the input
+buffer becomes empty or the output buffer becomes full. flush is usually
+NO_FLUSH.
+
+inflate returns OK if some progress has been made, STREAM_END if the end of
+the compressed data has been reached and all uncompressed output has been
+produced, NEED_DICT if a preset dictionary is needed at this point,
+DATA_ERROR if the input data was corrupted (the input stream does not
+conform to the zlib format or the check value does not match), STREAM_ERROR
+if the stream structure was inconsistent, MEM_ERROR if there was not enough
+memory, or BUF_ERROR if no progress was possible or if there was not enough
+room in the output buffer when FINISH is used. Msg is set to a description
+of a DATA_ERROR.
+*/
+// llgo:link (*Stream).Inflate C.inflate
+func (*Stream) Inflate(flush c.Int) c.Int { return 0 }
+
+/*
+ZEXTERN int ZEXPORT inflateEnd OF((z_streamp strm));
+
+All dynamically allocated data structures for this stream are freed.
+inflateEnd returns OK if success, or STREAM_ERROR if the stream state was
+inconsistent.
+*/
+// llgo:link (*Stream).InflateEnd C.inflateEnd
+func (*Stream) InflateEnd() c.Int { return 0 }
+
+/*
+ZEXTERN int ZEXPORT inflateReset OF((z_streamp strm));
+
+This function is equivalent to inflateEnd followed by inflateInit, but does
+not free and reallocate the internal decompression state. The stream will
+keep attributes that may have been set by inflateInit2.
+*/
+// llgo:link (*Stream).InflateReset C.inflateReset
+func (*Stream) InflateReset() c.Int { return 0 }
+
+/*
+ZEXTERN int ZEXPORT inflateSetDictionary OF((z_streamp strm,
+	const Bytef *dictionary, uInt  dictLength));
+
+Initializes the decompression dictionary from the given uncompressed byte
+sequence. It must be called immediately after a call of inflate that
+returned NEED_DICT, when the Adler-32 value of the dictionary chosen by the
+compressor is in Adler. inflateSetDictionary returns DATA_ERROR if the
+dictionary doesn't match that value.
+*/
+// llgo:link (*Stream).InflateSetDictionary C.inflateSetDictionary
+func (*Stream) InflateSetDictionary(dictionary *byte, dictLength c.Uint) c.Int { return 0 }
+
+// -----------------------------------------------------------------------------
@@ -0,0 +1,312 @@
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zlib
+
+import (
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"io"
+	"runtime"
+	"unsafe"
+
+	c "github.com/goplus/llgo/runtime/internal/clite"
+	"github.com/goplus/llgo/runtime/internal/clite/zlib"
+)
+
+const (
+	zlibDeflate   = 8
+	zlibMaxWindow = 7
+)
+
+var (
+	// ErrChecksum is returned when reading ZLIB data that has an invalid checksum.
+	ErrChecksum = errors.New("zlib: invalid checksum")
+	// ErrDictionary is returned when reading ZLIB data that has an invalid dictionary.
+	ErrDictionary = errors.New("zlib: invalid dictionary")
+	// ErrHeader is returned when reading ZLIB data that has an invalid header.
+	ErrHeader = errors.New("zlib: invalid header")
+
+	errReaderClosed = errors.New("zlib: read after Close")
+)
+
+// peeker is implemented by a *bufio.Reader, whose buffer a reader inflates in
+// place, discarding only the bytes that zlib consumed.
+type peeker interface {
+	Peek(n int) ([]byte, error)
+	Buffered() int
+	Discard(n int) (int, error)
+}
+
+// A reader inflates with the zlib library. No byte past the end of the stream
+// is consumed from an io.ByteReader, as with the standard library: a peeker
+// is inflated in place, an io.ByteReader that is also an io.Seeker, such as a
+// *bytes.Reader, is read by chunks and seeked back over the bytes left at the
+// end of the stream, and any other io.ByteReader is read one byte at a time.
+type reader struct {
+	pk     peeker        // r as a peeker, or nil
+	br     io.ByteReader // r as an io.ByteReader read byte by byte, or nil
+	seeker io.Seeker     // r as an io.Seeker read by chunks, or nil
+	r      io.Reader
+	buf    []byte // input read from r, unless it is a peeker
+	hdr    int    // bytes of the header in buf, before inflating
+	rerr   error  // error of the last read from r, once buf is used up
+
+	dict []byte
+	s    *zlib.Stream // nil before Reset and after the end of the stream
+	err  error
+}
+
+// Resetter resets a ReadCloser returned by NewReader or NewReaderDict
+// to switch to a new underlying Reader. This permits reusing a ReadCloser
+// instead of allocating a new one.
+type Resetter interface {
+	// Reset discards any buffered data and resets the Resetter as if it was
+	// newly initialized with the given reader.
+	Reset(r io.Reader, dict []byte) error
+}
+
+// NewReader creates a new ReadCloser.
+// Reads from the returned ReadCloser read and decompress data from r.
+// If r does not implement io.ByteReader, the decompressor may read more
+// data than necessary from r.
+// It is the caller's responsibility to call Close on the ReadCloser when done.
+// The zlib state of the ReadCloser is also released once it returns io.EOF,
+// or once the garbage collector finds it unreachable.
+//
+// The io.ReadCloser returned by NewReader also implements Resetter.
+func NewReader(r io.Reader) (io.ReadCloser, error) {
+	return NewReaderDict(r, nil)
+}
+
+// NewReaderDict is like NewReader but uses a preset dictionary.
+// NewReaderDict ignores the dictionary if the compressed data does not refer to it.
+// If the compressed data refers to a different dictionary, NewReaderDict returns ErrDictionary.
+//
+// The ReadCloser returned by NewReaderDict also implements Resetter.
+func NewReaderDict(r io.Reader, dict []byte) (io.ReadCloser, error) {
+	z := new(reader)
+	runtime.SetFinalizer(z, (*reader).end)
+	err := z.Reset(r, dict)
+	if err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+func (z *reader) Reset(r io.Reader, dict []byte) error {
+	z.end()
+	buf := z.buf
+	*z = reader{r: r, dict: dict}
+	size := bufSize
+	if pk, ok := r.(peeker); ok {
+		z.pk, size = pk, 0
+	} else if br, ok := r.(io.ByteReader); ok {
+		if z.seeker, ok = r.(io.Seeker); !ok {
+			z.br, size = br, 6 // room for the header
+		}
+	}
+	if len(buf) == size {
+		z.buf = buf
+	} else if size > 0 {
+		z.buf = make([]byte, size)
+	}
+
+	// Read the header (RFC 1950 section 2.2.), which inflate parses again,
+	// to report an invalid one here, as the standard library does.
+	h, err := z.header(2)
+	if err != nil {
+		z.err = err
+		return z.err
+	}
+	if (h[0]&0x0f != zlibDeflate) || (h[0]>>4 > zlibMaxWindow) || (binary.BigEndian.Uint16(h)%31 != 0) {
+		z.err = ErrHeader
+		return z.err
+	}
+	if h[1]&0x20 != 0 {
+		if h, err = z.header(6); err != nil {
+			z.err = err
+			return z.err
+		}
+		checksum := binary.BigEndian.Uint32(h[2:6])
+		if checksum != uint32(zlib.Adler32ZBytes(1, dict)) {
+			z.err = ErrDictionary
+			return z.err
+		}
+	}
+
+	s := new(zlib.Stream)
+	if ret := s.InflateInit(); ret != zlib.OK {
+		z.err = streamError("inflateInit", ret, s)
+		return z.err
+	}
+	if z.hdr > 0 {
+		s.NextIn, s.AvailIn = unsafe.SliceData(z.buf), c.Uint(z.hdr)
+	}
+	z.s = s
+	return nil
+}
+
+// header returns the first n bytes of the stream. They are peeked from a
+// peeker, and read into z.buf otherwise, to be inflated from there.
+func (z *reader) header(n int) (h []byte, err error) {
+	if z.pk != nil {
+		h, err = z.pk.Peek(n)
+	} else if z.br != nil {
+		for ; z.hdr < n && err == nil; z.hdr++ {
+			z.buf[z.hdr], err = z.br.ReadByte()
+		}
+		h = z.buf[:n]
+	} else {
+		var m int
+		m, err = io.ReadFull(z.r, z.buf[z.hdr:n])
+		z.hdr += m
+		h = z.buf[:n]
+	}
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return h, err
+}
+
+// fill reads more input into z.buf, once zlib has consumed all of it.
+func (z *reader) fill() error {
+	if z.rerr != nil {
+		return z.rerr
+	}
+	var n int
+	if z.br != nil {
+		z.buf[0], z.rerr = z.br.ReadByte()
+		if z.rerr == nil {
+			n = 1
+		}
+	} else {
+		n, z.rerr = z.r.Read(z.buf)
+	}
+	if n == 0 {
+		return z.rerr
+	}
+	z.s.NextIn, z.s.AvailIn = unsafe.SliceData(z.buf), c.Uint(n)
+	return nil
+}
+
+func (z *reader) Read(p []byte) (int, error) {
+	if z.err != nil {
+		return 0, z.err
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if len(p) > maxChunk {
+		p = p[:maxChunk]
+	}
+	s := z.s
+	for {
+		var in int
+		if z.pk != nil {
+			// Inflate what the peeker has buffered, filling it if empty.
+			if _, err := z.pk.Peek(1); err != nil {
+				return 0, z.fail(err)
+			}
+			b, _ := z.pk.Peek(z.pk.Buffered())
+			if len(b) > maxChunk {
+				b = b[:maxChunk]
+			}
+			in = len(b)
+			s.NextIn, s.AvailIn = unsafe.SliceData(b), c.Uint(in)
+		} else if s.AvailIn == 0 {
+			if err := z.fill(); err != nil {
+				return 0, z.fail(err)
+			}
+			if s.AvailIn == 0 {
+				continue // a read of no bytes and no error
+			}
+		}
+		s.NextOut, s.AvailOut = unsafe.SliceData(p), c.Uint(len(p))
+		ret := s.Inflate(zlib.NO_FLUSH)
+		n := len(p) - int(s.AvailOut)
+		s.NextOut = nil
+		if z.pk != nil {
+			z.pk.Discard(in - int(s.AvailIn))
+			s.NextIn, s.AvailIn = nil, 0
+		}
+		switch ret {
+		case zlib.OK, zlib.BUF_ERROR:
+		case zlib.STREAM_END:
+			// inflate has checked the Adler-32 checksum of the trailer.
+			if z.seeker != nil && s.AvailIn > 0 {
+				z.seeker.Seek(-int64(s.AvailIn), io.SeekCurrent)
+			}
+			z.end()
+			z.err = io.EOF
+			return n, z.err
+		case zlib.NEED_DICT:
+			// The header has been checked against the Adler-32 of dict.
+			if s.InflateSetDictionary(unsafe.SliceData(z.dict), c.Uint(len(z.dict))) != zlib.OK {
+				z.err = ErrDictionary
+				return n, z.err
+			}
+		case zlib.DATA_ERROR:
+			if s.Msg != nil && c.GoString(s.Msg) == "incorrect data check" {
+				z.err = ErrChecksum
+			} else {
+				z.err = flate.CorruptInputError(s.TotalIn)
+			}
+			return n, z.err
+		default:
+			z.err = streamError("inflate", ret, s)
+			return n, z.err
+		}
+		if n > 0 {
+			return n, nil
+		}
+	}
+}
+
+// fail records err, the failure to read more of the compressed data, before
+// the end of the stream.
+func (z *reader) fail(err error) error {
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	z.err = err
+	return err
+}
+
+// end releases the inflate state, if any, which the garbage collector can't
+// see. It is also the finalizer of z, for a reader dropped before io.EOF
+// without a Close.
+func (z *reader) end() {
+	if z.s != nil {
+		z.s.InflateEnd()
+		z.s = nil
+	}
+}
+
+// Calling Close does not close the wrapped io.Reader originally passed to NewReader.
+// In order for the ZLIB checksum to be verified, the reader must be
+// fully consumed until the io.EOF.
+func (z *reader) Close() error {
+	z.end()
+	if z.err != nil && z.err != io.EOF && z.err != errReaderClosed {
+		return z.err
+	}
+	if z.err == nil {
+		z.err = errReaderClosed
+	}
+	return nil
+}
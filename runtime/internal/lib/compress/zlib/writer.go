@@ -0,0 +1,260 @@
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zlib
+
+import (
+	"compress/flate"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"unsafe"
+
+	c "github.com/goplus/llgo/runtime/internal/clite"
+	"github.com/goplus/llgo/runtime/internal/clite/zlib"
+)
+
+// llgo:skipall
+type _zlib struct{}
+
+// These constants are copied from the flate package, so that code that imports
+// compress/zlib does not also have to import compress/flate.
+const (
+	NoCompression      = flate.NoCompression
+	BestSpeed          = flate.BestSpeed
+	BestCompression    = flate.BestCompression
+	DefaultCompression = flate.DefaultCompression
+	HuffmanOnly        = flate.HuffmanOnly
+)
+
+// maxChunk bounds the byte counts handed to zlib, which are 32-bit.
+const maxChunk = 1 << 30
+
+// bufSize is the size of the output buffer of a Writer and of the input
+// buffer of a reader.
+const bufSize = 16 << 10
+
+var errWriterClosed = errors.New("zlib: closed writer")
+
+// A Writer takes data written to it and writes the compressed
+// form of that data to an underlying writer (see NewWriter).
+//
+// A Writer deflates with the zlib library. Its state, allocated by the first
+// Write, Flush or Close, is released by Close, or by the garbage collector
+// once the Writer is unreachable.
+type Writer struct {
+	w     io.Writer
+	level int
+	dict  []byte
+	s     *zlib.Stream // nil until the first Write, Flush or Close
+	buf   []byte
+	err   error
+}
+
+// NewWriter creates a new Writer.
+// Writes to the returned Writer are compressed and written to w.
+//
+// It is the caller's responsibility to call Close on the Writer when done.
+// Writes may be buffered and not flushed until Close.
+func NewWriter(w io.Writer) *Writer {
+	z, _ := NewWriterLevelDict(w, DefaultCompression, nil)
+	return z
+}
+
+// NewWriterLevel is like NewWriter but specifies the compression level instead
+// of assuming DefaultCompression.
+//
+// The compression level can be DefaultCompression, NoCompression, HuffmanOnly
+// or any integer value between BestSpeed and BestCompression inclusive.
+// The error returned will be nil if the level is valid.
+func NewWriterLevel(w io.Writer, level int) (*Writer, error) {
+	return NewWriterLevelDict(w, level, nil)
+}
+
+// NewWriterLevelDict is like NewWriterLevel but specifies a dictionary to
+// compress with.
+//
+// The dictionary may be nil. If not, its contents should not be modified until
+// the Writer is closed.
+func NewWriterLevelDict(w io.Writer, level int, dict []byte) (*Writer, error) {
+	if level < HuffmanOnly || level > BestCompression {
+		return nil, fmt.Errorf("zlib: invalid compression level: %d", level)
+	}
+	z := &Writer{
+		w:     w,
+		level: level,
+		dict:  dict,
+	}
+	runtime.SetFinalizer(z, (*Writer).end)
+	return z, nil
+}
+
+// Reset clears the state of the Writer z such that it is equivalent to its
+// initial state from NewWriterLevel or NewWriterLevelDict, but instead writing
+// to w.
+func (z *Writer) Reset(w io.Writer) {
+	z.w = w
+	// z.level and z.dict left unchanged.
+	z.err = nil
+	if z.s != nil && z.s.DeflateReset() == zlib.OK && z.setDict() == nil {
+		return
+	}
+	z.end()
+}
+
+// init allocates the deflate state on first use.
+func (z *Writer) init() error {
+	if z.s != nil {
+		return nil
+	}
+	level, strategy := c.Int(z.level), c.Int(zlib.DEFAULT_STRATEGY)
+	if z.level == HuffmanOnly {
+		level, strategy = zlib.DEFAULT_COMPRESSION, zlib.HUFFMAN_ONLY
+	}
+	s := new(zlib.Stream)
+	if ret := s.DeflateInit2(level, zlib.DEFLATED, 15, 8, strategy); ret != zlib.OK {
+		return streamError("deflateInit2", ret, s)
+	}
+	z.s = s
+	if z.buf == nil {
+		z.buf = make([]byte, bufSize)
+	}
+	return z.setDict()
+}
+
+// setDict presets the dictionary of z, if any, on a new or reset stream.
+func (z *Writer) setDict() error {
+	if len(z.dict) == 0 {
+		return nil
+	}
+	if ret := z.s.DeflateSetDictionary(unsafe.SliceData(z.dict), c.Uint(len(z.dict))); ret != zlib.OK {
+		return streamError("deflateSetDictionary", ret, z.s)
+	}
+	return nil
+}
+
+// end releases the deflate state, if any, which the garbage collector can't
+// see. It is also the finalizer of z, for a Writer dropped without a Close.
+func (z *Writer) end() {
+	if z.s != nil {
+		z.s.DeflateEnd()
+		z.s = nil
+	}
+}
+
+// deflate runs deflate with flush on the pending input until it is all
+// consumed and, for a flush other than NO_FLUSH, all its output produced,
+// writing the output to z.w.
+func (z *Writer) deflate(flush c.Int) error {
+	s := z.s
+	for {
+		s.NextOut, s.AvailOut = unsafe.SliceData(z.buf), c.Uint(len(z.buf))
+		ret := s.Deflate(flush)
+		n := len(z.buf) - int(s.AvailOut)
+		s.NextOut = nil
+		if n > 0 {
+			if _, err := z.w.Write(z.buf[:n]); err != nil {
+				return err
+			}
+		}
+		switch ret {
+		case zlib.STREAM_END:
+			return nil
+		case zlib.OK:
+		case zlib.BUF_ERROR:
+			// No progress was possible: there is nothing left to flush, as
+			// when Flush is called twice in a row.
+			if flush != zlib.FINISH {
+				return nil
+			}
+			fallthrough
+		default:
+			return streamError("deflate", ret, s)
+		}
+		// A full output buffer may hide more output; SYNC_FLUSH and
+		// FINISH aren't done until deflate leaves room in it.
+		if s.AvailIn == 0 && s.AvailOut != 0 && flush != zlib.FINISH {
+			return nil
+		}
+	}
+}
+
+// Write writes a compressed form of p to the underlying io.Writer. The
+// compressed bytes are not necessarily flushed until the Writer is closed or
+// explicitly flushed.
+func (z *Writer) Write(p []byte) (n int, err error) {
+	if z.err != nil {
+		return 0, z.err
+	}
+	if z.err = z.init(); z.err != nil {
+		return 0, z.err
+	}
+	for len(p) > 0 {
+		chunk := p
+		if len(chunk) > maxChunk {
+			chunk = chunk[:maxChunk]
+		}
+		z.s.NextIn, z.s.AvailIn = unsafe.SliceData(chunk), c.Uint(len(chunk))
+		z.err = z.deflate(zlib.NO_FLUSH)
+		z.s.NextIn, z.s.AvailIn = nil, 0
+		if z.err != nil {
+			return n, z.err
+		}
+		n += len(chunk)
+		p = p[len(chunk):]
+	}
+	return n, nil
+}
+
+// Flush flushes the Writer to its underlying io.Writer: all the data written
+// so far can be decompressed from the output, which ends with an empty
+// stored block, as zlib's SYNC_FLUSH does.
+func (z *Writer) Flush() error {
+	if z.err != nil {
+		return z.err
+	}
+	if z.err = z.init(); z.err != nil {
+		return z.err
+	}
+	z.err = z.deflate(zlib.SYNC_FLUSH)
+	return z.err
+}
+
+// Close closes the Writer, flushing any unwritten data to the underlying
+// io.Writer, but does not close the underlying io.Writer.
+func (z *Writer) Close() error {
+	if z.err == nil {
+		if z.err = z.init(); z.err == nil {
+			z.err = z.deflate(zlib.FINISH)
+		}
+	}
+	z.end()
+	if z.err != nil {
+		return z.err
+	}
+	z.err = errWriterClosed
+	return nil
+}
+
+// streamError describes a failure of the zlib function fn, which returned
+// ret, with the message zlib left in s, if any.
+func streamError(fn string, ret c.Int, s *zlib.Stream) error {
+	if s.Msg != nil {
+		return fmt.Errorf("zlib: %s: %s", fn, c.GoString(s.Msg))
+	}
+	return fmt.Errorf("zlib: %s returned %d", fn, ret)
+}
@@ -0,0 +1,206 @@
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package aes
+
+import (
+	"crypto/cipher"
+	"runtime"
+	"strconv"
+	"sync"
+	"unsafe"
+
+	c "github.com/goplus/llgo/runtime/internal/clite"
+	"github.com/goplus/llgo/runtime/internal/clite/openssl"
+)
+
+// llgo:skipall
+type _aes struct{}
+
+// The AES block size in bytes.
+const BlockSize = 16
+
+type KeySizeError int
+
+func (k KeySizeError) Error() string {
+	return "crypto/aes: invalid key size " + strconv.Itoa(int(k))
+}
+
+// aesCipher is an AES block backed by OpenSSL. Besides the cipher.Block
+// methods it implements the optional interfaces crypto/cipher looks for, so
+// that NewCBCEncrypter, NewCBCDecrypter, NewCTR and NewGCM run the whole
+// mode in OpenSSL rather than one block at a time.
+type aesCipher struct {
+	key []byte
+
+	// ECB contexts for the single block Encrypt and Decrypt, which may be
+	// called concurrently, as with the standard implementation.
+	mu  sync.Mutex
+	enc *openssl.EVP_CIPHER_CTX
+	dec *openssl.EVP_CIPHER_CTX
+}
+
+// NewCipher creates and returns a new cipher.Block.
+// The key argument should be the AES key,
+// either 16, 24, or 32 bytes to select
+// AES-128, AES-192, or AES-256.
+//
+// A failure of OpenSSL to set up the key is returned as an error that names
+// the failed OpenSSL function and describes the OpenSSL error queue.
+func NewCipher(key []byte) (cipher.Block, error) {
+	switch k := len(key); k {
+	default:
+		return nil, KeySizeError(k)
+	case 16, 24, 32:
+	}
+	b := &aesCipher{key: append([]byte(nil), key...)}
+	var err error
+	if b.enc, err = b.newCtx(ecbCipher, nil, 1); err != nil {
+		return nil, err
+	}
+	if b.dec, err = b.newCtx(ecbCipher, nil, 0); err != nil {
+		b.enc.Free()
+		return nil, err
+	}
+	runtime.SetFinalizer(b, (*aesCipher).free)
+	return b, nil
+}
+
+// free releases the contexts of b, which the garbage collector can't see. It
+// is the finalizer of b.
+func (b *aesCipher) free() {
+	b.enc.Free()
+	b.dec.Free()
+}
+
+func (b *aesCipher) BlockSize() int { return BlockSize }
+
+func (b *aesCipher) Encrypt(dst, src []byte) {
+	if len(src) < BlockSize {
+		panic("crypto/aes: input not full block")
+	}
+	if len(dst) < BlockSize {
+		panic("crypto/aes: output not full block")
+	}
+	if inexactOverlap(dst[:BlockSize], src[:BlockSize]) {
+		panic("crypto/aes: invalid buffer overlap")
+	}
+	b.mu.Lock()
+	b.enc.CipherUpdateBytes(dst, src[:BlockSize])
+	b.mu.Unlock()
+}
+
+func (b *aesCipher) Decrypt(dst, src []byte) {
+	if len(src) < BlockSize {
+		panic("crypto/aes: input not full block")
+	}
+	if len(dst) < BlockSize {
+		panic("crypto/aes: output not full block")
+	}
+	if inexactOverlap(dst[:BlockSize], src[:BlockSize]) {
+		panic("crypto/aes: invalid buffer overlap")
+	}
+	b.mu.Lock()
+	b.dec.CipherUpdateBytes(dst, src[:BlockSize])
+	b.mu.Unlock()
+}
+
+// newCtx returns an unpadded cipher context for the mode picked by mode,
+// set up with b's key and iv to encrypt (enc is 1) or decrypt (enc is 0).
+func (b *aesCipher) newCtx(mode func(keyLen int) *openssl.EVP_CIPHER, iv []byte, enc c.Int) (*openssl.EVP_CIPHER_CTX, error) {
+	ctx := openssl.NewEVP_CIPHER_CTX()
+	if ctx == nil {
+		return nil, openssl.NewError("crypto/aes", "EVP_CIPHER_CTX_new")
+	}
+	var ivp *byte
+	if iv != nil {
+		ivp = unsafe.SliceData(iv)
+	}
+	if ctx.CipherInit(mode(len(b.key)), nil, unsafe.SliceData(b.key), ivp, enc) != 1 {
+		ctx.Free()
+		return nil, openssl.NewError("crypto/aes", "EVP_CipherInit_ex")
+	}
+	ctx.SetPadding(0)
+	return ctx, nil
+}
+
+// mustCtx is like newCtx, for the mode constructors of crypto/cipher, which
+// have no error result: they panic with the error of newCtx instead of
+// returning a mode that would produce wrong output.
+func (b *aesCipher) mustCtx(mode func(keyLen int) *openssl.EVP_CIPHER, iv []byte, enc c.Int) *openssl.EVP_CIPHER_CTX {
+	ctx, err := b.newCtx(mode, iv, enc)
+	if err != nil {
+		panic(err)
+	}
+	return ctx
+}
+
+func ecbCipher(keyLen int) *openssl.EVP_CIPHER {
+	switch keyLen {
+	case 16:
+		return openssl.EVP_aes_128_ecb()
+	case 24:
+		return openssl.EVP_aes_192_ecb()
+	}
+	return openssl.EVP_aes_256_ecb()
+}
+
+func cbcCipher(keyLen int) *openssl.EVP_CIPHER {
+	switch keyLen {
+	case 16:
+		return openssl.EVP_aes_128_cbc()
+	case 24:
+		return openssl.EVP_aes_192_cbc()
+	}
+	return openssl.EVP_aes_256_cbc()
+}
+
+func gcmCipher(keyLen int) *openssl.EVP_CIPHER {
+	switch keyLen {
+	case 16:
+		return openssl.EVP_aes_128_gcm()
+	case 24:
+		return openssl.EVP_aes_192_gcm()
+	}
+	return openssl.EVP_aes_256_gcm()
+}
+
+func ctrCipher(keyLen int) *openssl.EVP_CIPHER {
+	switch keyLen {
+	case 16:
+		return openssl.EVP_aes_128_ctr()
+	case 24:
+		return openssl.EVP_aes_192_ctr()
+	}
+	return openssl.EVP_aes_256_ctr()
+}
+
+// anyOverlap reports whether x and y share memory at any index, as
+// crypto/internal/alias.AnyOverlap does.
+func anyOverlap(x, y []byte) bool {
+	return len(x) > 0 && len(y) > 0 &&
+		uintptr(unsafe.Pointer(&x[0])) <= uintptr(unsafe.Pointer(&y[len(y)-1])) &&
+		uintptr(unsafe.Pointer(&y[0])) <= uintptr(unsafe.Pointer(&x[len(x)-1]))
+}
+
+// inexactOverlap reports whether x and y share memory at any non-corresponding
+// index, as crypto/internal/alias.InexactOverlap does.
+func inexactOverlap(x, y []byte) bool {
+	if len(x) == 0 || len(y) == 0 || &x[0] == &y[0] {
+		return false
+	}
+	return anyOverlap(x, y)
+}
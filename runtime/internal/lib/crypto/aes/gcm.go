@@ -0,0 +1,198 @@
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package aes
+
+import (
+	"crypto/cipher"
+	"errors"
+	"runtime"
+	"sync"
+	"unsafe"
+
+	c "github.com/goplus/llgo/runtime/internal/clite"
+	"github.com/goplus/llgo/runtime/internal/clite/openssl"
+)
+
+// errAuth is returned by Open if the ciphertext or the additional data fail
+// authentication. OpenSSL reports the tag mismatch only by the result of
+// EVP_CipherFinal_ex, without queuing an error, so the message is set here.
+var errAuth error = &openssl.Error{Pkg: "cipher", Op: "EVP_CipherFinal_ex", Msg: "message authentication failed"}
+
+// errOpen is returned by Open, as by the standard GCM, for a ciphertext too
+// short or too long to be checked at all.
+var errOpen = errors.New("cipher: message authentication failed")
+
+// gcm is an AES-GCM AEAD running in OpenSSL, with one context for Seal and
+// one for Open. The contexts keep the key schedule, and are set up with the
+// nonce of each call. An AEAD may be used concurrently, so mu guards them.
+type gcm struct {
+	nonceSize int
+	tagSize   int
+
+	mu   sync.Mutex
+	seal *openssl.EVP_CIPHER_CTX
+	open *openssl.EVP_CIPHER_CTX
+}
+
+// NewGCM is called by cipher.NewGCM and its variants once they have checked
+// nonceSize and tagSize. A failure of OpenSSL to set up the contexts is
+// returned as an error, as by NewCipher.
+func (b *aesCipher) NewGCM(nonceSize, tagSize int) (cipher.AEAD, error) {
+	g := &gcm{nonceSize: nonceSize, tagSize: tagSize}
+	var err error
+	if g.seal, err = b.newGCMCtx(nonceSize, 1); err != nil {
+		return nil, err
+	}
+	if g.open, err = b.newGCMCtx(nonceSize, 0); err != nil {
+		g.seal.Free()
+		return nil, err
+	}
+	runtime.SetFinalizer(g, (*gcm).free)
+	return g, nil
+}
+
+// free releases the contexts of g. It is the finalizer of g.
+func (g *gcm) free() {
+	g.seal.Free()
+	g.open.Free()
+}
+
+func (b *aesCipher) newGCMCtx(nonceSize int, enc c.Int) (*openssl.EVP_CIPHER_CTX, error) {
+	ctx, err := b.newCtx(gcmCipher, nil, enc)
+	if err != nil {
+		return nil, err
+	}
+	if ctx.Ctrl(openssl.EVP_CTRL_AEAD_SET_IVLEN, c.Int(nonceSize), nil) != 1 {
+		ctx.Free()
+		return nil, openssl.NewError("crypto/aes", "EVP_CIPHER_CTX_ctrl")
+	}
+	return ctx, nil
+}
+
+func (g *gcm) NonceSize() int { return g.nonceSize }
+
+func (g *gcm) Overhead() int { return g.tagSize }
+
+func (g *gcm) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != g.nonceSize {
+		panic("crypto/cipher: incorrect nonce length given to GCM")
+	}
+	if uint64(len(plaintext)) > uint64((1<<32)-2)*BlockSize {
+		panic("crypto/cipher: message too large for GCM")
+	}
+	ret, out := sliceForAppend(dst, len(plaintext)+g.tagSize)
+	if inexactOverlap(out, plaintext) {
+		panic("crypto/cipher: invalid buffer overlap of output and input")
+	}
+	if anyOverlap(out, additionalData) {
+		panic("crypto/cipher: invalid buffer overlap of output and additional data")
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	ctx := g.seal
+	if !g.start(ctx, nonce, additionalData) {
+		panic(openssl.NewError("crypto/aes", "EVP_CipherUpdate"))
+	}
+	var n c.Int
+	if len(plaintext) > 0 && ctx.CipherUpdateBytes(out, plaintext) != 1 ||
+		ctx.CipherFinal(unsafe.SliceData(out), &n) != 1 {
+		panic(openssl.NewError("crypto/aes", "EVP_CipherFinal_ex"))
+	}
+	if ctx.Ctrl(openssl.EVP_CTRL_AEAD_GET_TAG, c.Int(g.tagSize), unsafe.Pointer(&out[len(plaintext)])) != 1 {
+		panic(openssl.NewError("crypto/aes", "EVP_CIPHER_CTX_ctrl"))
+	}
+	return ret
+}
+
+// Open returns an error if the ciphertext or the additional data fail
+// authentication, and clears the output then, as the standard GCM does.
+func (g *gcm) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != g.nonceSize {
+		panic("crypto/cipher: incorrect nonce length given to GCM")
+	}
+	if len(ciphertext) < g.tagSize {
+		return nil, errOpen
+	}
+	if uint64(len(ciphertext)) > uint64((1<<32)-2)*BlockSize+uint64(g.tagSize) {
+		return nil, errOpen
+	}
+	ret, out := sliceForAppend(dst, len(ciphertext)-g.tagSize)
+	if inexactOverlap(out, ciphertext) {
+		panic("crypto/cipher: invalid buffer overlap of output and input")
+	}
+	if anyOverlap(out, additionalData) {
+		panic("crypto/cipher: invalid buffer overlap of output and additional data")
+	}
+	tag := ciphertext[len(ciphertext)-g.tagSize:]
+	ciphertext = ciphertext[:len(ciphertext)-g.tagSize]
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	ctx := g.open
+	if !g.start(ctx, nonce, additionalData) {
+		return nil, openssl.NewError("crypto/aes", "EVP_CipherUpdate")
+	}
+	if len(ciphertext) > 0 && ctx.CipherUpdateBytes(out, ciphertext) != 1 {
+		clearBytes(out)
+		return nil, openssl.NewError("crypto/aes", "EVP_CipherUpdate")
+	}
+	if ctx.Ctrl(openssl.EVP_CTRL_AEAD_SET_TAG, c.Int(g.tagSize), unsafe.Pointer(&tag[0])) != 1 {
+		clearBytes(out)
+		return nil, openssl.NewError("crypto/aes", "EVP_CIPHER_CTX_ctrl")
+	}
+	var n c.Int
+	if ctx.CipherFinal(unsafe.SliceData(out), &n) != 1 {
+		// The plaintext written by CipherUpdate is unauthenticated.
+		clearBytes(out)
+		openssl.ERRClearError()
+		return nil, errAuth
+	}
+	return ret, nil
+}
+
+// start sets ctx up for a message with nonce, and feeds it additionalData.
+func (g *gcm) start(ctx *openssl.EVP_CIPHER_CTX, nonce, additionalData []byte) bool {
+	if ctx.CipherInit(nil, nil, nil, &nonce[0], -1) != 1 {
+		return false
+	}
+	if len(additionalData) == 0 {
+		return true
+	}
+	var n c.Int
+	return ctx.CipherUpdate(nil, &n, &additionalData[0], c.Int(len(additionalData))) == 1
+}
+
+func clearBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// sliceForAppend takes a slice and a requested number of bytes. It returns a
+// slice with the contents of the given slice followed by that many bytes and
+// a second slice that aliases into it and contains only the extra bytes.
+func sliceForAppend(in []byte, n int) (head, tail []byte) {
+	if total := len(in) + n; cap(in) >= total {
+		head = in[:total]
+	} else {
+		head = make([]byte, total)
+		copy(head, in)
+	}
+	tail = head[len(in):]
+	return
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package aes
+
+import (
+	"crypto/cipher"
+	"runtime"
+
+	"github.com/goplus/llgo/runtime/internal/clite/openssl"
+)
+
+// cbc is a CBC encrypter or decrypter running in a single OpenSSL context,
+// which carries the chaining value from one CryptBlocks call to the next.
+type cbc struct {
+	ctx *openssl.EVP_CIPHER_CTX
+}
+
+// NewCBCEncrypter is called by cipher.NewCBCEncrypter once it has checked
+// the length of iv.
+func (b *aesCipher) NewCBCEncrypter(iv []byte) cipher.BlockMode {
+	return newCBC(b.mustCtx(cbcCipher, iv, 1))
+}
+
+// NewCBCDecrypter is called by cipher.NewCBCDecrypter once it has checked
+// the length of iv.
+func (b *aesCipher) NewCBCDecrypter(iv []byte) cipher.BlockMode {
+	return newCBC(b.mustCtx(cbcCipher, iv, 0))
+}
+
+func newCBC(ctx *openssl.EVP_CIPHER_CTX) *cbc {
+	x := &cbc{ctx}
+	runtime.SetFinalizer(x, (*cbc).free)
+	return x
+}
+
+// free releases the context of x. It is the finalizer of x.
+func (x *cbc) free() { x.ctx.Free() }
+
+func (x *cbc) BlockSize() int { return BlockSize }
+
+func (x *cbc) CryptBlocks(dst, src []byte) {
+	if len(src)%BlockSize != 0 {
+		panic("crypto/cipher: input not full blocks")
+	}
+	if len(dst) < len(src) {
+		panic("crypto/cipher: output smaller than input")
+	}
+	if inexactOverlap(dst[:len(src)], src) {
+		panic("crypto/cipher: invalid buffer overlap")
+	}
+	if len(src) == 0 {
+		return
+	}
+	// Padding is off, so a decrypting context doesn't hold back the last
+	// block and the output is always exactly len(src) bytes.
+	x.ctx.CipherUpdateBytes(dst, src)
+}
+
+// SetIV implements the interface the standard CBC modes provide for
+// reusing one with a new IV.
+func (x *cbc) SetIV(iv []byte) {
+	if len(iv) != BlockSize {
+		panic("cipher: incorrect length IV")
+	}
+	x.ctx.CipherInit(nil, nil, nil, &iv[0], -1)
+}
+
+// ctr is a CTR stream running in a single OpenSSL context. OpenSSL
+// increments the whole IV as a 128-bit big-endian counter, as crypto/cipher
+// does, and keeps the unused part of the last key stream block, so the
+// stream may be consumed in pieces of any length.
+type ctr struct {
+	ctx *openssl.EVP_CIPHER_CTX
+}
+
+// NewCTR is called by cipher.NewCTR before it checks the length of iv, so
+// the check is done here.
+func (b *aesCipher) NewCTR(iv []byte) cipher.Stream {
+	if len(iv) != BlockSize {
+		panic("cipher.NewCTR: IV length must equal block size")
+	}
+	x := &ctr{b.mustCtx(ctrCipher, iv, 1)}
+	runtime.SetFinalizer(x, (*ctr).free)
+	return x
+}
+
+// free releases the context of x. It is the finalizer of x.
+func (x *ctr) free() { x.ctx.Free() }
+
+func (x *ctr) XORKeyStream(dst, src []byte) {
+	if len(dst) < len(src) {
+		panic("crypto/cipher: output smaller than input")
+	}
+	if inexactOverlap(dst[:len(src)], src) {
+		panic("crypto/cipher: invalid buffer overlap")
+	}
+	if len(src) == 0 {
+		return
+	}
+	x.ctx.CipherUpdateBytes(dst, src)
+}
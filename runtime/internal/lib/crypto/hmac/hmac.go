@@ -1,9 +1,12 @@
 package hmac
 
 import (
+	"crypto/sha1"
 	"crypto/sha256"
+	"crypto/sha512"
 	"crypto/subtle"
 	"hash"
+	"runtime"
 	"unsafe"
 
 	c "github.com/goplus/llgo/runtime/internal/clite"
@@ -23,26 +26,45 @@ func funcOf(a any) unsafe.Pointer {
 	return *e.funcPtr
 }
 
-type digest openssl.HMAC_CTX
+type digest struct {
+	ctx *openssl.HMAC_CTX
+	sum *openssl.HMAC_CTX // scratch copy of ctx finalized by Sum
+	md  *openssl.EVP_MD
+}
 
-func (d *digest) Size() int { panic("todo: hmac.(*digest).Size") }
+// free releases the HMAC contexts of d, which the garbage collector can't
+// see. It is the finalizer of d.
+func (d *digest) free() {
+	d.ctx.Free()
+	if d.sum != nil {
+		d.sum.Free()
+	}
+}
 
-func (d *digest) BlockSize() int { panic("todo: hmac.(*digest).BlockSize") }
+func (d *digest) Size() int { return int(d.md.Size()) }
+
+func (d *digest) BlockSize() int { return int(d.md.BlockSize()) }
 
 func (d *digest) Reset() {
-	(*openssl.HMAC_CTX)(d).Reset()
+	// A nil key and digest restart the MAC with the ones already set.
+	d.ctx.InitEx(nil, 0, nil, nil)
 }
 
 func (d *digest) Write(p []byte) (nn int, err error) {
-	(*openssl.HMAC_CTX)(d).UpdateBytes(p)
+	d.ctx.UpdateBytes(p)
 	return len(p), nil
 }
 
 func (d *digest) Sum(in []byte) []byte {
+	// Finalize a copy so that the caller can keep writing and summing.
+	if d.sum == nil {
+		d.sum = openssl.NewHMAC_CTX()
+	}
+	d.sum.Copy(d.ctx)
 	const Size = openssl.EVP_MAX_MD_SIZE
 	var digestLen c.Uint
 	hash := (*[Size]byte)(c.Alloca(Size))
-	(*openssl.HMAC_CTX)(d).Final(&hash[0], &digestLen)
+	d.sum.Final(&hash[0], &digestLen)
 	return append(in, hash[:digestLen]...)
 }
 
@@ -55,14 +77,24 @@ func (d *digest) Sum(in []byte) []byte {
 func New(h func() hash.Hash, key []byte) hash.Hash {
 	var md *openssl.EVP_MD
 	switch funcOf(h) {
+	case c.Func(sha1.New):
+		md = openssl.EVP_sha1()
+	case c.Func(sha256.New224):
+		md = openssl.EVP_sha224()
 	case c.Func(sha256.New):
 		md = openssl.EVP_sha256()
+	case c.Func(sha512.New384):
+		md = openssl.EVP_sha384()
+	case c.Func(sha512.New):
+		md = openssl.EVP_sha512()
 	default:
 		panic("todo: hmac.New: unsupported hash function")
 	}
 	ctx := openssl.NewHMAC_CTX()
 	ctx.InitBytes(key, md)
-	return (*digest)(ctx)
+	d := &digest{ctx: ctx, md: md}
+	runtime.SetFinalizer(d, (*digest).free)
+	return d
 }
 
 // Equal compares two MACs for equality without leaking timing information.
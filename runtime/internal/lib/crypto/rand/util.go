@@ -4,7 +4,6 @@
 
 package rand
 
-/* TODO(xsw):
 import (
 	"errors"
 	"io"
@@ -95,4 +94,3 @@ func Int(rand io.Reader, max *big.Int) (n *big.Int, err error) {
 		}
 	}
 }
-*/
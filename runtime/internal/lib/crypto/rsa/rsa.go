@@ -0,0 +1,400 @@
+// Copyright 2009 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package rsa implements the PKCS #1 v1.5 part of crypto/rsa on top of
+// math/big, which in llgo is backed by OpenSSL BIGNUMs. Keys have the same
+// fields as with the standard library. OAEP, PSS and multi-prime key
+// generation are not implemented: their functions panic.
+package rsa
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+)
+
+// llgo:skipall
+type _rsa struct{}
+
+var bigOne = big.NewInt(1)
+
+var (
+	errPublicModulus       = errors.New("crypto/rsa: missing public modulus")
+	errPublicExponentSmall = errors.New("crypto/rsa: public exponent too small")
+	errPublicExponentLarge = errors.New("crypto/rsa: public exponent too large")
+)
+
+// ErrMessageTooLong is returned when attempting to encrypt or sign a message
+// which is too large for the size of the key.
+var ErrMessageTooLong = errors.New("crypto/rsa: message too long for RSA key size")
+
+// ErrDecryption represents a failure to decrypt a message.
+// It is deliberately vague to avoid adaptive attacks.
+var ErrDecryption = errors.New("crypto/rsa: decryption error")
+
+// ErrVerification represents a failure to verify a signature.
+// It is deliberately vague to avoid adaptive attacks.
+var ErrVerification = errors.New("crypto/rsa: verification error")
+
+// A PublicKey represents the public part of an RSA key.
+type PublicKey struct {
+	N *big.Int // modulus
+	E int      // public exponent
+}
+
+// Size returns the modulus size in bytes. Raw signatures and ciphertexts
+// for or by this public key will have the same size.
+func (pub *PublicKey) Size() int {
+	return (pub.N.BitLen() + 7) / 8
+}
+
+// Equal reports whether pub and x have the same value.
+func (pub *PublicKey) Equal(x crypto.PublicKey) bool {
+	xx, ok := x.(*PublicKey)
+	if !ok {
+		return false
+	}
+	return bigIntEqual(pub.N, xx.N) && pub.E == xx.E
+}
+
+// checkPub sanity checks the public key before we use it.
+func checkPub(pub *PublicKey) error {
+	if pub.N == nil {
+		return errPublicModulus
+	}
+	if pub.E < 2 {
+		return errPublicExponentSmall
+	}
+	if pub.E > 1<<31-1 {
+		return errPublicExponentLarge
+	}
+	return nil
+}
+
+// A PrivateKey represents an RSA key.
+type PrivateKey struct {
+	PublicKey            // public part.
+	D         *big.Int   // private exponent
+	Primes    []*big.Int // prime factors of N, has >= 2 elements.
+
+	// Precomputed contains precomputed values that speed up RSA operations,
+	// if available. It must be generated by calling PrivateKey.Precompute and
+	// must not be modified.
+	Precomputed PrecomputedValues
+}
+
+type PrecomputedValues struct {
+	Dp, Dq *big.Int // D mod (P-1) (or mod Q-1)
+	Qinv   *big.Int // Q^-1 mod P
+
+	// CRTValues is used for the 3rd and subsequent primes. Due to a
+	// historical accident, the CRT for the first two primes is handled
+	// differently in PKCS #1 and interoperability is sufficiently
+	// important that we mirror this.
+	//
+	// Deprecated: These values are still filled in by Precompute for
+	// backwards compatibility but are not used. Multi-prime RSA is very rare,
+	// and is implemented by this package without CRT optimizations to limit
+	// complexity.
+	CRTValues []CRTValue
+}
+
+// CRTValue contains the precomputed Chinese remainder theorem values.
+type CRTValue struct {
+	Exp   *big.Int // D mod (prime-1).
+	Coeff *big.Int // R·Coeff ≡ 1 mod Prime.
+	R     *big.Int // product of primes prior to this (inc p and q).
+}
+
+// Public returns the public key corresponding to priv.
+func (priv *PrivateKey) Public() crypto.PublicKey {
+	return &priv.PublicKey
+}
+
+// Equal reports whether priv and x have equivalent values. It ignores
+// Precomputed values.
+func (priv *PrivateKey) Equal(x crypto.PrivateKey) bool {
+	xx, ok := x.(*PrivateKey)
+	if !ok {
+		return false
+	}
+	if !priv.PublicKey.Equal(&xx.PublicKey) || !bigIntEqual(priv.D, xx.D) {
+		return false
+	}
+	if len(priv.Primes) != len(xx.Primes) {
+		return false
+	}
+	for i := range priv.Primes {
+		if !bigIntEqual(priv.Primes[i], xx.Primes[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// bigIntEqual reports whether a and b are equal leaking only their bit length
+// through timing side-channels.
+func bigIntEqual(a, b *big.Int) bool {
+	return subtle.ConstantTimeCompare(a.Bytes(), b.Bytes()) == 1
+}
+
+// Sign signs digest with priv, reading randomness from rand, which is
+// ignored. Only PKCS #1 v1.5 signatures are supported: opts.HashFunc() is
+// the hash that produced digest, as for SignPKCS1v15. Sign panics, as
+// SignPSS does, if opts is a *PSSOptions.
+//
+// This method implements crypto.Signer, which is an interface to support keys
+// where the private part is kept in, for example, a hardware module. Common
+// uses can use the SignPKCS1v15 function in this package directly.
+func (priv *PrivateKey) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if pssOpts, ok := opts.(*PSSOptions); ok {
+		return SignPSS(rand, priv, pssOpts.Hash, digest, pssOpts)
+	}
+	return SignPKCS1v15(rand, priv, opts.HashFunc(), digest)
+}
+
+// Decrypt decrypts ciphertext with priv. If opts is nil or a
+// *PKCS1v15DecryptOptions, PKCS #1 v1.5 decryption is performed, and a
+// non-zero SessionKeyLen asks for DecryptPKCS1v15SessionKey. OAEP is not
+// implemented: Decrypt panics if opts is an *OAEPOptions.
+func (priv *PrivateKey) Decrypt(rand io.Reader, ciphertext []byte, opts crypto.DecrypterOpts) (plaintext []byte, err error) {
+	if opts == nil {
+		return DecryptPKCS1v15(rand, priv, ciphertext)
+	}
+
+	switch opts := opts.(type) {
+	case *OAEPOptions:
+		return DecryptOAEP(opts.Hash.New(), rand, priv, ciphertext, opts.Label)
+
+	case *PKCS1v15DecryptOptions:
+		if l := opts.SessionKeyLen; l > 0 {
+			plaintext = make([]byte, l)
+			if _, err := io.ReadFull(rand, plaintext); err != nil {
+				return nil, err
+			}
+			if err := DecryptPKCS1v15SessionKey(rand, priv, ciphertext, plaintext); err != nil {
+				return nil, err
+			}
+			return plaintext, nil
+		} else {
+			return DecryptPKCS1v15(rand, priv, ciphertext)
+		}
+
+	default:
+		return nil, errors.New("crypto/rsa: invalid options for Decrypt")
+	}
+}
+
+// Validate performs basic sanity checks on the key.
+// It returns nil if the key is valid, or else an error describing a problem.
+func (priv *PrivateKey) Validate() error {
+	if err := checkPub(&priv.PublicKey); err != nil {
+		return err
+	}
+
+	// Check that Πprimes == n.
+	modulus := new(big.Int).Set(bigOne)
+	for _, prime := range priv.Primes {
+		// Any primes ≤ 1 will cause divide-by-zero panics later.
+		if prime.Cmp(bigOne) <= 0 {
+			return errors.New("crypto/rsa: invalid prime value")
+		}
+		modulus.Mul(modulus, prime)
+	}
+	if modulus.Cmp(priv.N) != 0 {
+		return errors.New("crypto/rsa: invalid modulus")
+	}
+
+	// Check that de ≡ 1 mod p-1, for each prime.
+	// This implies that e is coprime to each p-1 as e has a multiplicative
+	// inverse. Therefore e is coprime to lcm(p-1,q-1,r-1,...) =
+	// exponent(ℤ/nℤ). It also implies that a^de ≡ a mod p as a^(p-1) ≡ 1
+	// mod p. Thus a^de ≡ a mod n for all a coprime to n, as required.
+	congruence := new(big.Int)
+	de := new(big.Int).SetInt64(int64(priv.E))
+	de.Mul(de, priv.D)
+	for _, prime := range priv.Primes {
+		pminus1 := new(big.Int).Sub(prime, bigOne)
+		congruence.Mod(de, pminus1)
+		if congruence.Cmp(bigOne) != 0 {
+			return errors.New("crypto/rsa: invalid exponents")
+		}
+	}
+	return nil
+}
+
+// GenerateKey generates a random RSA private key of the given bit size,
+// with public exponent 65537, drawing the two primes from random by
+// crypto/rand.Prime. It returns an error for a size of less than 64 bits,
+// far too small to be secure.
+func GenerateKey(random io.Reader, bits int) (*PrivateKey, error) {
+	if bits < 64 {
+		return nil, errors.New("crypto/rsa: GenerateKey: bits too small")
+	}
+	priv := &PrivateKey{PublicKey: PublicKey{E: 65537}}
+	e := big.NewInt(int64(priv.E))
+	for {
+		p, err := rand.Prime(random, bits-bits/2)
+		if err != nil {
+			return nil, err
+		}
+		q, err := rand.Prime(random, bits/2)
+		if err != nil {
+			return nil, err
+		}
+		if p.Cmp(q) == 0 {
+			continue
+		}
+		n := new(big.Int).Mul(p, q)
+		if n.BitLen() != bits {
+			continue
+		}
+		pminus1 := new(big.Int).Sub(p, bigOne)
+		qminus1 := new(big.Int).Sub(q, bigOne)
+		totient := new(big.Int).Mul(pminus1, qminus1)
+		d := new(big.Int)
+		if d.ModInverse(e, totient) == nil {
+			continue // e divides p-1 or q-1
+		}
+		priv.N, priv.D, priv.Primes = n, d, []*big.Int{p, q}
+		break
+	}
+	priv.Precompute()
+	return priv, nil
+}
+
+// GenerateMultiPrimeKey generates a multi-prime RSA keypair of the given bit
+// size and the given random source.
+//
+// Multi-prime key generation is not implemented: GenerateMultiPrimeKey
+// panics.
+//
+// Deprecated: The use of this function with a number of primes different from
+// two is not recommended for compatibility, security, and performance reasons.
+// Use GenerateKey instead.
+func GenerateMultiPrimeKey(random io.Reader, nprimes int, bits int) (*PrivateKey, error) {
+	panic("todo: rsa.GenerateMultiPrimeKey")
+}
+
+// Precompute performs some calculations that speed up private key operations
+// in the future. Only two-prime keys are decrypted by the Chinese remainder
+// theorem; the CRTValues of the further primes of a multi-prime key are
+// filled in, but not used.
+func (priv *PrivateKey) Precompute() {
+	if priv.Precomputed.Dp != nil || len(priv.Primes) < 2 {
+		return
+	}
+	p, q := priv.Primes[0], priv.Primes[1]
+	pre := &priv.Precomputed
+	pre.Dp = new(big.Int).Sub(p, bigOne)
+	pre.Dp.Mod(priv.D, pre.Dp)
+	pre.Dq = new(big.Int).Sub(q, bigOne)
+	pre.Dq.Mod(priv.D, pre.Dq)
+	pre.Qinv = new(big.Int).ModInverse(q, p)
+
+	r := new(big.Int).Mul(p, q)
+	pre.CRTValues = make([]CRTValue, len(priv.Primes)-2)
+	for i := 2; i < len(priv.Primes); i++ {
+		prime := priv.Primes[i]
+		values := &pre.CRTValues[i-2]
+
+		values.Exp = new(big.Int).Sub(prime, bigOne)
+		values.Exp.Mod(priv.D, values.Exp)
+
+		values.R = new(big.Int).Set(r)
+		values.Coeff = new(big.Int).ModInverse(r, prime)
+
+		r.Mul(r, prime)
+	}
+}
+
+// OAEPOptions is an interface for passing options to OAEP decryption using the
+// crypto.Decrypter interface.
+type OAEPOptions struct {
+	// Hash is the hash function that will be used when generating the mask.
+	Hash crypto.Hash
+
+	// MGFHash is the hash function used for MGF1.
+	// If zero, Hash is used instead.
+	MGFHash crypto.Hash
+
+	// Label is an arbitrary byte string that must be equal to the value
+	// used when encrypting.
+	Label []byte
+}
+
+// EncryptOAEP encrypts the given message with RSA-OAEP.
+//
+// OAEP is not implemented: EncryptOAEP panics.
+func EncryptOAEP(hash hash.Hash, random io.Reader, pub *PublicKey, msg []byte, label []byte) ([]byte, error) {
+	panic("todo: rsa.EncryptOAEP")
+}
+
+// DecryptOAEP decrypts ciphertext using RSA-OAEP.
+//
+// OAEP is not implemented: DecryptOAEP panics.
+func DecryptOAEP(hash hash.Hash, random io.Reader, priv *PrivateKey, ciphertext []byte, label []byte) ([]byte, error) {
+	panic("todo: rsa.DecryptOAEP")
+}
+
+// encrypt returns m**E mod N.
+func encrypt(pub *PublicKey, m *big.Int) *big.Int {
+	e := big.NewInt(int64(pub.E))
+	return new(big.Int).Exp(m, e, pub.N)
+}
+
+// decrypt returns c**D mod N. A precomputed two-prime key is decrypted by
+// the Chinese remainder theorem, with two exponentiations modulo the primes,
+// of about half the size of N, which is some 3 times faster:
+//
+//	m1 = c**Dp mod p
+//	m2 = c**Dq mod q
+//	m  = m2 + q * (Qinv * (m1 - m2) mod p)
+//
+// If check is set, the result is encrypted again and compared with c, so that
+// a fault in the computation, which would leak a prime with the faulty
+// signature, returns ErrDecryption instead.
+func decrypt(priv *PrivateKey, c *big.Int, check bool) (*big.Int, error) {
+	if priv.N.Sign() <= 0 || c.Cmp(priv.N) >= 0 {
+		return nil, ErrDecryption
+	}
+	var m *big.Int
+	pre := &priv.Precomputed
+	if pre.Dp == nil || len(priv.Primes) != 2 {
+		m = expSecret(new(big.Int), c, priv.D, priv.N)
+	} else {
+		p, q := priv.Primes[0], priv.Primes[1]
+		m = expSecret(new(big.Int), c, pre.Dp, p)
+		m2 := expSecret(new(big.Int), c, pre.Dq, q)
+		m.Sub(m, m2)
+		m.Mul(m, pre.Qinv)
+		m.Mod(m, p)
+		m.Mul(m, q)
+		m.Add(m, m2)
+	}
+	if check && encrypt(&priv.PublicKey, m).Cmp(c) != 0 {
+		return nil, ErrDecryption
+	}
+	return m, nil
+}
+
+// expConstTimer is implemented by the math/big of llgo, whose ExpConstTime
+// runs in a time independent of the value of the exponent.
+type expConstTimer interface {
+	ExpConstTime(x, y, m *big.Int) *big.Int
+}
+
+// expSecret sets z = x**y mod m for a secret exponent y and an odd m, and
+// returns z. It falls back to Exp for a math/big without ExpConstTime, such
+// as the one of the standard library.
+func expSecret(z, x, y, m *big.Int) *big.Int {
+	if e, ok := any(z).(expConstTimer); ok {
+		return e.ExpConstTime(x, y, m)
+	}
+	return z.Exp(x, y, m)
+}
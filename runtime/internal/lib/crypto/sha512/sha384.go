@@ -42,9 +42,11 @@ func (d *digest384) Write(p []byte) (nn int, err error) {
 }
 
 func (d *digest384) Sum(in []byte) []byte {
+	// Finalize a copy so that the caller can keep writing to d.
+	ctx := d.ctx
 	hash := (*[Size]byte)(c.Alloca(Size))
-	d.ctx.Final((*byte)(unsafe.Pointer(hash)))
-	return append(in, hash[:]...)
+	ctx.Final((*byte)(unsafe.Pointer(hash)))
+	return append(in, hash[:Size384]...)
 }
 
 func New384() hash.Hash {
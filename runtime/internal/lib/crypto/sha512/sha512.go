@@ -71,8 +71,10 @@ func (d *digest512) Write(p []byte) (nn int, err error) {
 }
 
 func (d *digest512) Sum(in []byte) []byte {
+	// Finalize a copy so that the caller can keep writing to d.
+	ctx := d.ctx
 	hash := (*[Size]byte)(c.Alloca(Size))
-	d.ctx.Final((*byte)(unsafe.Pointer(hash)))
+	ctx.Final((*byte)(unsafe.Pointer(hash)))
 	return append(in, hash[:]...)
 }
 
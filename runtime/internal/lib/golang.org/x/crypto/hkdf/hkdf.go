@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package hkdf implements the HMAC-based Extract-and-Expand Key Derivation
+// Function (HKDF) as defined in RFC 5869, on top of the OpenSSL-backed
+// crypto/hmac. It replaces golang.org/x/crypto/hkdf.
+package hkdf
+
+import (
+	"crypto/hmac"
+	"errors"
+	"hash"
+	"io"
+)
+
+// llgo:skipall
+type _hkdf struct{}
+
+// Extract generates a pseudorandom key for use with Expand from an input
+// secret and an optional independent salt.
+//
+// Only use this function if you need to reuse the extracted key with
+// multiple Expand invocations and different context values. Most common
+// scenarios, including the generation of multiple keys, should use New
+// instead.
+func Extract(hash func() hash.Hash, secret, salt []byte) []byte {
+	if salt == nil {
+		salt = make([]byte, hash().Size())
+	}
+	extractor := hmac.New(hash, salt)
+	extractor.Write(secret)
+	return extractor.Sum(nil)
+}
+
+// maxBlocks is the number of hash-sized blocks Expand can produce: the block
+// counter is a single byte, starting at 1.
+const maxBlocks = 255
+
+type reader struct {
+	expander hash.Hash // HMAC keyed with the pseudorandom key
+	info     []byte
+	size     int    // of a block, the expander's output
+	blocks   int    // computed so far
+	prev     []byte // the last block, T(blocks)
+	left     []byte // unread tail of prev
+}
+
+// Read returns the next len(p) bytes of key material. It fails without
+// reading anything if fewer than len(p) bytes are left before the limit of
+// 255 blocks, so that a key is never cut short.
+func (r *reader) Read(p []byte) (int, error) {
+	n := len(p)
+	if n > len(r.left)+(maxBlocks-r.blocks)*r.size {
+		return 0, errors.New("hkdf: entropy limit reached")
+	}
+	for {
+		k := copy(p, r.left)
+		r.left = r.left[k:]
+		if p = p[k:]; len(p) == 0 {
+			return n, nil
+		}
+		// T(i) = HMAC(PRK, T(i-1) | info | i), with T(0) empty.
+		r.blocks++
+		r.expander.Reset()
+		r.expander.Write(r.prev)
+		r.expander.Write(r.info)
+		r.expander.Write([]byte{byte(r.blocks)})
+		r.prev = r.expander.Sum(r.prev[:0])
+		r.left = r.prev
+	}
+}
+
+// Expand returns a Reader, from which keys can be read, using the given
+// pseudorandom key and optional context info, skipping the extraction step.
+//
+// The pseudorandomKey should have been generated by Extract, or be a
+// uniformly random or pseudorandom cryptographically strong key. See RFC
+// 5869, Section 3.3. Most common scenarios will want to use New instead.
+func Expand(hash func() hash.Hash, pseudorandomKey, info []byte) io.Reader {
+	expander := hmac.New(hash, pseudorandomKey)
+	return &reader{expander: expander, info: info, size: expander.Size()}
+}
+
+// New returns a Reader, from which keys can be read, using the given hash,
+// secret, salt and context info. Salt and info can be nil. At most 255 times
+// the size of the hash can be read.
+func New(hash func() hash.Hash, secret, salt, info []byte) io.Reader {
+	prk := Extract(hash, secret, salt)
+	return Expand(hash, prk, info)
+}
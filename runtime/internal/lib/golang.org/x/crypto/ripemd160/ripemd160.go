@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ripemd160 implements the RIPEMD-160 hash algorithm on top of
+// OpenSSL's EVP digest. It replaces golang.org/x/crypto/ripemd160.
+package ripemd160
+
+import (
+	"crypto"
+	"hash"
+	"runtime"
+
+	c "github.com/goplus/llgo/runtime/internal/clite"
+	"github.com/goplus/llgo/runtime/internal/clite/openssl"
+)
+
+// llgo:skipall
+type _ripemd160 struct{}
+
+func init() {
+	crypto.RegisterHash(crypto.RIPEMD160, New)
+}
+
+// The size of the checksum in bytes.
+const Size = 20
+
+// The block size of the hash algorithm in bytes.
+const BlockSize = 64
+
+type digest struct {
+	ctx *openssl.EVP_MD_CTX
+	sum *openssl.EVP_MD_CTX // scratch copy of ctx finalized by Sum
+}
+
+// free releases the EVP contexts of d. It is the finalizer of d.
+func (d *digest) free() {
+	d.ctx.Free()
+	if d.sum != nil {
+		d.sum.Free()
+	}
+}
+
+func (d *digest) Size() int { return Size }
+
+func (d *digest) BlockSize() int { return BlockSize }
+
+func (d *digest) Reset() {
+	d.ctx.DigestInit(openssl.EVP_ripemd160(), nil)
+}
+
+func (d *digest) Write(p []byte) (nn int, err error) {
+	d.ctx.DigestUpdateBytes(p)
+	return len(p), nil
+}
+
+func (d *digest) Sum(in []byte) []byte {
+	// Finalize a copy so that the caller can keep writing and summing. The
+	// copy fully replaces the state left in d.sum by the previous Sum, so the
+	// same scratch context is reused instead of allocating one per call.
+	// Contexts aren't pooled across digests: the sync.Pool of llgo doesn't
+	// keep what is Put yet, so every Get would allocate a context anyway, and
+	// one dropped by the pool would never be freed.
+	if d.sum == nil {
+		d.sum = openssl.NewEVP_MD_CTX()
+	}
+	d.sum.Copy(d.ctx)
+	hash := (*[Size]byte)(c.Alloca(Size))
+	d.sum.DigestFinal(&hash[0], nil)
+	return append(in, hash[:]...)
+}
+
+// New returns a new hash.Hash computing the checksum.
+func New() hash.Hash {
+	d := &digest{ctx: openssl.NewEVP_MD_CTX()}
+	runtime.SetFinalizer(d, (*digest).free)
+	d.Reset()
+	return d
+}
@@ -0,0 +1,104 @@
+//go:build !math_big_pure_go && !gmp
+
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package big
+
+import (
+	"math"
+	"math/bits"
+	"unsafe"
+
+	c "github.com/goplus/llgo/runtime/internal/clite"
+)
+
+// A BitSet is a set of non-negative integers, held as the bits of an Int:
+// i is in the set if bit i is set. The zero value is an empty set, ready to
+// use. Like an Int, a BitSet must not be copied.
+//
+// The elements are BIGNUM bit indices, which are C ints: Add and Contains
+// panic for an element larger than math.MaxInt32.
+type BitSet struct {
+	x Int
+}
+
+// Add adds i to s.
+func (s *BitSet) Add(i uint) {
+	if s.x.mut().SetBit(bitIndex(i)) == 0 {
+		panic(newError("BN_set_bit"))
+	}
+}
+
+// Contains reports whether i is in s.
+func (s *BitSet) Contains(i uint) bool {
+	return s.x.bn().IsBitSet(bitIndex(i)) != 0
+}
+
+// bitIndex returns the element i as a bit index.
+func bitIndex(i uint) c.Int {
+	if i > math.MaxInt32 {
+		panic("math/big: BitSet element out of range")
+	}
+	return c.Int(i)
+}
+
+// Union sets s to the union of s and b.
+func (s *BitSet) Union(b *BitSet) {
+	s.x.Or(&s.x, &b.x)
+}
+
+// Intersect sets s to the intersection of s and b.
+func (s *BitSet) Intersect(b *BitSet) {
+	s.x.And(&s.x, &b.x)
+}
+
+// Difference removes the elements of b from s.
+func (s *BitSet) Difference(b *BitSet) {
+	s.x.AndNot(&s.x, &b.x)
+}
+
+// Count returns the number of elements of s. OpenSSL has no population
+// count, so it is computed over the bytes of the set.
+func (s *BitSet) Count() int {
+	n := 0
+	for _, b := range s.bytes() {
+		n += bits.OnesCount8(b)
+	}
+	return n
+}
+
+// Iterate calls f for each element of s in increasing order, until f
+// returns false. s must not be modified during the iteration.
+func (s *BitSet) Iterate(f func(i uint) bool) {
+	for k, b := range s.bytes() {
+		for b != 0 {
+			i := bits.TrailingZeros8(b)
+			if !f(uint(k*8 + i)) {
+				return
+			}
+			b &= b - 1
+		}
+	}
+}
+
+// bytes returns the bits of s in little-endian byte order.
+func (s *BitSet) bytes() []byte {
+	a := s.x.bn()
+	buf := make([]byte, a.NumBytes())
+	a.Bn2lebinpad(unsafe.SliceData(buf), c.Int(len(buf)))
+	return buf
+}
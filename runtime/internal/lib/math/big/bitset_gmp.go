@@ -0,0 +1,72 @@
+//go:build gmp && !math_big_pure_go
+
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package big
+
+import c "github.com/goplus/llgo/runtime/internal/clite"
+
+// A BitSet is a set of non-negative integers, held as the bits of an Int:
+// i is in the set if bit i is set. The zero value is an empty set, ready to
+// use. Like an Int, a BitSet must not be copied.
+type BitSet struct {
+	x Int
+}
+
+// Add adds i to s.
+func (s *BitSet) Add(i uint) {
+	s.x.mut().Setbit(c.Ulong(i))
+}
+
+// Contains reports whether i is in s.
+func (s *BitSet) Contains(i uint) bool {
+	return s.x.mpz().Tstbit(c.Ulong(i)) != 0
+}
+
+// Union sets s to the union of s and b.
+func (s *BitSet) Union(b *BitSet) {
+	s.x.Or(&s.x, &b.x)
+}
+
+// Intersect sets s to the intersection of s and b.
+func (s *BitSet) Intersect(b *BitSet) {
+	s.x.And(&s.x, &b.x)
+}
+
+// Difference removes the elements of b from s.
+func (s *BitSet) Difference(b *BitSet) {
+	s.x.AndNot(&s.x, &b.x)
+}
+
+// Count returns the number of elements of s, by mpz_popcount.
+func (s *BitSet) Count() int {
+	return int(s.x.mpz().Popcount())
+}
+
+// Iterate calls f for each element of s in increasing order, until f
+// returns false. s must not be modified during the iteration.
+func (s *BitSet) Iterate(f func(i uint) bool) {
+	a := s.x.mpz()
+	if a.Sgn() == 0 {
+		return
+	}
+	// mpz_scan1 returns the largest mp_bitcnt_t past the highest set bit.
+	for i := a.Scan1(0); i != ^c.Ulong(0); i = a.Scan1(i + 1) {
+		if !f(uint(i)) {
+			return
+		}
+	}
+}
@@ -0,0 +1,35 @@
+//go:build !math_big_pure_go
+
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package big
+
+// An Error is returned for failures reported by OpenSSL, such as running out
+// of memory while growing a BIGNUM. Code is the earliest error of the OpenSSL
+// error queue of the calling thread; Msg describes all the queued errors.
+//
+// The GMP backend never returns an Error: GMP aborts the program when it
+// fails to allocate memory.
+type Error struct {
+	Op   string // the failing OpenSSL function, e.g. "BN_mul_word"
+	Code uint64 // packed OpenSSL error code, 0 if the queue was empty
+	Msg  string
+}
+
+func (e *Error) Error() string {
+	return "math/big: " + e.Op + ": " + e.Msg
+}
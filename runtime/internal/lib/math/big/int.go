@@ -1,3 +1,5 @@
+//go:build !math_big_pure_go && !gmp
+
 /*
  * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
  *
@@ -17,7 +19,12 @@
 package big
 
 import (
+	"fmt"
+	"math"
 	"math/rand"
+	"runtime"
+	"sync/atomic"
+	"unsafe"
 
 	c "github.com/goplus/llgo/runtime/internal/clite"
 	"github.com/goplus/llgo/runtime/internal/clite/openssl"
@@ -29,6 +36,11 @@ type _big struct{}
 // A Word represents a single digit of a multi-precision unsigned integer.
 type Word openssl.BN_ULONG
 
+const (
+	_W = 64        // word size in bits
+	_M = 1<<_W - 1 // digit mask
+)
+
 // -----------------------------------------------------------------------------
 
 // TODO(xsw): share ctx
@@ -40,9 +52,157 @@ func ctxPut(ctx *openssl.BN_CTX) {
 	ctx.Free()
 }
 
+// newError drains the OpenSSL error queue of the calling thread into an
+// *Error for the failed operation op.
+func newError(op string) *Error {
+	e := openssl.NewError("math/big", op)
+	return &Error{Op: op, Code: uint64(e.Code), Msg: e.Msg}
+}
+
 // -----------------------------------------------------------------------------
 
-type Int openssl.BIGNUM
+// An Int represents a signed multi-precision integer.
+// The zero value for an Int represents the value 0.
+//
+// Operations always take pointer arguments (*Int) rather
+// than Int values, and each unique Int value requires
+// its own unique *Int pointer. To "copy" an Int value,
+// an existing (or newly allocated) Int must be set to
+// a new value using the Int.Set method; shallow copies
+// of Ints are not supported and may lead to errors.
+type Int struct {
+	b   unsafe.Pointer // *bnBox, allocated on first use, see box
+	gen uint64         // bumped by every mutation, see mut
+
+	text unsafe.Pointer // *intText cached by String
+}
+
+// A bnBox owns the BIGNUM of an Int. It is a separate heap object so that a
+// finalizer can free the BIGNUM once the box is unreachable, wherever the Int
+// itself lives (see box). Its p is only nil after free.
+type bnBox struct {
+	p      *openssl.BIGNUM
+	secure bool // see SetSecure
+}
+
+func newBox(secure bool) *bnBox {
+	b := &bnBox{p: openssl.BNNew(), secure: secure}
+	if secure {
+		b.p.SetFlags(openssl.BN_FLG_CONSTTIME)
+	}
+	return b
+}
+
+// box returns the bnBox of x, allocating it on first use so that the zero
+// value of Int is ready to use. Reading an Int is no write to it, so that
+// concurrent reads of a zero value are safe: the first box published wins
+// and the others are freed.
+func (x *Int) box() *bnBox {
+	b := (*bnBox)(atomic.LoadPointer(&x.b))
+	if b == nil {
+		b = newBox(false)
+		if !atomic.CompareAndSwapPointer(&x.b, nil, unsafe.Pointer(b)) {
+			b.free()
+			return (*bnBox)(atomic.LoadPointer(&x.b))
+		}
+		runtime.SetFinalizer(b, (*bnBox).free)
+	}
+	return b
+}
+
+// bn returns the BIGNUM holding x.
+func (x *Int) bn() *openssl.BIGNUM {
+	return x.box().p
+}
+
+// free releases the BIGNUM of b, unless it was released already.
+func (b *bnBox) free() {
+	if p := b.p; p != nil {
+		b.p = nil
+		if b.secure {
+			p.ClearFree()
+		} else {
+			p.Free()
+		}
+	}
+}
+
+// Free releases the memory held by z right away instead of when z becomes
+// unreachable, and sets z to 0. It is useful for large values, or in
+// programs built without the garbage collector, which never run finalizers.
+// z must not be used concurrently with Free.
+//
+// A secure z is scrubbed before its memory is released, and stays secure.
+func (z *Int) Free() {
+	b := (*bnBox)(atomic.LoadPointer(&z.b))
+	if b == nil {
+		return
+	}
+	var next unsafe.Pointer
+	if b.secure {
+		nb := newBox(true)
+		runtime.SetFinalizer(nb, (*bnBox).free)
+		next = unsafe.Pointer(nb)
+	}
+	atomic.AddUint64(&z.gen, 1)
+	atomic.StorePointer(&z.b, next)
+	b.free()
+}
+
+// SetSecure marks z as holding a secret, such as a private key, or clears
+// the mark, and returns z. The value of z is unchanged.
+//
+// The memory of a secure Int is zeroed before it is released, by Free or
+// once z is unreachable, and OpenSSL uses constant-time algorithms where
+// z is an operand. The mark belongs to z and not to its value: it is kept
+// when z is overwritten, e.g. by Set, and isn't passed on to the results
+// of operations that read z. String doesn't memoize the text of a secure
+// Int.
+func (z *Int) SetSecure(secure bool) *Int {
+	b := z.box()
+	a := b.p
+	if secure == b.secure {
+		return z
+	}
+	if secure {
+		a.SetFlags(openssl.BN_FLG_CONSTTIME)
+		atomic.StorePointer(&z.text, nil)
+	} else {
+		// BN_FLG_CONSTTIME can't be cleared, move the value to a new BIGNUM.
+		p := openssl.BNNew()
+		p.Copy(a)
+		a.ClearFree()
+		b.p = p
+	}
+	b.secure = secure
+	return z
+}
+
+// IsSecure reports whether z was marked secure by SetSecure.
+func (x *Int) IsSecure() bool {
+	b := (*bnBox)(atomic.LoadPointer(&x.b))
+	return b != nil && b.secure
+}
+
+// Zero sets z to 0 and returns z, overwriting all the words allocated for its
+// value with BN_clear, where SetInt64(0) only resets the length. It wipes a
+// one-shot secret, such as a DH shared secret, from an Int that isn't marked
+// by SetSecure, and keeps the memory for reuse, unlike Free. The secrecy of
+// the copies of the value made elsewhere, e.g. by Set or String, is up to
+// the caller; the text memoized by String, if any, is dropped.
+func (z *Int) Zero() *Int {
+	z.mut().Clear()
+	atomic.StorePointer(&z.text, nil)
+	return z
+}
+
+// mut returns the BIGNUM holding z for modification. Every method that
+// changes the value of z must use it, as it also invalidates state derived
+// from the value, such as the cached String text.
+func (z *Int) mut() *openssl.BIGNUM {
+	atomic.AddUint64(&z.gen, 1)
+	return z.bn()
+}
 
 // Sign returns:
 //
@@ -50,7 +210,7 @@ type Int openssl.BIGNUM
 //	 0 if x == 0
 //	+1 if x >  0
 func (x *Int) Sign() int {
-	a := (*openssl.BIGNUM)(x)
+	a := x.bn()
 	if a.IsNegative() != 0 {
 		return -1
 	} else if a.IsZero() != 0 {
@@ -61,7 +221,7 @@ func (x *Int) Sign() int {
 
 // SetInt64 sets z to x and returns z.
 func (z *Int) SetInt64(x int64) *Int {
-	a := (*openssl.BIGNUM)(z)
+	a := z.mut()
 	if x < 0 {
 		a.SetWord(openssl.BN_ULONG(-x))
 		a.SetNegative(1)
@@ -74,7 +234,7 @@ func (z *Int) SetInt64(x int64) *Int {
 
 // SetUint64 sets z to x and returns z.
 func (z *Int) SetUint64(x uint64) *Int {
-	a := (*openssl.BIGNUM)(z)
+	a := z.mut()
 	a.SetWord(openssl.BN_ULONG(x))
 	a.SetNegative(0)
 	return z
@@ -82,16 +242,13 @@ func (z *Int) SetUint64(x uint64) *Int {
 
 // NewInt allocates and returns a new Int set to x.
 func NewInt(x int64) *Int {
-	z := (*Int)(openssl.BNNew())
-	return z.SetInt64(x)
+	return new(Int).SetInt64(x)
 }
 
 // Set sets z to x and returns z.
 func (z *Int) Set(x *Int) *Int {
 	if z != x {
-		a := (*openssl.BIGNUM)(z)
-		b := (*openssl.BIGNUM)(x)
-		a.Copy(b)
+		z.mut().Copy(x.bn())
 	}
 	return z
 }
@@ -99,15 +256,14 @@ func (z *Int) Set(x *Int) *Int {
 // Abs sets z to |x| (the absolute value of x) and returns z.
 func (z *Int) Abs(x *Int) *Int {
 	z.Set(x)
-	a := (*openssl.BIGNUM)(z)
-	a.SetNegative(0)
+	z.mut().SetNegative(0)
 	return z
 }
 
 // Neg sets z to -x and returns z.
 func (z *Int) Neg(x *Int) *Int {
 	z.Set(x)
-	a := (*openssl.BIGNUM)(z)
+	a := z.mut()
 	if a.IsNegative() != 0 {
 		a.SetNegative(0)
 	} else {
@@ -136,19 +292,31 @@ func (z *Int) SetBits(abs []Word) *Int {
 
 // Add sets z to the sum x+y and returns z.
 func (z *Int) Add(x, y *Int) *Int {
-	(*openssl.BIGNUM)(z).Add((*openssl.BIGNUM)(x), (*openssl.BIGNUM)(y))
+	z.mut().Add(x.bn(), y.bn())
 	return z
 }
 
 // Sub sets z to the difference x-y and returns z.
 func (z *Int) Sub(x, y *Int) *Int {
-	(*openssl.BIGNUM)(z).Sub((*openssl.BIGNUM)(x), (*openssl.BIGNUM)(y))
+	z.mut().Sub(x.bn(), y.bn())
 	return z
 }
 
 // Mul sets z to the product x*y and returns z.
+//
+// Mul uses BN_mul, or BN_sqr if x and y are the same Int. OpenSSL switches
+// from schoolbook to Karatsuba multiplication for operands of at least 16
+// words (BN_MULL_SIZE_NORMAL), a threshold fixed when OpenSSL is built: unlike
+// math/big's karatsubaThreshold, it can't be tuned at run time.
 func (z *Int) Mul(x, y *Int) *Int {
-	panic("todo big.Mul")
+	ctx := ctxGet()
+	defer ctxPut(ctx)
+	if x == y {
+		z.mut().Sqr(x.bn(), ctx)
+	} else {
+		z.mut().Mul(x.bn(), y.bn(), ctx)
+	}
+	return z
 }
 
 // MulRange sets z to the product of all integers
@@ -167,14 +335,16 @@ func (z *Int) Binomial(n, k int64) *Int {
 // If y == 0, a division-by-zero run-time panic occurs.
 // Quo implements truncated division (like Go); see QuoRem for more details.
 func (z *Int) Quo(x, y *Int) *Int {
-	panic("todo big.Quo")
+	quoRem(z, nil, x, y)
+	return z
 }
 
 // Rem sets z to the remainder x%y for y != 0 and returns z.
 // If y == 0, a division-by-zero run-time panic occurs.
 // Rem implements truncated modulus (like Go); see QuoRem for more details.
 func (z *Int) Rem(x, y *Int) *Int {
-	panic("todo big.Rem")
+	quoRem(nil, z, x, y)
+	return z
 }
 
 // QuoRem sets z to the quotient x/y and r to the remainder x%y
@@ -188,22 +358,53 @@ func (z *Int) Rem(x, y *Int) *Int {
 //
 // (See Daan Leijen, “Division and Modulus for Computer Scientists”.)
 // See DivMod for Euclidean division and modulus (unlike Go).
+//
+// The results are stored in z and r themselves, so that reusing them as
+// scratch values across calls, e.g. in a loop, doesn't allocate. Any of z,
+// r, x and y may be the same Int, except z and r.
 func (z *Int) QuoRem(x, y, r *Int) (*Int, *Int) {
-	panic("todo big.QuoRem")
+	quoRem(z, r, x, y)
+	return z, r
+}
+
+// quoRem sets q to the quotient and r to the remainder of the T-division x/y,
+// either of them may be nil. It panics like math/big if y == 0.
+func quoRem(q, r, x, y *Int) {
+	if y.bn().IsZero() != 0 {
+		panic("division by zero")
+	}
+	var dv, rem *openssl.BIGNUM
+	if q != nil {
+		dv = q.mut()
+	}
+	if r != nil {
+		rem = r.mut()
+	}
+	ctx := ctxGet()
+	dv.Div(rem, x.bn(), y.bn(), ctx)
+	ctxPut(ctx)
 }
 
 // Div sets z to the quotient x/y for y != 0 and returns z.
 // If y == 0, a division-by-zero run-time panic occurs.
 // Div implements Euclidean division (unlike Go); see DivMod for more details.
 func (z *Int) Div(x, y *Int) *Int {
-	panic("todo big.Div")
+	var m Int
+	z.DivMod(x, y, &m)
+	return z
 }
 
 // Mod sets z to the modulus x%y for y != 0 and returns z.
 // If y == 0, a division-by-zero run-time panic occurs.
 // Mod implements Euclidean modulus (unlike Go); see DivMod for more details.
 func (z *Int) Mod(x, y *Int) *Int {
-	panic("todo big.Mod")
+	if y.bn().IsZero() != 0 {
+		panic("division by zero")
+	}
+	ctx := ctxGet()
+	z.mut().Nnmod(x.bn(), y.bn(), ctx) // 0 <= z < |y|
+	ctxPut(ctx)
+	return z
 }
 
 // DivMod sets z to the quotient x div y and m to the modulus x mod y
@@ -221,7 +422,21 @@ func (z *Int) Mod(x, y *Int) *Int {
 // ACM press.)
 // See QuoRem for T-division and modulus (like Go).
 func (z *Int) DivMod(x, y, m *Int) (*Int, *Int) {
-	panic("big.DivMod")
+	y0 := y // save y
+	if z == y || m == y {
+		y0 = new(Int).Set(y)
+	}
+	z.QuoRem(x, y, m)
+	if m.Sign() < 0 {
+		if y0.Sign() > 0 {
+			z.mut().SubWord(1)
+			m.Add(m, y0)
+		} else {
+			z.mut().AddWord(1)
+			m.Sub(m, y0)
+		}
+	}
+	return z, m
 }
 
 // Cmp compares x and y and returns:
@@ -230,7 +445,10 @@ func (z *Int) DivMod(x, y, m *Int) (*Int, *Int) {
 //	 0 if x == y
 //	+1 if x >  y
 func (x *Int) Cmp(y *Int) (r int) {
-	return int((*openssl.BIGNUM)(x).Cmp((*openssl.BIGNUM)(y)))
+	// BN_cmp settles operands of different signs or word counts before it
+	// looks at any word, so testing BN_is_negative and BN_num_bits first
+	// only adds calls: BN_num_bits scans the top word for its bit length.
+	return int(x.bn().Cmp(y.bn()))
 }
 
 // CmpAbs compares the absolute values of x and y and returns:
@@ -239,29 +457,30 @@ func (x *Int) Cmp(y *Int) (r int) {
 //	 0 if |x| == |y|
 //	+1 if |x| >  |y|
 func (x *Int) CmpAbs(y *Int) int {
-	return int((*openssl.BIGNUM)(x).Ucmp((*openssl.BIGNUM)(y)))
+	return int(x.bn().Ucmp(y.bn()))
 }
 
 // Int64 returns the int64 representation of x.
 // If x cannot be represented in an int64, the result is undefined.
 func (x *Int) Int64() int64 {
-	panic("todo big.Int64")
+	return int64(lowBits(x.bn(), _M)) // -low64(|x|) for x < 0, as in math/big
 }
 
 // Uint64 returns the uint64 representation of x.
 // If x cannot be represented in a uint64, the result is undefined.
 func (x *Int) Uint64() uint64 {
-	panic("todo big.Uint64")
+	return lowAbs(x.bn(), _M)
 }
 
 // IsInt64 reports whether x can be represented as an int64.
 func (x *Int) IsInt64() bool {
-	panic("todo big.IsInt64")
+	n := x.BitLen()
+	return n < 64 || n == 64 && x.Sign() < 0 && x.TrailingZeroBits() == 63 // -1 << 63
 }
 
 // IsUint64 reports whether x can be represented as a uint64.
 func (x *Int) IsUint64() bool {
-	panic("todo big.IsUint64")
+	return x.Sign() >= 0 && x.BitLen() <= 64
 }
 
 // Float64 returns the float64 value nearest x,
@@ -272,43 +491,21 @@ func (x *Int) Float64() (float64, Accuracy) {
 	panic("todo big.Float64")
 }*/
 
-// SetString sets z to the value of s, interpreted in the given base,
-// and returns z and a boolean indicating success. The entire string
-// (not just a prefix) must be valid for success. If SetString fails,
-// the value of z is undefined but the returned value is nil.
-//
-// The base argument must be 0 or a value between 2 and MaxBase.
-// For base 0, the number prefix determines the actual base: A prefix of
-// “0b” or “0B” selects base 2, “0”, “0o” or “0O” selects base 8,
-// and “0x” or “0X” selects base 16. Otherwise, the selected base is 10
-// and no prefix is accepted.
-//
-// For bases <= 36, lower and upper case letters are considered the same:
-// The letters 'a' to 'z' and 'A' to 'Z' represent digit values 10 to 35.
-// For bases > 36, the upper case letters 'A' to 'Z' represent the digit
-// values 36 to 61.
-//
-// For base 0, an underscore character “_” may appear between a base
-// prefix and an adjacent digit, and between successive digits; such
-// underscores do not change the value of the number.
-// Incorrect placement of underscores is reported as an error if there
-// are no other errors. If base != 0, underscores are not recognized
-// and act like any other character that is not a valid digit.
-func (z *Int) SetString(s string, base int) (*Int, bool) {
-	panic("todo big.SetString")
-}
-
 // SetBytes interprets buf as the bytes of a big-endian unsigned
 // integer, sets z to that value, and returns z.
 func (z *Int) SetBytes(buf []byte) *Int {
-	panic("todo big.SetBytes")
+	openssl.BNBin2bn(unsafe.SliceData(buf), c.Int(len(buf)), z.mut())
+	return z
 }
 
 // Bytes returns the absolute value of x as a big-endian byte slice.
 //
 // To use a fixed length slice, or a preallocated one, use FillBytes.
 func (x *Int) Bytes() []byte {
-	panic("todo big.Bytes")
+	a := x.bn()
+	buf := make([]byte, a.NumBytes())
+	a.Bn2bin(unsafe.SliceData(buf))
+	return buf
 }
 
 // FillBytes sets buf to the absolute value of x, storing it as a zero-extended
@@ -316,19 +513,89 @@ func (x *Int) Bytes() []byte {
 //
 // If the absolute value of x doesn't fit in buf, FillBytes will panic.
 func (x *Int) FillBytes(buf []byte) []byte {
-	panic("todo big.FillBytes")
+	a := x.bn()
+	if int(a.NumBytes()) > len(buf) {
+		panic("math/big: buffer too small to fit value")
+	}
+	a.Bn2binpad(unsafe.SliceData(buf), c.Int(len(buf)))
+	return buf
+}
+
+// FillBytesSigned sets buf to the two's complement encoding of x, storing it
+// as a sign-extended big-endian byte slice, and returns buf. It is the
+// fixed-width counterpart of FillBytes for signed fields: -1 fills buf with
+// 0xff bytes.
+//
+// If x doesn't fit in buf, that is, x is outside [-2**(n-1), 2**(n-1)) for
+// the n = 8*len(buf) bits of buf, FillBytesSigned will panic.
+func (x *Int) FillBytesSigned(buf []byte) []byte {
+	if x.bn().SignedBn2bin(unsafe.SliceData(buf), c.Int(len(buf))) < 0 {
+		panic("math/big: buffer too small to fit value")
+	}
+	return buf
+}
+
+// Bytes32 returns the absolute value of x as a zero-extended big-endian
+// 32-byte array, the fixed-size encoding of a 256-bit field element or
+// scalar. Bytes32 and the other fixed-size encodings below are llgo
+// extensions with no counterpart in math/big.
+//
+// If the absolute value of x doesn't fit in 32 bytes, Bytes32 will panic.
+func (x *Int) Bytes32() (buf [32]byte) {
+	x.FillBytes(buf[:])
+	return
+}
+
+// Bytes64 is like Bytes32 for a 64-byte array, such as the concatenated
+// coordinates of a point on a 256-bit curve.
+//
+// If the absolute value of x doesn't fit in 64 bytes, Bytes64 will panic.
+func (x *Int) Bytes64() (buf [64]byte) {
+	x.FillBytes(buf[:])
+	return
+}
+
+// SetBytes32 interprets b as the bytes of a big-endian unsigned integer,
+// sets z to that value, and returns z. It is the inverse of Bytes32.
+func (z *Int) SetBytes32(b [32]byte) *Int {
+	return z.SetBytes(b[:])
+}
+
+// SetBytes64 is like SetBytes32 for a 64-byte array, the inverse of Bytes64.
+func (z *Int) SetBytes64(b [64]byte) *Int {
+	return z.SetBytes(b[:])
+}
+
+// Key returns a compact binary encoding of x for use as a map[string] key:
+// a sign byte followed by the minimal big-endian bytes of |x|. x and y have
+// the same Key if and only if x.Cmp(y) == 0; in particular there is a single
+// key for 0, whatever the sign it was computed with.
+func (x *Int) Key() string {
+	a := x.bn()
+	buf := make([]byte, 1+a.NumBytes())
+	buf[0] = byte(x.Sign() + 1) // 0, 1 or 2
+	a.Bn2bin(unsafe.SliceData(buf[1:]))
+	return string(buf)
 }
 
 // BitLen returns the length of the absolute value of x in bits.
 // The bit length of 0 is 0.
 func (x *Int) BitLen() int {
-	panic("todo big.BitLen")
+	return int(x.bn().NumBits())
 }
 
 // TrailingZeroBits returns the number of consecutive least significant zero
 // bits of |x|.
 func (x *Int) TrailingZeroBits() uint {
-	panic("todo big.TrailingZeroBits")
+	a := x.bn()
+	if a.IsZero() != 0 {
+		return 0
+	}
+	var i c.Int
+	for a.IsBitSet(i) == 0 {
+		i++
+	}
+	return uint(i)
 }
 
 // Exp sets z = x**y mod |m| (i.e. the sign of m is ignored), and returns z.
@@ -338,14 +605,176 @@ func (x *Int) TrailingZeroBits() uint {
 // Modular exponentiation of inputs of a particular size is not a
 // cryptographically constant-time operation.
 func (z *Int) Exp(x, y, m *Int) *Int {
+	if m != nil && m.Sign() == 0 {
+		m = nil
+	}
+	if m == nil && y.Sign() <= 0 {
+		return z.SetInt64(1)
+	}
 	ctx := ctxGet()
-	mbn := (*openssl.BIGNUM)(m)
-	if mbn == nil || mbn.IsZero() != 0 {
-		(*openssl.BIGNUM)(z).Exp((*openssl.BIGNUM)(x), (*openssl.BIGNUM)(y), ctx)
-	} else {
-		(*openssl.BIGNUM)(z).ModExp((*openssl.BIGNUM)(x), (*openssl.BIGNUM)(y), mbn, ctx)
+	defer ctxPut(ctx)
+	if m == nil {
+		if y.BitLen() > _W && x.BitLen() > 1 {
+			// The power would have more than 2**64 bits.
+			panic("math/big: Exp result too large")
+		}
+		z.mut().Exp(x.bn(), y.bn(), ctx)
+		return z
 	}
-	ctxPut(ctx)
+
+	// Work modulo |m| with the base reduced into [0, |m|), which makes the
+	// result non-negative as well, like math/big's.
+	mod := openssl.BNNew()
+	defer mod.Free()
+	mod.Copy(m.bn())
+	mod.SetNegative(0)
+	if mod.IsOne() != 0 {
+		return z.SetInt64(0) // BN_mod_inverse has no inverse modulo 1
+	}
+	base := openssl.BNNew()
+	defer base.Free()
+	base.Nnmod(x.bn(), mod, ctx)
+	exp := openssl.BNNew()
+	defer exp.Free()
+	exp.Copy(y.bn())
+	negX, negY := x.Sign() < 0, exp.IsNegative() != 0
+	if negY {
+		// x**y == (x**-1)**|y| for x and m relatively prime
+		if base.ModInverse(base, mod, ctx) == nil {
+			openssl.ERRClearError() // BN_R_NO_INVERSE
+			return nil
+		}
+		exp.SetNegative(0)
+	}
+	// z may alias x, y or m, whose values were copied above.
+	a := z.mut()
+	a.ModExp(base, exp, mod, ctx)
+	if negX && negY && exp.IsOdd() != 0 && a.IsZero() == 0 {
+		// math/big applies the sign of x to the result again even though the
+		// inverse already accounts for it; match it for identical results.
+		a.Sub(mod, a)
+	}
+	return z
+}
+
+// ExpConstTime sets z = x**y mod |m| and returns z, like Exp, for a secret
+// exponent y: it uses BN_mod_exp_mont_consttime with BN_FLG_CONSTTIME set on
+// a copy of y, so that the sequence of operations and memory accesses, and
+// hence the running time, depend on the sizes of x, y and m but not on the
+// value of y. Nothing is promised for the values of x and m.
+//
+// y must not be negative and m must be odd, as required by Montgomery
+// multiplication; ExpConstTime panics otherwise. Use Exp when y isn't secret,
+// which is faster.
+func (z *Int) ExpConstTime(x, y, m *Int) *Int {
+	if y.Sign() < 0 {
+		panic("math/big: ExpConstTime with negative exponent")
+	}
+	if m.bn().IsOdd() == 0 {
+		panic("math/big: ExpConstTime with even modulus")
+	}
+	ctx := ctxGet()
+	defer ctxPut(ctx)
+
+	mod := openssl.BNNew()
+	defer mod.Free()
+	mod.Copy(m.bn())
+	mod.SetNegative(0)
+	if mod.IsOne() != 0 {
+		return z.SetInt64(0)
+	}
+	base := openssl.BNNew()
+	defer base.ClearFree()
+	base.Nnmod(x.bn(), mod, ctx)
+	exp := openssl.BNNew()
+	defer exp.ClearFree()
+	exp.Copy(y.bn())
+	exp.SetFlags(openssl.BN_FLG_CONSTTIME)
+
+	// z may alias x, y or m, whose values were copied above.
+	if z.mut().ModExpMontConsttime(base, exp, mod, ctx, nil) == 0 {
+		panic(newError("BN_mod_exp_mont_consttime"))
+	}
+	return z
+}
+
+// maxExpWindow is the largest window of ExpWindow, whose table then holds
+// 2**maxExpWindow powers.
+const maxExpWindow = 12
+
+// ExpWindow sets z = x**y mod |m| and returns z, like Exp, scanning y in
+// fixed windows of window bits: after precomputing x**0 to x**(2**window-1),
+// each window costs window squarings and at most one multiplication, all in
+// Montgomery form. It is meant for comparing window sizes, which change the
+// running time but not the result. For reference, Exp uses the sliding window
+// of BN_mod_exp, which OpenSSL sizes from the length of y, up to 6 bits.
+//
+// Montgomery multiplication needs an odd modulus: for a nil, zero or even m,
+// a negative y, or a window outside [1, 12], ExpWindow is the same as Exp.
+// ExpWindow is an llgo extension with no counterpart in math/big.
+func (z *Int) ExpWindow(x, y, m *Int, window int) *Int {
+	if window < 1 || window > maxExpWindow || m == nil || m.bn().IsOdd() == 0 || y.Sign() < 0 {
+		return z.Exp(x, y, m)
+	}
+	ctx := ctxGet()
+	defer ctxPut(ctx)
+
+	mod := openssl.BNNew()
+	defer mod.Free()
+	mod.Copy(m.bn())
+	mod.SetNegative(0)
+	if mod.IsOne() != 0 {
+		return z.SetInt64(0)
+	}
+	mont := openssl.BN_MONT_CTXNew()
+	defer mont.Free()
+	if mont.Set(mod, ctx) == 0 {
+		panic(newError("BN_MONT_CTX_set"))
+	}
+
+	// table[i] is x**i * R mod m, R being the Montgomery radix.
+	table := make([]*openssl.BIGNUM, 1<<window)
+	for i := range table {
+		table[i] = openssl.BNNew()
+	}
+	defer func() {
+		for _, t := range table {
+			t.Free()
+		}
+	}()
+	t := openssl.BNNew()
+	defer t.Free()
+	t.SetWord(1)
+	table[0].ToMontgomery(t, mont, ctx)
+	t.Nnmod(x.bn(), mod, ctx)
+	table[1].ToMontgomery(t, mont, ctx)
+	for i := 2; i < len(table); i++ {
+		table[i].ModMulMontgomery(table[i-1], table[1], mont, ctx)
+	}
+
+	// z may alias x, y or m, which are only read before z is set.
+	exp := y.bn()
+	digit := func(k int) int {
+		d := 0
+		for j := window - 1; j >= 0; j-- {
+			d = d<<1 | int(exp.IsBitSet(c.Int(k*window+j)))
+		}
+		return d
+	}
+	nwin := (int(exp.NumBits()) + window - 1) / window
+	t.Copy(table[0])
+	if nwin > 0 {
+		t.Copy(table[digit(nwin-1)])
+	}
+	for k := nwin - 2; k >= 0; k-- {
+		for j := 0; j < window; j++ {
+			t.ModMulMontgomery(t, t, mont, ctx)
+		}
+		if d := digit(k); d != 0 {
+			t.ModMulMontgomery(t, table[d], mont, ctx)
+		}
+	}
+	z.mut().FromMontgomery(t, mont, ctx)
 	return z
 }
 
@@ -361,7 +790,95 @@ func (z *Int) Exp(x, y, m *Int) *Int {
 //
 // If a != 0 and b == 0, GCD sets z = |a|, x = sign(a) * 1, y = 0.
 func (z *Int) GCD(x, y, a, b *Int) *Int {
-	panic("todo big.GCD")
+	ctx := ctxGet()
+	defer ctxPut(ctx)
+	if x == nil && y == nil {
+		z.mut().Gcd(a.bn(), b.bn(), ctx)
+		return z
+	}
+	if a.Sign() == 0 || b.Sign() == 0 {
+		sa, sb := a.Sign(), b.Sign()
+		if sa == 0 {
+			z.Abs(b)
+		} else {
+			z.Abs(a)
+		}
+		if x != nil {
+			x.SetInt64(int64(sa))
+		}
+		if y != nil {
+			y.SetInt64(int64(sb))
+		}
+		return z
+	}
+
+	// The extended Euclidean algorithm on |a| and |b|, where ua is the
+	// cofactor of |a| in A. It goes through the same quotients as the Lehmer
+	// algorithm of math/big, so the cofactors are the same too.
+	A, B, ua, ub := openssl.BNNew(), openssl.BNNew(), openssl.BNNew(), openssl.BNNew()
+	q, r, t := openssl.BNNew(), openssl.BNNew(), openssl.BNNew()
+	defer func() {
+		for _, p := range [...]*openssl.BIGNUM{A, B, ua, ub, q, r, t} {
+			p.Free()
+		}
+	}()
+	A.Copy(a.bn())
+	A.SetNegative(0)
+	B.Copy(b.bn())
+	B.SetNegative(0)
+	ua.SetWord(1)
+	if A.Ucmp(B) < 0 {
+		A, B = B, A
+		ua, ub = ub, ua
+	}
+	for B.IsZero() == 0 {
+		q.Div(r, A, B, ctx)
+		A, B, r = B, r, A
+		t.Mul(q, ub, ctx)
+		t.Sub(ua, t)
+		ua, ub, t = ub, t, ua
+	}
+	if a.bn().IsNegative() != 0 {
+		ua.SetNegative(1 - ua.IsNegative())
+	}
+	if y != nil {
+		// y = (A - a*x) / b, which is exact. z, x and y may alias a and b,
+		// so they are only written once this is known.
+		q.Mul(a.bn(), ua, ctx)
+		r.Sub(A, q)
+		t.Div(nil, r, b.bn(), ctx)
+		y.mut().Copy(t)
+	}
+	if x != nil {
+		x.mut().Copy(ua)
+	}
+	z.mut().Copy(A)
+	return z
+}
+
+// LCM sets z to the least common multiple of x and y and returns z. The
+// result is never negative, and is 0 if x or y is 0. LCM is an llgo extension
+// with no counterpart in math/big.
+func (z *Int) LCM(x, y *Int) *Int {
+	a, b := x.bn(), y.bn()
+	if a.IsZero() != 0 || b.IsZero() != 0 {
+		return z.SetInt64(0)
+	}
+	ctx := ctxGet()
+	defer ctxPut(ctx)
+
+	// lcm(x, y) = |x / gcd(x, y) * y|, dividing first to keep the product small.
+	var g Int
+	defer g.Free()
+	g.GCD(nil, nil, x, y)
+	q := openssl.BNNew()
+	defer q.Free()
+	q.Div(nil, a, g.bn(), ctx)
+	// z may alias x or y, which aren't read after this.
+	r := z.mut()
+	r.Mul(q, b, ctx)
+	r.SetNegative(0)
+	return z
 }
 
 // Rand sets z to a pseudo-random number in [0, n) and returns z.
@@ -377,13 +894,60 @@ func (z *Int) Rand(rnd *rand.Rand, n *Int) *Int {
 // inverse in the ring ℤ/nℤ.  In this case, z is unchanged and the return value
 // is nil. If n == 0, a division-by-zero run-time panic occurs.
 func (z *Int) ModInverse(g, n *Int) *Int {
-	panic("todo big.ModInverse")
+	if n.Sign() == 0 {
+		// What math/big's GCD-based definition amounts to without a modulus.
+		if g.Sign() < 0 {
+			panic("division by zero")
+		}
+		if g.bn().IsOne() == 0 {
+			return nil
+		}
+		return z.SetInt64(1)
+	}
+	ctx := ctxGet()
+	defer ctxPut(ctx)
+	mod := openssl.BNNew()
+	defer mod.Free()
+	mod.Copy(n.bn())
+	mod.SetNegative(0)
+	if mod.IsOne() != 0 {
+		return z.SetInt64(0) // everything is 0 modulo 1
+	}
+
+	// Check coprimality up front rather than relying on BN_mod_inverse to
+	// fail: depending on the OpenSSL version and the inputs it reports a
+	// missing inverse as BN_R_NO_INVERSE on the error queue or otherwise.
+	inv := openssl.BNNew()
+	defer inv.Free()
+	inv.Gcd(g.bn(), mod, ctx)
+	if inv.IsOne() == 0 {
+		return nil
+	}
+	if inv.ModInverse(g.bn(), mod, ctx) == nil {
+		openssl.ERRClearError()
+		return nil
+	}
+	// z may alias g or n, so only write it once the result is known.
+	z.mut().Copy(inv)
+	return z
 }
 
 // Jacobi returns the Jacobi symbol (x/y), either +1, -1, or 0.
 // The y argument must be an odd integer.
 func Jacobi(x, y *Int) int {
-	panic("todo big.Jacobi")
+	if y.bn().IsOdd() == 0 {
+		panic(fmt.Sprintf("big: invalid 2nd argument to Int.Jacobi: need odd integer but got %s", y.String()))
+	}
+	// For an odd y, the Kronecker symbol is the Jacobi symbol, with the
+	// same sign rule for y < 0 as math/big: (x/y) == (x/|y|), negated for
+	// x < 0 and y < 0.
+	ctx := ctxGet()
+	j := x.bn().Kronecker(y.bn(), ctx)
+	ctxPut(ctx)
+	if j == -2 {
+		panic(newError("BN_kronecker"))
+	}
+	return int(j)
 }
 
 // ModSqrt sets z to a square root of x mod p if such a square root exists, and
@@ -396,24 +960,60 @@ func (z *Int) ModSqrt(x, p *Int) *Int {
 
 // Lsh sets z = x << n and returns z.
 func (z *Int) Lsh(x *Int, n uint) *Int {
-	a := (*openssl.BIGNUM)(z)
-	b := (*openssl.BIGNUM)(x)
-	a.Lshift(b, c.Int(n))
+	z.mut().Lshift(x.bn(), c.Int(n))
 	return z
 }
 
 // Rsh sets z = x >> n and returns z.
+//
+// Like Go's >> on signed integers, Rsh of a negative x rounds towards negative
+// infinity, while BN_rshift shifts the magnitude. Shifts by whole words are
+// done by BN_rshift moving words rather than bits.
 func (z *Int) Rsh(x *Int, n uint) *Int {
-	a := (*openssl.BIGNUM)(z)
-	b := (*openssl.BIGNUM)(x)
-	a.Rshift(b, c.Int(n))
+	neg := x.Sign() < 0
+	if n >= uint(x.BitLen()) {
+		// All bits are shifted out; avoids overflowing the C int shift count.
+		if neg {
+			return z.SetInt64(-1)
+		}
+		return z.SetInt64(0)
+	}
+	a := z.mut()
+	if neg {
+		// (-x) >> s == ^(x-1) >> s == ^((x-1) >> s) == -(((x-1) >> s) + 1)
+		a.Copy(x.bn())
+		a.SetNegative(0)
+		a.SubWord(1)
+		a.Rshift(a, c.Int(n))
+		a.AddWord(1)
+		a.SetNegative(1)
+		return z
+	}
+	a.Rshift(x.bn(), c.Int(n))
 	return z
 }
 
 // Bit returns the value of the i'th bit of x. That is, it
 // returns (x>>i)&1. The bit index i must be >= 0.
 func (x *Int) Bit(i int) uint {
-	panic("todo big.Bit")
+	if i < 0 {
+		panic("negative bit index")
+	}
+	a := x.bn()
+	neg := a.IsNegative() != 0
+	if i >= int(a.NumBits()) {
+		if neg {
+			return 1 // the sign extension of the two's complement
+		}
+		return 0
+	}
+	b := uint(a.IsBitSet(c.Int(i)))
+	// Negating |x| in two's complement keeps the bits up to its lowest 1
+	// and inverts those above it.
+	if neg && uint(i) > x.TrailingZeroBits() {
+		b ^= 1
+	}
+	return b
 }
 
 // SetBit sets z to x, with x's i'th bit set to b (0 or 1).
@@ -421,38 +1021,108 @@ func (x *Int) Bit(i int) uint {
 // if b is 0 SetBit sets z = x &^ (1 << i). If b is not 0 or 1,
 // SetBit will panic.
 func (z *Int) SetBit(x *Int, i int, b uint) *Int {
-	panic("todo big.SetBit")
+	if i < 0 {
+		panic("negative bit index")
+	}
+	if b > 1 {
+		panic("set bit is not 0 or 1")
+	}
+	z.Set(x)
+	a := z.mut()
+	// A negative z is set as -(t+1), with the bit of t = |z|-1 inverted:
+	// ^t is the two's complement of z.
+	neg := a.IsNegative() != 0
+	if neg {
+		a.SetNegative(0)
+		a.SubWord(1)
+		b ^= 1
+	}
+	switch {
+	case b == 0:
+		if i < int(a.NumBits()) {
+			a.ClearBit(c.Int(i))
+		}
+	case i > math.MaxInt32:
+		panic("math/big: bit index too large")
+	case a.SetBit(c.Int(i)) == 0:
+		panic(newError("BN_set_bit"))
+	}
+	if neg {
+		a.AddWord(1)
+		a.SetNegative(1)
+	}
+	return z
 }
 
 // And sets z = x & y and returns z.
 func (z *Int) And(x, y *Int) *Int {
-	panic("todo big.And")
+	return z.bitwise(x, y, bitAnd)
 }
 
 // AndNot sets z = x &^ y and returns z.
 func (z *Int) AndNot(x, y *Int) *Int {
-	panic("todo big.AndNot")
+	return z.bitwise(x, y, bitAndNot)
 }
 
 // Or sets z = x | y and returns z.
 func (z *Int) Or(x, y *Int) *Int {
-	panic("todo big.Or")
+	return z.bitwise(x, y, bitOr)
 }
 
 // Xor sets z = x ^ y and returns z.
 func (z *Int) Xor(x, y *Int) *Int {
-	panic("todo big.Xor")
+	return z.bitwise(x, y, bitXor)
 }
 
 // Not sets z = ^x and returns z.
 func (z *Int) Not(x *Int) *Int {
-	panic("todo big.Not")
+	// ^x == -x-1
+	a := z.mut()
+	a.Copy(x.bn())
+	if a.IsNegative() != 0 {
+		a.SetNegative(0)
+		a.SubWord(1)
+	} else {
+		a.AddWord(1)
+		a.SetNegative(1)
+	}
+	return z
 }
 
 // Sqrt sets z to ⌊√x⌋, the largest integer such that z² ≤ x, and returns z.
 // It panics if x is negative.
 func (z *Int) Sqrt(x *Int) *Int {
-	panic("todo big.Sqrt")
+	if x.Sign() < 0 {
+		panic("square root of negative number")
+	}
+	a := x.bn()
+	if a.IsZero() != 0 {
+		return z.SetInt64(0)
+	}
+	ctx := ctxGet()
+	defer ctxPut(ctx)
+
+	// Newton's method, as in math/big: starting from r = 2**⌈n/2⌉ >= √x,
+	// s = ⌊(r + ⌊x/r⌋) / 2⌋ decreases until it reaches ⌊√x⌋.
+	r, s := openssl.BNNew(), openssl.BNNew()
+	defer func() {
+		r.Free()
+		s.Free()
+	}()
+	r.SetWord(1)
+	r.Lshift(r, (a.NumBits()+1)/2)
+	for {
+		s.Div(nil, a, r, ctx)
+		s.Add(s, r)
+		s.Rshift1(s)
+		if s.Cmp(r) >= 0 {
+			break
+		}
+		r, s = s, r
+	}
+	// z may alias x, which isn't read after this.
+	z.mut().Copy(r)
+	return z
 }
 
 // -----------------------------------------------------------------------------
@@ -0,0 +1,969 @@
+//go:build gmp && !math_big_pure_go
+
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package big
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sync/atomic"
+	"unsafe"
+
+	c "github.com/goplus/llgo/runtime/internal/clite"
+	"github.com/goplus/llgo/runtime/internal/clite/gmp"
+)
+
+// llgo:skipall
+type _big struct{}
+
+// A Word represents a single digit of a multi-precision unsigned integer.
+// It is a GMP limb, an unsigned long on the LP64 targets llgo supports.
+type Word c.Ulong
+
+const (
+	_W = 64        // word size in bits
+	_M = 1<<_W - 1 // digit mask
+)
+
+// -----------------------------------------------------------------------------
+
+// An Int represents a signed multi-precision integer.
+// The zero value for an Int represents the value 0.
+//
+// Operations always take pointer arguments (*Int) rather
+// than Int values, and each unique Int value requires
+// its own unique *Int pointer. To "copy" an Int value,
+// an existing (or newly allocated) Int must be set to
+// a new value using the Int.Set method; shallow copies
+// of Ints are not supported and may lead to errors.
+type Int struct {
+	b   unsafe.Pointer // *mpzBox, allocated on first use, see box
+	gen uint64         // bumped by every mutation, see mut
+
+	text unsafe.Pointer // *intText cached by String
+}
+
+// An mpzBox owns the mpz_t of an Int. It is a separate heap object so that a
+// finalizer can clear the mpz_t once the box is unreachable, wherever the Int
+// itself lives (see box). Its z is only cleared after free.
+type mpzBox struct {
+	z       gmp.Int
+	cleared bool
+	secure  bool // see SetSecure
+}
+
+func newBox(secure bool) *mpzBox {
+	b := &mpzBox{secure: secure}
+	b.z.Init()
+	return b
+}
+
+// box returns the mpzBox of x, allocating it on first use so that the zero
+// value of Int is ready to use. Reading an Int is no write to it, so that
+// concurrent reads of a zero value are safe: the first box published wins
+// and the others are freed.
+func (x *Int) box() *mpzBox {
+	b := (*mpzBox)(atomic.LoadPointer(&x.b))
+	if b == nil {
+		b = newBox(false)
+		if !atomic.CompareAndSwapPointer(&x.b, nil, unsafe.Pointer(b)) {
+			b.free()
+			return (*mpzBox)(atomic.LoadPointer(&x.b))
+		}
+		runtime.SetFinalizer(b, (*mpzBox).free)
+	}
+	return b
+}
+
+// mpz returns the mpz_t holding x.
+func (x *Int) mpz() *gmp.Int {
+	return &x.box().z
+}
+
+// free clears the mpz_t of b, unless it was cleared already.
+func (b *mpzBox) free() {
+	if !b.cleared {
+		b.cleared = true
+		if b.secure {
+			b.z.Wipe()
+		}
+		b.z.Clear()
+	}
+}
+
+// Free releases the memory held by z right away instead of when z becomes
+// unreachable, and sets z to 0. It is useful for large values, or in
+// programs built without the garbage collector, which never run finalizers.
+// z must not be used concurrently with Free.
+//
+// A secure z is scrubbed before its memory is released, and stays secure.
+func (z *Int) Free() {
+	b := (*mpzBox)(atomic.LoadPointer(&z.b))
+	if b == nil {
+		return
+	}
+	var next unsafe.Pointer
+	if b.secure {
+		nb := newBox(true)
+		runtime.SetFinalizer(nb, (*mpzBox).free)
+		next = unsafe.Pointer(nb)
+	}
+	atomic.AddUint64(&z.gen, 1)
+	atomic.StorePointer(&z.b, next)
+	b.free()
+}
+
+// SetSecure marks z as holding a secret, such as a private key, or clears
+// the mark, and returns z. The value of z is unchanged.
+//
+// The limbs of a secure Int are zeroed before they are released, by Free or
+// once z is unreachable. GMP reallocates the limbs of an mpz_t as it grows
+// without zeroing the old ones, and has no constant-time mode for it: use
+// ExpConstTime for secret exponents. The mark belongs to z and not to its
+// value: it is kept when z is overwritten, e.g. by Set, and isn't passed on
+// to the results of operations that read z. String doesn't memoize the text
+// of a secure Int.
+func (z *Int) SetSecure(secure bool) *Int {
+	b := z.box()
+	if secure {
+		atomic.StorePointer(&z.text, nil)
+	}
+	b.secure = secure
+	return z
+}
+
+// IsSecure reports whether z was marked secure by SetSecure.
+func (x *Int) IsSecure() bool {
+	b := (*mpzBox)(atomic.LoadPointer(&x.b))
+	return b != nil && b.secure
+}
+
+// Zero sets z to 0 and returns z, overwriting all the limbs allocated for its
+// value, where SetInt64(0) only resets the length. It wipes a one-shot
+// secret, such as a DH shared secret, from an Int that isn't marked by
+// SetSecure, and keeps the memory for reuse, unlike Free. The secrecy of the
+// copies of the value made elsewhere, e.g. by Set or String, is up to the
+// caller; the text memoized by String, if any, is dropped.
+func (z *Int) Zero() *Int {
+	z.mut().Wipe()
+	atomic.StorePointer(&z.text, nil)
+	return z
+}
+
+// mut returns the mpz_t holding z for modification. Every method that
+// changes the value of z must use it, as it also invalidates state derived
+// from the value, such as the cached String text.
+func (z *Int) mut() *gmp.Int {
+	atomic.AddUint64(&z.gen, 1)
+	return z.mpz()
+}
+
+// bitLen returns the length of |a| in bits: mpz_sizeinbase counts 1 digit
+// for 0.
+func bitLen(a *gmp.Int) int {
+	if a.Sgn() == 0 {
+		return 0
+	}
+	return int(a.Sizeinbase(2))
+}
+
+// sign returns -1, 0 or +1 for the result r of an mpz comparison, which may
+// be any int.
+func sign(r c.Int) int {
+	switch {
+	case r < 0:
+		return -1
+	case r > 0:
+		return 1
+	}
+	return 0
+}
+
+// Sign returns:
+//
+//	-1 if x <  0
+//	 0 if x == 0
+//	+1 if x >  0
+func (x *Int) Sign() int {
+	return int(x.mpz().Sgn())
+}
+
+// SetInt64 sets z to x and returns z.
+func (z *Int) SetInt64(x int64) *Int {
+	z.mut().SetSi(c.Long(x))
+	return z
+}
+
+// SetUint64 sets z to x and returns z.
+func (z *Int) SetUint64(x uint64) *Int {
+	z.mut().SetUi(c.Ulong(x))
+	return z
+}
+
+// NewInt allocates and returns a new Int set to x.
+func NewInt(x int64) *Int {
+	return new(Int).SetInt64(x)
+}
+
+// Set sets z to x and returns z.
+func (z *Int) Set(x *Int) *Int {
+	if z != x {
+		z.mut().Set(x.mpz())
+	}
+	return z
+}
+
+// Abs sets z to |x| (the absolute value of x) and returns z.
+func (z *Int) Abs(x *Int) *Int {
+	z.mut().Abs(x.mpz())
+	return z
+}
+
+// Neg sets z to -x and returns z.
+func (z *Int) Neg(x *Int) *Int {
+	z.mut().Neg(x.mpz())
+	return z
+}
+
+// Bits provides raw (unchecked but fast) access to x by returning its
+// absolute value as a little-endian Word slice. The result and x share
+// the same underlying array.
+// Bits is intended to support implementation of missing low-level Int
+// functionality outside this package; it should be avoided otherwise.
+func (x *Int) Bits() []Word {
+	panic("todo big.Bits")
+}
+
+// SetBits provides raw (unchecked but fast) access to z by setting its
+// value to abs, interpreted as a little-endian Word slice, and returning
+// z. The result and abs share the same underlying array.
+// SetBits is intended to support implementation of missing low-level Int
+// functionality outside this package; it should be avoided otherwise.
+func (z *Int) SetBits(abs []Word) *Int {
+	panic("todo big.SetBits")
+}
+
+// Add sets z to the sum x+y and returns z.
+func (z *Int) Add(x, y *Int) *Int {
+	z.mut().Add(x.mpz(), y.mpz())
+	return z
+}
+
+// Sub sets z to the difference x-y and returns z.
+func (z *Int) Sub(x, y *Int) *Int {
+	z.mut().Sub(x.mpz(), y.mpz())
+	return z
+}
+
+// Mul sets z to the product x*y and returns z.
+//
+// Mul uses mpz_mul, which squares if x and y are the same Int and moves from
+// schoolbook to Karatsuba, Toom-Cook and FFT multiplication as the operands
+// grow, at thresholds tuned for the CPU when GMP is built.
+func (z *Int) Mul(x, y *Int) *Int {
+	z.mut().Mul(x.mpz(), y.mpz())
+	return z
+}
+
+// MulRange sets z to the product of all integers
+// in the range [a, b] inclusively and returns z.
+// If a > b (empty range), the result is 1.
+func (z *Int) MulRange(a, b int64) *Int {
+	panic("todo big.MulRange")
+}
+
+// Binomial sets z to the binomial coefficient C(n, k) and returns z.
+func (z *Int) Binomial(n, k int64) *Int {
+	panic("todo big.Binomial")
+}
+
+// Quo sets z to the quotient x/y for y != 0 and returns z.
+// If y == 0, a division-by-zero run-time panic occurs.
+// Quo implements truncated division (like Go); see QuoRem for more details.
+func (z *Int) Quo(x, y *Int) *Int {
+	quoRem(z, nil, x, y)
+	return z
+}
+
+// Rem sets z to the remainder x%y for y != 0 and returns z.
+// If y == 0, a division-by-zero run-time panic occurs.
+// Rem implements truncated modulus (like Go); see QuoRem for more details.
+func (z *Int) Rem(x, y *Int) *Int {
+	quoRem(nil, z, x, y)
+	return z
+}
+
+// QuoRem sets z to the quotient x/y and r to the remainder x%y
+// and returns the pair (z, r) for y != 0.
+// If y == 0, a division-by-zero run-time panic occurs.
+//
+// QuoRem implements T-division and modulus (like Go):
+//
+//	q = x/y      with the result truncated to zero
+//	r = x - y*q
+//
+// (See Daan Leijen, “Division and Modulus for Computer Scientists”.)
+// See DivMod for Euclidean division and modulus (unlike Go).
+//
+// The results are stored in z and r themselves, so that reusing them as
+// scratch values across calls, e.g. in a loop, doesn't allocate. Any of z,
+// r, x and y may be the same Int, except z and r.
+func (z *Int) QuoRem(x, y, r *Int) (*Int, *Int) {
+	quoRem(z, r, x, y)
+	return z, r
+}
+
+// quoRem sets q to the quotient and r to the remainder of the T-division x/y,
+// either of them may be nil. It panics like math/big if y == 0, which GMP
+// would turn into a SIGFPE.
+func quoRem(q, r, x, y *Int) {
+	if y.Sign() == 0 {
+		panic("division by zero")
+	}
+	switch {
+	case r == nil:
+		q.mut().TdivQ(x.mpz(), y.mpz())
+	case q == nil:
+		r.mut().TdivR(x.mpz(), y.mpz())
+	default:
+		q.mut().TdivQr(r.mut(), x.mpz(), y.mpz())
+	}
+}
+
+// Div sets z to the quotient x/y for y != 0 and returns z.
+// If y == 0, a division-by-zero run-time panic occurs.
+// Div implements Euclidean division (unlike Go); see DivMod for more details.
+func (z *Int) Div(x, y *Int) *Int {
+	var m Int
+	z.DivMod(x, y, &m)
+	return z
+}
+
+// Mod sets z to the modulus x%y for y != 0 and returns z.
+// If y == 0, a division-by-zero run-time panic occurs.
+// Mod implements Euclidean modulus (unlike Go); see DivMod for more details.
+func (z *Int) Mod(x, y *Int) *Int {
+	if y.Sign() == 0 {
+		panic("division by zero")
+	}
+	z.mut().Mod(x.mpz(), y.mpz()) // 0 <= z < |y|
+	return z
+}
+
+// DivMod sets z to the quotient x div y and m to the modulus x mod y
+// and returns the pair (z, m) for y != 0.
+// If y == 0, a division-by-zero run-time panic occurs.
+//
+// DivMod implements Euclidean division and modulus (unlike Go):
+//
+//	q = x div y  such that
+//	m = x - y*q  with 0 <= m < |y|
+//
+// (See Raymond T. Boute, “The Euclidean definition of the functions
+// div and mod”. ACM Transactions on Programming Languages and
+// Systems (TOPLAS), 14(2):127-144, New York, NY, USA, 4/1992.
+// ACM press.)
+// See QuoRem for T-division and modulus (like Go).
+func (z *Int) DivMod(x, y, m *Int) (*Int, *Int) {
+	y0 := y // save y
+	if z == y || m == y {
+		y0 = new(Int).Set(y)
+	}
+	z.QuoRem(x, y, m)
+	if m.Sign() < 0 {
+		if y0.Sign() > 0 {
+			a := z.mut()
+			a.SubUi(a, 1)
+			m.Add(m, y0)
+		} else {
+			a := z.mut()
+			a.AddUi(a, 1)
+			m.Sub(m, y0)
+		}
+	}
+	return z, m
+}
+
+// Cmp compares x and y and returns:
+//
+//	-1 if x <  y
+//	 0 if x == y
+//	+1 if x >  y
+func (x *Int) Cmp(y *Int) (r int) {
+	return sign(x.mpz().Cmp(y.mpz()))
+}
+
+// CmpAbs compares the absolute values of x and y and returns:
+//
+//	-1 if |x| <  |y|
+//	 0 if |x| == |y|
+//	+1 if |x| >  |y|
+func (x *Int) CmpAbs(y *Int) int {
+	return sign(x.mpz().Cmpabs(y.mpz()))
+}
+
+// Int64 returns the int64 representation of x.
+// If x cannot be represented in an int64, the result is undefined.
+func (x *Int) Int64() int64 {
+	a := x.mpz()
+	v := int64(a.GetUi()) // the low word of |x|
+	if a.Sgn() < 0 {
+		v = -v
+	}
+	return v
+}
+
+// Uint64 returns the uint64 representation of x.
+// If x cannot be represented in a uint64, the result is undefined.
+func (x *Int) Uint64() uint64 {
+	return uint64(x.mpz().GetUi())
+}
+
+// IsInt64 reports whether x can be represented as an int64.
+func (x *Int) IsInt64() bool {
+	n := x.BitLen()
+	return n < 64 || n == 64 && x.Sign() < 0 && x.TrailingZeroBits() == 63 // -1 << 63
+}
+
+// IsUint64 reports whether x can be represented as a uint64.
+func (x *Int) IsUint64() bool {
+	return x.Sign() >= 0 && x.BitLen() <= 64
+}
+
+// Float64 returns the float64 value nearest x,
+// and an indication of any rounding that occurred.
+// TODO(xsw):
+/*
+func (x *Int) Float64() (float64, Accuracy) {
+	panic("todo big.Float64")
+}*/
+
+// SetBytes interprets buf as the bytes of a big-endian unsigned
+// integer, sets z to that value, and returns z.
+func (z *Int) SetBytes(buf []byte) *Int {
+	z.mut().Import(uintptr(len(buf)), 1, 1, 1, 0, c.Pointer(unsafe.SliceData(buf)))
+	return z
+}
+
+// Bytes returns the absolute value of x as a big-endian byte slice.
+//
+// To use a fixed length slice, or a preallocated one, use FillBytes.
+func (x *Int) Bytes() []byte {
+	buf := make([]byte, (x.BitLen()+7)/8)
+	exportBytes(buf, x.mpz())
+	return buf
+}
+
+// exportBytes writes |a| to buf, big-endian. buf must be exactly as long as
+// the bytes of |a|.
+func exportBytes(buf []byte, a *gmp.Int) {
+	if len(buf) > 0 {
+		var n uintptr
+		gmp.Export(c.Pointer(unsafe.SliceData(buf)), &n, 1, 1, 1, 0, a)
+	}
+}
+
+// FillBytes sets buf to the absolute value of x, storing it as a zero-extended
+// big-endian byte slice, and returns buf.
+//
+// If the absolute value of x doesn't fit in buf, FillBytes will panic.
+func (x *Int) FillBytes(buf []byte) []byte {
+	n := (x.BitLen() + 7) / 8
+	if n > len(buf) {
+		panic("math/big: buffer too small to fit value")
+	}
+	k := len(buf) - n
+	for i := range buf[:k] {
+		buf[i] = 0
+	}
+	exportBytes(buf[k:], x.mpz())
+	return buf
+}
+
+// FillBytesSigned sets buf to the two's complement encoding of x, storing it
+// as a sign-extended big-endian byte slice, and returns buf. It is the
+// fixed-width counterpart of FillBytes for signed fields: -1 fills buf with
+// 0xff bytes.
+//
+// If x doesn't fit in buf, that is, x is outside [-2**(n-1), 2**(n-1)) for
+// the n = 8*len(buf) bits of buf, FillBytesSigned will panic.
+func (x *Int) FillBytesSigned(buf []byte) []byte {
+	if x.Sign() >= 0 {
+		if x.Sign() > 0 && x.BitLen() >= 8*len(buf) {
+			panic("math/big: buffer too small to fit value")
+		}
+		return x.FillBytes(buf)
+	}
+	// -|x| == ^(|x|-1)
+	var t gmp.Int
+	t.Init()
+	defer t.Clear()
+	t.Abs(x.mpz())
+	t.SubUi(&t, 1)
+	if bitLen(&t) >= 8*len(buf) {
+		panic("math/big: buffer too small to fit value")
+	}
+	n := (bitLen(&t) + 7) / 8
+	k := len(buf) - n
+	for i := range buf[:k] {
+		buf[i] = 0
+	}
+	exportBytes(buf[k:], &t)
+	for i := range buf {
+		buf[i] = ^buf[i]
+	}
+	return buf
+}
+
+// Bytes32 returns the absolute value of x as a zero-extended big-endian
+// 32-byte array, the fixed-size encoding of a 256-bit field element or
+// scalar. Bytes32 and the other fixed-size encodings below are llgo
+// extensions with no counterpart in math/big.
+//
+// If the absolute value of x doesn't fit in 32 bytes, Bytes32 will panic.
+func (x *Int) Bytes32() (buf [32]byte) {
+	x.FillBytes(buf[:])
+	return
+}
+
+// Bytes64 is like Bytes32 for a 64-byte array, such as the concatenated
+// coordinates of a point on a 256-bit curve.
+//
+// If the absolute value of x doesn't fit in 64 bytes, Bytes64 will panic.
+func (x *Int) Bytes64() (buf [64]byte) {
+	x.FillBytes(buf[:])
+	return
+}
+
+// SetBytes32 interprets b as the bytes of a big-endian unsigned integer,
+// sets z to that value, and returns z. It is the inverse of Bytes32.
+func (z *Int) SetBytes32(b [32]byte) *Int {
+	return z.SetBytes(b[:])
+}
+
+// SetBytes64 is like SetBytes32 for a 64-byte array, the inverse of Bytes64.
+func (z *Int) SetBytes64(b [64]byte) *Int {
+	return z.SetBytes(b[:])
+}
+
+// Key returns a compact binary encoding of x for use as a map[string] key:
+// a sign byte followed by the minimal big-endian bytes of |x|. x and y have
+// the same Key if and only if x.Cmp(y) == 0; in particular there is a single
+// key for 0, whatever the sign it was computed with.
+func (x *Int) Key() string {
+	buf := make([]byte, 1+(x.BitLen()+7)/8)
+	buf[0] = byte(x.Sign() + 1) // 0, 1 or 2
+	exportBytes(buf[1:], x.mpz())
+	return string(buf)
+}
+
+// BitLen returns the length of the absolute value of x in bits.
+// The bit length of 0 is 0.
+func (x *Int) BitLen() int {
+	return bitLen(x.mpz())
+}
+
+// TrailingZeroBits returns the number of consecutive least significant zero
+// bits of |x|.
+func (x *Int) TrailingZeroBits() uint {
+	a := x.mpz()
+	if a.Sgn() == 0 {
+		return 0
+	}
+	return uint(a.Scan1(0)) // the same for x and -x in two's complement
+}
+
+// Exp sets z = x**y mod |m| (i.e. the sign of m is ignored), and returns z.
+// If m == nil or m == 0, z = x**y unless y <= 0 then z = 1. If m != 0, y < 0,
+// and x and m are not relatively prime, z is unchanged and nil is returned.
+//
+// Modular exponentiation of inputs of a particular size is not a
+// cryptographically constant-time operation.
+func (z *Int) Exp(x, y, m *Int) *Int {
+	if m != nil && m.Sign() == 0 {
+		m = nil
+	}
+	if m == nil && y.Sign() <= 0 {
+		return z.SetInt64(1)
+	}
+	if m == nil {
+		e := y.mpz().GetUi()
+		if y.BitLen() > _W {
+			// GetUi truncates y, and only |x| <= 1 has a power this large
+			// that fits in memory: for them, keep the parity of y.
+			if x.BitLen() > 1 {
+				panic("math/big: Exp result too large")
+			}
+			e = 2 - e&1
+		}
+		z.mut().PowUi(x.mpz(), e)
+		return z
+	}
+
+	// Work modulo |m| with the base reduced into [0, |m|), which makes the
+	// result non-negative as well, like math/big's.
+	var mod, base, exp gmp.Int
+	mod.Init()
+	defer mod.Clear()
+	mod.Abs(m.mpz())
+	if mod.CmpUi(1) == 0 {
+		return z.SetInt64(0) // mpz_invert has no inverse modulo 1
+	}
+	base.Init()
+	defer base.Clear()
+	base.Mod(x.mpz(), &mod)
+	exp.InitSet(y.mpz())
+	defer exp.Clear()
+	negX, negY := x.Sign() < 0, exp.Sgn() < 0
+	if negY {
+		// x**y == (x**-1)**|y| for x and m relatively prime
+		if base.Invert(&base, &mod) == 0 {
+			return nil
+		}
+		exp.Abs(&exp)
+	}
+	// z may alias x, y or m, whose values were copied above.
+	a := z.mut()
+	a.Powm(&base, &exp, &mod)
+	if negX && negY && exp.Tstbit(0) != 0 && a.Sgn() != 0 {
+		// math/big applies the sign of x to the result again even though the
+		// inverse already accounts for it; match it for identical results.
+		a.Sub(&mod, a)
+	}
+	return z
+}
+
+// ExpConstTime sets z = x**y mod |m| and returns z, like Exp, for a secret
+// exponent y: it uses mpz_powm_sec, whose sequence of operations and memory
+// accesses, and hence the running time, depend on the sizes of x, y and m but
+// not on the value of y. Nothing is promised for the values of x and m.
+//
+// y must not be negative and m must be odd, as required by mpz_powm_sec;
+// ExpConstTime panics otherwise. Use Exp when y isn't secret, which is
+// faster.
+func (z *Int) ExpConstTime(x, y, m *Int) *Int {
+	if y.Sign() < 0 {
+		panic("math/big: ExpConstTime with negative exponent")
+	}
+	if m.mpz().Tstbit(0) == 0 {
+		panic("math/big: ExpConstTime with even modulus")
+	}
+	var mod, base gmp.Int
+	mod.Init()
+	defer mod.Clear()
+	mod.Abs(m.mpz())
+	if mod.CmpUi(1) == 0 {
+		return z.SetInt64(0)
+	}
+	if y.Sign() == 0 {
+		return z.SetInt64(1) // mpz_powm_sec needs y > 0
+	}
+	base.Init()
+	defer func() {
+		base.Wipe()
+		base.Clear()
+	}()
+	base.Mod(x.mpz(), &mod)
+
+	// z may alias y, which mpz_powm_sec reads to the end: write a copy.
+	var r gmp.Int
+	r.Init()
+	r.PowmSec(&base, y.mpz(), &mod)
+	a := z.mut()
+	a.Swap(&r)
+	r.Wipe()
+	r.Clear()
+	return z
+}
+
+// maxExpWindow is the largest window of ExpWindow, whose table then holds
+// 2**maxExpWindow powers.
+const maxExpWindow = 12
+
+// ExpWindow sets z = x**y mod |m| and returns z, like Exp, scanning y in
+// fixed windows of window bits: after precomputing x**0 to x**(2**window-1),
+// each window costs window squarings and at most one multiplication, each
+// reduced by mpz_mod. It is meant for comparing window sizes, which change
+// the running time but not the result. For reference, Exp uses the sliding
+// window of mpz_powm, which GMP sizes from the length of y.
+//
+// For a nil, zero or even m, a negative y, or a window outside [1, 12],
+// ExpWindow is the same as Exp, as with the OpenSSL backend, which needs an
+// odd modulus for Montgomery multiplication. ExpWindow is an llgo extension
+// with no counterpart in math/big.
+func (z *Int) ExpWindow(x, y, m *Int, window int) *Int {
+	if window < 1 || window > maxExpWindow || m == nil || m.mpz().Tstbit(0) == 0 || y.Sign() < 0 {
+		return z.Exp(x, y, m)
+	}
+	var mod, t gmp.Int
+	mod.Init()
+	defer mod.Clear()
+	mod.Abs(m.mpz())
+	if mod.CmpUi(1) == 0 {
+		return z.SetInt64(0)
+	}
+
+	// table[i] is x**i mod m.
+	table := make([]gmp.Int, 1<<window)
+	for i := range table {
+		table[i].Init()
+	}
+	mulMod := func(r, a, b *gmp.Int) {
+		r.Mul(a, b)
+		r.Mod(r, &mod)
+	}
+	defer func() {
+		for i := range table {
+			table[i].Clear()
+		}
+	}()
+	table[0].SetUi(1)
+	table[1].Mod(x.mpz(), &mod)
+	for i := 2; i < len(table); i++ {
+		mulMod(&table[i], &table[i-1], &table[1])
+	}
+
+	// z may alias x, y or m, which are only read before z is set.
+	exp := y.mpz()
+	digit := func(k int) int {
+		d := 0
+		for j := window - 1; j >= 0; j-- {
+			d = d<<1 | int(exp.Tstbit(c.Ulong(k*window+j)))
+		}
+		return d
+	}
+	nwin := (bitLen(exp) + window - 1) / window
+	t.Init()
+	defer t.Clear()
+	t.Set(&table[0])
+	if nwin > 0 {
+		t.Set(&table[digit(nwin-1)])
+	}
+	for k := nwin - 2; k >= 0; k-- {
+		for j := 0; j < window; j++ {
+			mulMod(&t, &t, &t)
+		}
+		if d := digit(k); d != 0 {
+			mulMod(&t, &t, &table[d])
+		}
+	}
+	z.mut().Swap(&t)
+	return z
+}
+
+// GCD sets z to the greatest common divisor of a and b and returns z.
+// If x or y are not nil, GCD sets their value such that z = a*x + b*y.
+//
+// a and b may be positive, zero or negative. (Before Go 1.14 both had
+// to be > 0.) Regardless of the signs of a and b, z is always >= 0.
+//
+// If a == b == 0, GCD sets z = x = y = 0.
+//
+// If a == 0 and b != 0, GCD sets z = |b|, x = 0, y = sign(b) * 1.
+//
+// If a != 0 and b == 0, GCD sets z = |a|, x = sign(a) * 1, y = 0.
+func (z *Int) GCD(x, y, a, b *Int) *Int {
+	if x == nil && y == nil {
+		z.mut().Gcd(a.mpz(), b.mpz())
+		return z
+	}
+	// z, x and y may alias a and b and must be distinct for mpz_gcdext:
+	// compute into temporaries and move the results.
+	var g, s, t gmp.Int
+	g.Init()
+	s.Init()
+	t.Init()
+	g.Gcdext(&s, &t, a.mpz(), b.mpz())
+	z.mut().Swap(&g)
+	if x != nil {
+		x.mut().Swap(&s)
+	}
+	if y != nil {
+		y.mut().Swap(&t)
+	}
+	g.Clear()
+	s.Clear()
+	t.Clear()
+	return z
+}
+
+// LCM sets z to the least common multiple of x and y and returns z. The
+// result is never negative, and is 0 if x or y is 0. LCM is an llgo extension
+// with no counterpart in math/big.
+func (z *Int) LCM(x, y *Int) *Int {
+	z.mut().Lcm(x.mpz(), y.mpz())
+	return z
+}
+
+// Rand sets z to a pseudo-random number in [0, n) and returns z.
+//
+// As this uses the math/rand package, it must not be used for
+// security-sensitive work. Use crypto/rand.Int instead.
+func (z *Int) Rand(rnd *rand.Rand, n *Int) *Int {
+	panic("todo big.Rand")
+}
+
+// ModInverse sets z to the multiplicative inverse of g in the ring ℤ/nℤ
+// and returns z. If g and n are not relatively prime, g has no multiplicative
+// inverse in the ring ℤ/nℤ.  In this case, z is unchanged and the return value
+// is nil. If n == 0, a division-by-zero run-time panic occurs.
+func (z *Int) ModInverse(g, n *Int) *Int {
+	if n.Sign() == 0 {
+		// What math/big's GCD-based definition amounts to without a modulus.
+		if g.Sign() < 0 {
+			panic("division by zero")
+		}
+		if g.mpz().CmpUi(1) != 0 {
+			return nil
+		}
+		return z.SetInt64(1)
+	}
+	var mod, inv gmp.Int
+	mod.Init()
+	defer mod.Clear()
+	mod.Abs(n.mpz())
+	if mod.CmpUi(1) == 0 {
+		return z.SetInt64(0) // everything is 0 modulo 1
+	}
+	inv.Init()
+	defer inv.Clear()
+	if inv.Invert(g.mpz(), &mod) == 0 {
+		return nil
+	}
+	// z may alias g or n, so only write it once the result is known.
+	z.mut().Swap(&inv)
+	return z
+}
+
+// Jacobi returns the Jacobi symbol (x/y), either +1, -1, or 0.
+// The y argument must be an odd integer.
+func Jacobi(x, y *Int) int {
+	if y.mpz().Tstbit(0) == 0 {
+		panic(fmt.Sprintf("big: invalid 2nd argument to Int.Jacobi: need odd integer but got %s", y.String()))
+	}
+	// mpz_jacobi is only defined for y > 0; (x/y) == (x/|y|), negated for
+	// x < 0 and y < 0, as in math/big.
+	var b gmp.Int
+	b.Init()
+	defer b.Clear()
+	b.Abs(y.mpz())
+	j := int(x.mpz().Jacobi(&b))
+	if x.Sign() < 0 && y.Sign() < 0 {
+		j = -j
+	}
+	return j
+}
+
+// ModSqrt sets z to a square root of x mod p if such a square root exists, and
+// returns z. The modulus p must be an odd prime. If x is not a square mod p,
+// ModSqrt leaves z unchanged and returns nil. This function panics if p is
+// not an odd integer, its behavior is undefined if p is odd but not prime.
+func (z *Int) ModSqrt(x, p *Int) *Int {
+	panic("todo big.ModSqrt")
+}
+
+// Lsh sets z = x << n and returns z.
+func (z *Int) Lsh(x *Int, n uint) *Int {
+	z.mut().Mul2exp(x.mpz(), c.Ulong(n))
+	return z
+}
+
+// Rsh sets z = x >> n and returns z.
+//
+// Like Go's >> on signed integers, Rsh of a negative x rounds towards negative
+// infinity, which is mpz_fdiv_q_2exp.
+func (z *Int) Rsh(x *Int, n uint) *Int {
+	z.mut().FdivQ2exp(x.mpz(), c.Ulong(n))
+	return z
+}
+
+// Bit returns the value of the i'th bit of x. That is, it
+// returns (x>>i)&1. The bit index i must be >= 0.
+func (x *Int) Bit(i int) uint {
+	if i < 0 {
+		panic("negative bit index")
+	}
+	return uint(x.mpz().Tstbit(c.Ulong(i))) // in two's complement for x < 0
+}
+
+// SetBit sets z to x, with x's i'th bit set to b (0 or 1).
+// That is, if b is 1 SetBit sets z = x | (1 << i);
+// if b is 0 SetBit sets z = x &^ (1 << i). If b is not 0 or 1,
+// SetBit will panic.
+func (z *Int) SetBit(x *Int, i int, b uint) *Int {
+	if i < 0 {
+		panic("negative bit index")
+	}
+	z.Set(x)
+	switch b {
+	case 0:
+		z.mut().Clrbit(c.Ulong(i))
+	case 1:
+		z.mut().Setbit(c.Ulong(i))
+	default:
+		panic("set bit is not 0 or 1")
+	}
+	return z
+}
+
+// And sets z = x & y and returns z.
+func (z *Int) And(x, y *Int) *Int {
+	z.mut().And(x.mpz(), y.mpz())
+	return z
+}
+
+// AndNot sets z = x &^ y and returns z.
+func (z *Int) AndNot(x, y *Int) *Int {
+	var t gmp.Int
+	t.Init()
+	t.Com(y.mpz())
+	z.mut().And(x.mpz(), &t)
+	t.Clear()
+	return z
+}
+
+// Or sets z = x | y and returns z.
+func (z *Int) Or(x, y *Int) *Int {
+	z.mut().Ior(x.mpz(), y.mpz())
+	return z
+}
+
+// Xor sets z = x ^ y and returns z.
+func (z *Int) Xor(x, y *Int) *Int {
+	z.mut().Xor(x.mpz(), y.mpz())
+	return z
+}
+
+// Not sets z = ^x and returns z.
+func (z *Int) Not(x *Int) *Int {
+	z.mut().Com(x.mpz())
+	return z
+}
+
+// Sqrt sets z to ⌊√x⌋, the largest integer such that z² ≤ x, and returns z.
+// It panics if x is negative.
+func (z *Int) Sqrt(x *Int) *Int {
+	if x.Sign() < 0 {
+		panic("square root of negative number")
+	}
+	z.mut().Sqrt(x.mpz())
+	return z
+}
+
+// -----------------------------------------------------------------------------
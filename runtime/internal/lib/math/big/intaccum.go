@@ -0,0 +1,46 @@
+//go:build !math_big_pure_go && !gmp
+
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package big
+
+import "github.com/goplus/llgo/runtime/internal/clite/openssl"
+
+// An Accumulator computes the exact sum of a stream of int64 values, which
+// would overflow an int64 total. The zero value is an empty sum, ready to
+// use.
+//
+// Adding a value updates the sum in place with a single word operation, so
+// an Accumulator doesn't allocate per value like summing with Int.Add and
+// NewInt would.
+type Accumulator struct {
+	sum Int
+}
+
+// AddInt64 adds v to the sum.
+func (a *Accumulator) AddInt64(v int64) {
+	if v >= 0 {
+		a.sum.mut().AddWord(openssl.BN_ULONG(v))
+	} else {
+		a.sum.mut().SubWord(openssl.BN_ULONG(-v)) // -v wraps around for MinInt64, as wanted
+	}
+}
+
+// Sum returns the sum of the values added so far, as a new Int.
+func (a *Accumulator) Sum() *Int {
+	return new(Int).Set(&a.sum)
+}
@@ -0,0 +1,159 @@
+//go:build !math_big_pure_go && !gmp
+
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package big
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/goplus/llgo/runtime/internal/clite/openssl"
+)
+
+// ExpModBatch returns a slice r with r[i] = bases[i]**exp mod |mod|, the
+// same results as calling Exp for each base, but sharing the work that only
+// depends on the modulus.
+//
+// For an odd modulus and a positive exponent the Montgomery context of mod
+// is computed once and shared by all exponentiations, which only read it.
+// The bases are split among up to GOMAXPROCS goroutines, each with its own
+// BN_CTX, since a BN_CTX must not be used by more than one thread at a time.
+// Other inputs fall back to calling Exp for each base.
+func ExpModBatch(bases []*Int, exp, mod *Int) []*Int {
+	ret := make([]*Int, len(bases))
+	if mod == nil || mod.bn().IsOdd() == 0 || exp.Sign() <= 0 {
+		for i, x := range bases {
+			ret[i] = new(Int).Exp(x, exp, mod)
+		}
+		return ret
+	}
+	m := new(Int).Abs(mod)
+	ctx := ctxGet()
+	mont := openssl.BN_MONT_CTXNew()
+	mont.Set(m.bn(), ctx)
+	ctxPut(ctx)
+	defer mont.Free()
+
+	// Allocate the BIGNUMs of zero-valued bases up front, so that the
+	// goroutines below only ever read shared Ints.
+	for _, x := range bases {
+		x.bn()
+	}
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(bases) {
+		workers = len(bases)
+	}
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			ctx := ctxGet()
+			for i := w; i < len(bases); i += workers {
+				z := new(Int)
+				z.mut().ModExpMont(bases[i].bn(), exp.bn(), m.bn(), ctx, mont)
+				ret[i] = z
+			}
+			ctxPut(ctx)
+		}(w)
+	}
+	wg.Wait()
+	return ret
+}
+
+// BatchModInverse returns a slice r with r[i] the inverse of xs[i] modulo |m|,
+// the same results as ModInverse, in [0, |m|). It uses Montgomery's trick: the
+// products of the prefixes of xs are inverted with a single ModInverse, from
+// which 3(n-1) multiplications recover the n inverses.
+//
+// An error is returned if m is 0, or if any of xs has no inverse, i.e. isn't
+// relatively prime to m; the error names the first such element.
+func BatchModInverse(xs []*Int, m *Int) ([]*Int, error) {
+	if m == nil || m.Sign() == 0 {
+		return nil, errors.New("math/big: BatchModInverse: zero modulus")
+	}
+	ret := make([]*Int, len(xs))
+	if len(xs) == 0 {
+		return ret, nil
+	}
+	ctx := ctxGet()
+	defer ctxPut(ctx)
+	mod := openssl.BNNew()
+	defer mod.Free()
+	mod.Copy(m.bn())
+	mod.SetNegative(0)
+	a, t := openssl.BNNew(), openssl.BNNew()
+	defer a.Free()
+	defer t.Free()
+	mulMod := func(z, x, y *openssl.BIGNUM) {
+		t.Mul(x, y, ctx)
+		z.Nnmod(t, mod, ctx)
+	}
+
+	// ret[i] is first the product of xs[0] to xs[i] mod m.
+	for i, x := range xs {
+		ret[i] = new(Int)
+		p := ret[i].mut()
+		if i == 0 {
+			p.Nnmod(x.bn(), mod, ctx)
+		} else {
+			a.Nnmod(x.bn(), mod, ctx)
+			mulMod(p, ret[i-1].bn(), a)
+		}
+	}
+
+	inv := openssl.BNNew()
+	defer inv.Free()
+	if mod.IsOne() != 0 {
+		inv.SetWord(0) // everything is 0 modulo 1
+	} else if inv.ModInverse(ret[len(xs)-1].bn(), mod, ctx) == nil {
+		// The product has no inverse iff one of its factors has none.
+		if err := noInverse(xs, mod, ctx); err != nil {
+			openssl.ERRClearError()
+			return nil, err
+		}
+		panic(newError("BN_mod_inverse"))
+	}
+
+	// With inv the inverse of the product up to xs[i], the inverse of xs[i]
+	// is inv times the product up to xs[i-1], and dividing out xs[i] leaves
+	// the inverse of the product up to xs[i-1].
+	for i := len(xs) - 1; i > 0; i-- {
+		mulMod(ret[i].mut(), inv, ret[i-1].bn())
+		a.Nnmod(xs[i].bn(), mod, ctx)
+		mulMod(inv, inv, a)
+	}
+	ret[0].mut().Copy(inv)
+	return ret, nil
+}
+
+// noInverse returns the error of BatchModInverse for the first of xs that has
+// no inverse modulo mod, or nil if they all have one.
+func noInverse(xs []*Int, mod *openssl.BIGNUM, ctx *openssl.BN_CTX) error {
+	g := openssl.BNNew()
+	defer g.Free()
+	for i, x := range xs {
+		if g.Gcd(x.bn(), mod, ctx); g.IsOne() == 0 {
+			return fmt.Errorf("math/big: BatchModInverse: element %d has no inverse modulo m", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+//go:build gmp && !math_big_pure_go
+
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package big
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/goplus/llgo/runtime/internal/clite/gmp"
+)
+
+// ExpModBatch returns a slice r with r[i] = bases[i]**exp mod |mod|, the
+// same results as calling Exp for each base.
+//
+// For an odd modulus and a positive exponent the bases are split among up to
+// GOMAXPROCS goroutines, as with the OpenSSL backend; GMP has no modulus
+// context to share, so each exponentiation is a separate mpz_powm. Other
+// inputs fall back to calling Exp for each base in turn.
+func ExpModBatch(bases []*Int, exp, mod *Int) []*Int {
+	ret := make([]*Int, len(bases))
+	if mod == nil || mod.mpz().Tstbit(0) == 0 || exp.Sign() <= 0 {
+		for i, x := range bases {
+			ret[i] = new(Int).Exp(x, exp, mod)
+		}
+		return ret
+	}
+	m := new(Int).Abs(mod)
+
+	// Allocate the mpz_t of zero-valued bases up front, so that the
+	// goroutines below only ever read shared Ints.
+	for _, x := range bases {
+		x.mpz()
+	}
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(bases) {
+		workers = len(bases)
+	}
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < len(bases); i += workers {
+				z := new(Int)
+				z.mut().Powm(bases[i].mpz(), exp.mpz(), m.mpz())
+				ret[i] = z
+			}
+		}(w)
+	}
+	wg.Wait()
+	return ret
+}
+
+// BatchModInverse returns a slice r with r[i] the inverse of xs[i] modulo |m|,
+// the same results as ModInverse, in [0, |m|). It uses Montgomery's trick: the
+// products of the prefixes of xs are inverted with a single ModInverse, from
+// which 3(n-1) multiplications recover the n inverses.
+//
+// An error is returned if m is 0, or if any of xs has no inverse, i.e. isn't
+// relatively prime to m; the error names the first such element.
+func BatchModInverse(xs []*Int, m *Int) ([]*Int, error) {
+	if m == nil || m.Sign() == 0 {
+		return nil, errors.New("math/big: BatchModInverse: zero modulus")
+	}
+	ret := make([]*Int, len(xs))
+	if len(xs) == 0 {
+		return ret, nil
+	}
+	var mod, a, inv gmp.Int
+	mod.Init()
+	a.Init()
+	inv.Init()
+	defer mod.Clear()
+	defer a.Clear()
+	defer inv.Clear()
+	mod.Abs(m.mpz())
+	mulMod := func(z, x, y *gmp.Int) {
+		z.Mul(x, y)
+		z.Mod(z, &mod)
+	}
+
+	// ret[i] is first the product of xs[0] to xs[i] mod m.
+	for i, x := range xs {
+		ret[i] = new(Int)
+		p := ret[i].mut()
+		if i == 0 {
+			p.Mod(x.mpz(), &mod)
+		} else {
+			a.Mod(x.mpz(), &mod)
+			mulMod(p, ret[i-1].mpz(), &a)
+		}
+	}
+
+	if mod.CmpUi(1) == 0 {
+		inv.SetUi(0) // everything is 0 modulo 1
+	} else if inv.Invert(ret[len(xs)-1].mpz(), &mod) == 0 {
+		// The product has no inverse iff one of its factors has none.
+		return nil, noInverse(xs, &mod)
+	}
+
+	// With inv the inverse of the product up to xs[i], the inverse of xs[i]
+	// is inv times the product up to xs[i-1], and dividing out xs[i] leaves
+	// the inverse of the product up to xs[i-1].
+	for i := len(xs) - 1; i > 0; i-- {
+		mulMod(ret[i].mut(), &inv, ret[i-1].mpz())
+		a.Mod(xs[i].mpz(), &mod)
+		mulMod(&inv, &inv, &a)
+	}
+	ret[0].mut().Set(&inv)
+	return ret, nil
+}
+
+// noInverse returns the error of BatchModInverse for the first of xs that has
+// no inverse modulo mod.
+func noInverse(xs []*Int, mod *gmp.Int) error {
+	var g gmp.Int
+	g.Init()
+	defer g.Clear()
+	for i, x := range xs {
+		if g.Gcd(x.mpz(), mod); g.CmpUi(1) != 0 {
+			return fmt.Errorf("math/big: BatchModInverse: element %d has no inverse modulo m", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+//go:build !math_big_pure_go && !gmp
+
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package big
+
+import (
+	"math/bits"
+	"unsafe"
+
+	c "github.com/goplus/llgo/runtime/internal/clite"
+	"github.com/goplus/llgo/runtime/internal/clite/openssl"
+)
+
+// BitField sets z to the width-bit field of x starting at bit shift, that is
+// (x >> shift) & (1<<width - 1), and returns z. As with Rsh and And, a
+// negative x is treated as if in two's complement representation.
+//
+// BitField computes the field in place in z, without allocating the
+// intermediate shifted value and mask.
+func (z *Int) BitField(x *Int, shift, width uint) *Int {
+	z.Rsh(x, shift)
+	a := z.mut()
+	neg := a.IsNegative() != 0
+	a.SetNegative(0)
+	if width < uint(a.NumBits()) {
+		a.MaskBits(c.Int(width))
+	}
+	if neg && a.IsZero() == 0 {
+		// For t < 0, t mod 2**width == 2**width - (|t| mod 2**width).
+		m := openssl.BNNew()
+		m.SetBit(c.Int(width))
+		m.Sub(m, a)
+		a.Copy(m) // not Swap, which would drop the flags of a
+		m.Free()
+	}
+	return z
+}
+
+// IsPowerOfTwo reports whether x is a power of two, that is x > 0 with a
+// single bit set.
+func (x *Int) IsPowerOfTwo() bool {
+	return x.Sign() > 0 && x.TrailingZeroBits() == uint(x.BitLen()-1)
+}
+
+// NextPowerOfTwo sets z to the smallest power of two >= x and returns z.
+// That is x itself if it is a power of two, and 1 if x <= 1.
+func (z *Int) NextPowerOfTwo(x *Int) *Int {
+	if x.Sign() <= 0 {
+		return z.SetInt64(1)
+	}
+	if x.IsPowerOfTwo() {
+		return z.Set(x)
+	}
+	n := x.BitLen()
+	a := z.mut()
+	a.SetZero()
+	a.SetBit(c.Int(n))
+	return z
+}
+
+// Bitwise operations of Int.bitwise.
+const (
+	bitAnd = iota
+	bitAndNot
+	bitOr
+	bitXor
+)
+
+// bitwise sets z to x op y and returns z. Like math/big, it operates on the
+// two's complement representations of x and y, which OpenSSL doesn't have
+// (BN_signed_bn2lebin needs OpenSSL 3.2): twosComplement provides them, with
+// enough bytes for a sign byte of 0x00 or 0xff.
+func (z *Int) bitwise(x, y *Int, op int) *Int {
+	n := x.bn().NumBytes()
+	if m := y.bn().NumBytes(); m > n {
+		n = m
+	}
+	n++
+	s, negX := twosComplement(x, n)
+	t, negY := twosComplement(y, n)
+	var neg bool
+	switch op {
+	case bitAnd:
+		for i := range s {
+			s[i] &= t[i]
+		}
+		neg = negX && negY
+	case bitAndNot:
+		for i := range s {
+			s[i] &^= t[i]
+		}
+		neg = negX && !negY
+	case bitOr:
+		for i := range s {
+			s[i] |= t[i]
+		}
+		neg = negX || negY
+	case bitXor:
+		for i := range s {
+			s[i] ^= t[i]
+		}
+		neg = negX != negY
+	}
+
+	// For a negative result, s holds ^(|z|-1).
+	if neg {
+		for i := range s {
+			s[i] = ^s[i]
+		}
+	}
+	a := z.mut()
+	openssl.BNLebin2bn(unsafe.SliceData(s), n, a)
+	if neg {
+		a.AddWord(1)
+		a.SetNegative(1)
+	}
+	return z
+}
+
+// twosComplement returns the n-byte little-endian two's complement
+// representation of x, and whether x is negative. n must be larger than the
+// size of |x| in bytes.
+func twosComplement(x *Int, n c.Int) (s []byte, neg bool) {
+	s = make([]byte, n)
+	a := x.bn()
+	if a.IsNegative() == 0 {
+		a.Bn2lebinpad(unsafe.SliceData(s), n)
+		return s, false
+	}
+	// -|x| == ^(|x|-1)
+	t := openssl.BNNew()
+	t.Copy(a)
+	t.SetNegative(0)
+	t.SubWord(1)
+	t.Bn2lebinpad(unsafe.SliceData(s), n)
+	t.Free()
+	for i := range s {
+		s[i] = ^s[i]
+	}
+	return s, true
+}
+
+// AndInt64 sets z = x & mask and returns z. Like And, it treats x and mask
+// as if in two's complement representation, but only the low bits of x that
+// mask selects or clears are read, and the result is computed with word
+// operations: no mask Int is built, and nothing is allocated.
+func (z *Int) AndInt64(x *Int, mask int64) *Int {
+	m := uint64(mask)
+	if mask >= 0 {
+		// The result is the bits of mask set in x, a Word.
+		return z.SetUint64(lowBits(x.bn(), m) & m)
+	}
+	// mask keeps all the bits of x above bit 63: clear those of the low
+	// word that mask doesn't have, by subtracting them.
+	d := lowBits(x.bn(), ^m) &^ m
+	a := z.mut()
+	if z != x {
+		a.Copy(x.bn())
+	}
+	if d != 0 {
+		a.SubWord(openssl.BN_ULONG(d))
+	}
+	return z
+}
+
+// OrInt64 sets z = x | mask and returns z. Like Or, it treats x and mask as
+// if in two's complement representation; see AndInt64 for how it avoids
+// building a mask Int.
+func (z *Int) OrInt64(x *Int, mask int64) *Int {
+	m := uint64(mask)
+	if mask < 0 {
+		// The result has all the bits above bit 63 set, an int64.
+		return z.SetInt64(int64(lowBits(x.bn(), ^m) | m))
+	}
+	// Set the bits of mask that x doesn't have, by adding them.
+	d := m &^ lowBits(x.bn(), m)
+	a := z.mut()
+	if z != x {
+		a.Copy(x.bn())
+	}
+	if d != 0 {
+		a.AddWord(openssl.BN_ULONG(d))
+	}
+	return z
+}
+
+// lowBits returns the low word of the two's complement representation of a,
+// exact for the bits up to the highest one set in need and 0 above it. It
+// reads only those bits, so that its cost doesn't depend on the size of a.
+func lowBits(a *openssl.BIGNUM, need uint64) uint64 {
+	w := lowAbs(a, need)
+	if a.IsNegative() != 0 {
+		w = -w // correct in the low bits read, as negation carries upwards
+	}
+	return w
+}
+
+// lowAbs returns the low word of |a|, exact for the bits lowBits reads. Unlike
+// BN_get_word, it doesn't saturate when |a| doesn't fit in a word.
+func lowAbs(a *openssl.BIGNUM, need uint64) uint64 {
+	if a.NumBits() <= _W {
+		return uint64(a.GetWord())
+	}
+	var w uint64
+	for i, n := 0, bits.Len64(need); i < n; i++ {
+		if a.IsBitSet(c.Int(i)) != 0 {
+			w |= 1 << i
+		}
+	}
+	return w
+}
@@ -0,0 +1,80 @@
+//go:build gmp && !math_big_pure_go
+
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package big
+
+import (
+	c "github.com/goplus/llgo/runtime/internal/clite"
+	"github.com/goplus/llgo/runtime/internal/clite/gmp"
+)
+
+// BitField sets z to the width-bit field of x starting at bit shift, that is
+// (x >> shift) & (1<<width - 1), and returns z. As with Rsh and And, a
+// negative x is treated as if in two's complement representation.
+//
+// BitField computes the field in place in z, without allocating the
+// intermediate shifted value and mask: mpz_fdiv_r_2exp takes the low bits
+// in two's complement.
+func (z *Int) BitField(x *Int, shift, width uint) *Int {
+	a := z.mut()
+	a.FdivQ2exp(x.mpz(), c.Ulong(shift))
+	a.FdivR2exp(a, c.Ulong(width))
+	return z
+}
+
+// IsPowerOfTwo reports whether x is a power of two, that is x > 0 with a
+// single bit set.
+func (x *Int) IsPowerOfTwo() bool {
+	return x.Sign() > 0 && x.TrailingZeroBits() == uint(x.BitLen()-1)
+}
+
+// NextPowerOfTwo sets z to the smallest power of two >= x and returns z.
+// That is x itself if it is a power of two, and 1 if x <= 1.
+func (z *Int) NextPowerOfTwo(x *Int) *Int {
+	if x.Sign() <= 0 {
+		return z.SetInt64(1)
+	}
+	if x.IsPowerOfTwo() {
+		return z.Set(x)
+	}
+	n := x.BitLen()
+	a := z.mut()
+	a.SetUi(0)
+	a.Setbit(c.Ulong(n))
+	return z
+}
+
+// AndInt64 sets z = x & mask and returns z. Like And, it treats x and mask
+// as if in two's complement representation. The mask is held in a one-limb
+// temporary rather than an Int.
+func (z *Int) AndInt64(x *Int, mask int64) *Int {
+	var m gmp.Int
+	m.InitSetSi(c.Long(mask))
+	z.mut().And(x.mpz(), &m)
+	m.Clear()
+	return z
+}
+
+// OrInt64 sets z = x | mask and returns z. Like Or, it treats x and mask as
+// if in two's complement representation; see AndInt64 for the mask.
+func (z *Int) OrInt64(x *Int, mask int64) *Int {
+	var m gmp.Int
+	m.InitSetSi(c.Long(mask))
+	z.mut().Ior(x.mpz(), &m)
+	m.Clear()
+	return z
+}
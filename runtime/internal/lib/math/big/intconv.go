@@ -1,3 +1,5 @@
+//go:build !math_big_pure_go
+
 /*
  * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
  *
@@ -17,11 +19,12 @@
 package big
 
 import (
-	c "github.com/goplus/llgo/runtime/internal/clite"
-	"github.com/goplus/llgo/runtime/internal/clite/openssl"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
 )
 
-/*
 // Text returns the string representation of x in the given base.
 // Base must be between 2 and 62, inclusive. The result uses the
 // lower-case letters 'a' to 'z' for digit values 10 to 35, and
@@ -29,25 +32,416 @@ import (
 // No prefix (such as "0x") is added to the string. If x is a nil
 // pointer it returns "<nil>".
 func (x *Int) Text(base int) string {
+	if x == nil {
+		return "<nil>"
+	}
+	if base == 10 {
+		return x.String()
+	}
+	return string(x.itoa(nil, base))
 }
 
 // Append appends the string representation of x, as generated by
 // x.Text(base), to buf and returns the extended buffer.
 func (x *Int) Append(buf []byte, base int) []byte {
+	if x == nil {
+		return append(buf, "<nil>"...)
+	}
+	if base == 10 {
+		return append(buf, x.String()...)
+	}
+	return x.itoa(buf, base)
 }
-*/
 
-// String returns the decimal representation of x as generated by
-// x.Text(10).
-func (x *Int) String() string {
-	// TODO(xsw): can optimize it?
-	cstr := (*openssl.BIGNUM)(x).CStr()
-	ret := c.GoString(cstr)
-	openssl.FreeCStr(cstr)
-	return ret
+// TextUpper is like Text but uses the upper-case letters 'A' to 'Z' for digit
+// values 10 to 35, as required e.g. for upper-case hexadecimal. Only the
+// digits are affected, not the sign. For bases > 36, where upper-case letters
+// already stand for digit values 36 to 61, the result is the same as Text's.
+func (x *Int) TextUpper(base int) string {
+	if x == nil {
+		return "<nil>"
+	}
+	buf := x.Append(nil, base)
+	if base <= 36 {
+		for i, d := range buf {
+			if 'a' <= d && d <= 'z' {
+				buf[i] = 'A' + (d - 'a')
+			}
+		}
+	}
+	return string(buf)
+}
+
+// TextGrouped is like Text but separates the digits into groups of group
+// digits with sep, counting from the least significant digit, as in
+// "-1,234,567" for base 10, sep ',' and group 3. The sign is not part of
+// any group. If group <= 0, the result is the same as Text's.
+func (x *Int) TextGrouped(base int, sep byte, group int) string {
+	if x == nil {
+		return "<nil>"
+	}
+	buf := x.Append(nil, base)
+	if group <= 0 {
+		return string(buf)
+	}
+	var sign []byte
+	if buf[0] == '-' {
+		sign, buf = buf[:1], buf[1:]
+	}
+	ret := make([]byte, 0, len(sign)+len(buf)+(len(buf)-1)/group)
+	ret = append(ret, sign...)
+	for i, d := range buf {
+		if i > 0 && (len(buf)-i)%group == 0 {
+			ret = append(ret, sep)
+		}
+		ret = append(ret, d)
+	}
+	return string(ret)
+}
+
+const digits = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// intText is the decimal text of an Int as of mutation generation gen.
+type intText struct {
+	gen  uint64
+	text string
+}
+
+// MaxBase is the largest number base accepted for string conversions.
+const MaxBase = 10 + ('z' - 'a' + 1) + ('Z' - 'A' + 1)
+const maxBaseSmall = 10 + ('z' - 'a' + 1)
+
+var (
+	errNoDigits = errors.New("number has no digits")
+	errInvalSep = errors.New("'_' must separate successive digits")
+)
+
+// SetString sets z to the value of s, interpreted in the given base,
+// and returns z and a boolean indicating success. The entire string
+// (not just a prefix) must be valid for success. If SetString fails,
+// the value of z is undefined but the returned value is nil.
+//
+// The base argument must be 0 or a value between 2 and MaxBase.
+// For base 0, the number prefix determines the actual base: A prefix of
+// “0b” or “0B” selects base 2, “0”, “0o” or “0O” selects base 8,
+// and “0x” or “0X” selects base 16. Otherwise, the selected base is 10
+// and no prefix is accepted.
+//
+// For bases <= 36, lower and upper case letters are considered the same:
+// The letters 'a' to 'z' and 'A' to 'Z' represent digit values 10 to 35.
+// For bases > 36, the upper case letters 'A' to 'Z' represent the digit
+// values 36 to 61.
+//
+// For base 0, an underscore character “_” may appear between a base
+// prefix and an adjacent digit, and between successive digits; such
+// underscores do not change the value of the number.
+// Incorrect placement of underscores is reported as an error if there
+// are no other errors. If base != 0, underscores are not recognized
+// and act like any other character that is not a valid digit.
+func (z *Int) SetString(s string, base int) (*Int, bool) {
+	return z.setFromScanner(strings.NewReader(s), base)
+}
+
+// setFromScanner implements SetString given an io.ByteScanner.
+// For documentation see comments of SetString.
+func (z *Int) setFromScanner(r io.ByteScanner, base int) (*Int, bool) {
+	if _, _, err := z.scan(r, base); err != nil {
+		return nil, false
+	}
+	// entire content must have been consumed
+	if _, err := r.ReadByte(); err != io.EOF {
+		return nil, false
+	}
+	return z, true // err == io.EOF => scan consumed all content of r
+}
+
+// ReadText sets z to the integer read from r in the given base, with the
+// syntax accepted by SetString, and returns the number of bytes that form
+// it. Unlike SetString, it stops at the first byte that can't continue the
+// number rather than failing, so that a number can be parsed from a stream,
+// such as a large file, without first reading it into a string.
+//
+// r is read one byte at a time; wrap it in a bufio.Reader unless it is
+// already buffered. If r implements io.ByteScanner, the byte that ends the
+// number is unread and left in r; otherwise it is consumed, but not counted
+// in n. ReadText returns io.EOF if r is empty, and an error if there are no
+// digits, or if reading r fails; the value of z is then undefined.
+func (z *Int) ReadText(r io.Reader, base int) (n int64, err error) {
+	br := &byteReader{r: r}
+	br.bs, _ = r.(io.ByteScanner)
+	_, _, err = z.scan(br, base)
+	return br.n, err
+}
+
+// byteReader adapts an io.Reader to the io.ByteScanner used by scan,
+// counting the bytes read and not unread.
+type byteReader struct {
+	r  io.Reader
+	bs io.ByteScanner // r, if it is an io.ByteScanner
+	n  int64
+
+	buf    [1]byte
+	unread bool // buf[0] was unread, if bs is nil
+}
+
+func (b *byteReader) ReadByte() (byte, error) {
+	if b.bs != nil {
+		ch, err := b.bs.ReadByte()
+		if err == nil {
+			b.n++
+		}
+		return ch, err
+	}
+	if !b.unread {
+		if _, err := io.ReadFull(b.r, b.buf[:]); err != nil {
+			return 0, err
+		}
+	}
+	b.unread = false
+	b.n++
+	return b.buf[0], nil
+}
+
+func (b *byteReader) UnreadByte() error {
+	b.n--
+	if b.bs != nil {
+		return b.bs.UnreadByte()
+	}
+	b.unread = true
+	return nil
+}
+
+// scan sets z to the integer value corresponding to the longest possible prefix
+// read from r representing a signed integer number in a given conversion base.
+// It returns z, the actual conversion base used, and an error, if any. In the
+// error case, the value of z is undefined but the returned value is nil. The
+// syntax follows the syntax of integer literals in Go.
+//
+// The base argument must be 0 or a value from 2 through MaxBase. If the base
+// is 0, the string prefix determines the actual conversion base. A prefix of
+// “0b” or “0B” selects base 2; a “0”, “0o”, or “0O” prefix selects
+// base 8, and a “0x” or “0X” prefix selects base 16. Otherwise the selected
+// base is 10.
+func (z *Int) scan(r io.ByteScanner, base int) (*Int, int, error) {
+	// determine sign
+	neg, err := scanSign(r)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// determine mantissa
+	base, _, err = z.scanAbs(r, base, false)
+	if err != nil {
+		return nil, base, err
+	}
+	if neg {
+		z.Neg(z) // 0 has no sign
+	}
+
+	return z, base, nil
+}
+
+func scanSign(r io.ByteScanner) (neg bool, err error) {
+	var ch byte
+	if ch, err = r.ReadByte(); err != nil {
+		return false, err
+	}
+	switch ch {
+	case '-':
+		neg = true
+	case '+':
+		// nothing to do
+	default:
+		r.UnreadByte()
+	}
+	return
+}
+
+// scanAbs sets z to the non-negative value scanned from r, following the
+// rules of the standard library's nat.scan: it returns the actual base and
+// the number of digits scanned, and reports an *Error if the backend fails
+// to grow z.
+//
+// If fracOk is set, a "0" prefix doesn't select base 8 and the digits may
+// contain one radix point '.', which is skipped: the count returned is then
+// the negated number of digits after it, or 0 if there are none.
+func (z *Int) scanAbs(r io.ByteScanner, base int, fracOk bool) (b, count int, err error) {
+	// Reject invalid bases.
+	if base != 0 && (base < 2 || base > MaxBase) {
+		panic(fmt.Sprintf("invalid number base %d", base))
+	}
+
+	// prev encodes the previously seen char: it is one
+	// of '_', '0' (a digit), or '.' (anything else). A
+	// valid separator '_' may only occur after a digit
+	// and if base == 0.
+	prev := '.'
+	invalSep := false
+
+	// one char look-ahead
+	ch, err := r.ReadByte()
+
+	// Determine actual base.
+	b, prefix := base, 0
+	if base == 0 {
+		// Actual base is 10 unless there's a base prefix.
+		b = 10
+		if err == nil && ch == '0' {
+			prev = '0'
+			count = 1
+			ch, err = r.ReadByte()
+			if err == nil {
+				// possibly one of 0b, 0B, 0o, 0O, 0x, 0X
+				switch ch {
+				case 'b', 'B':
+					b, prefix = 2, 'b'
+				case 'o', 'O':
+					b, prefix = 8, 'o'
+				case 'x', 'X':
+					b, prefix = 16, 'x'
+				default:
+					if !fracOk {
+						b, prefix = 8, '0'
+					}
+				}
+				if prefix != 0 {
+					count = 0 // prefix is not counted
+					if prefix != '0' {
+						ch, err = r.ReadByte()
+					}
+				}
+			}
+		}
+	}
+
+	// Convert string.
+	// Collect digits in groups of at most n digits in di, and use
+	// mulAddWord for every such group to shift z up one group and
+	// add di to the result.
+	z.SetInt64(0)
+	b1 := Word(b)
+	bn, n := maxPow(b1)
+	di := Word(0) // 0 <= di < b1**i < bn
+	i := 0        // 0 <= i < n
+	dp := -1      // position of decimal point
+	for err == nil {
+		if ch == '.' && fracOk && dp < 0 {
+			if prev == '_' {
+				invalSep = true
+			}
+			prev = '.'
+			dp = count
+		} else if ch == '_' && base == 0 {
+			if prev != '0' {
+				invalSep = true
+			}
+			prev = '_'
+		} else {
+			// convert rune into digit value d1
+			var d1 Word
+			switch {
+			case '0' <= ch && ch <= '9':
+				d1 = Word(ch - '0')
+			case 'a' <= ch && ch <= 'z':
+				d1 = Word(ch - 'a' + 10)
+			case 'A' <= ch && ch <= 'Z':
+				if b <= maxBaseSmall {
+					d1 = Word(ch - 'A' + 10)
+				} else {
+					d1 = Word(ch - 'A' + maxBaseSmall)
+				}
+			default:
+				d1 = MaxBase + 1
+			}
+			if d1 >= b1 {
+				r.UnreadByte() // ch does not belong to number anymore
+				break
+			}
+			prev = '0'
+			count++
+
+			// collect d1 in di
+			di = di*b1 + d1
+			i++
+
+			// if di is "full", add it to the result
+			if i == n {
+				if e := z.mulAddWord(bn, di); e != nil {
+					return b, count, e
+				}
+				di = 0
+				i = 0
+			}
+		}
+
+		ch, err = r.ReadByte()
+	}
+
+	if err == io.EOF {
+		err = nil
+	}
+
+	// other errors take precedence over invalid separators
+	if err == nil && (invalSep || prev == '_') {
+		err = errInvalSep
+	}
+
+	if count == 0 {
+		// no digits found
+		if prefix == '0' {
+			// there was only the octal prefix 0 (possibly followed by separators and digits > 7);
+			// interpret as decimal 0
+			z.SetInt64(0)
+			return 10, 1, err
+		}
+		err = errNoDigits // fall through; result will be 0
+	}
+
+	if i > 0 {
+		// Add remaining digit chunk to result.
+		if e := z.mulAddWord(pow(b1, i), di); e != nil {
+			return b, count, e
+		}
+	}
+
+	// adjust count for fraction, if any
+	if dp >= 0 {
+		// 0 <= dp <= count
+		count = dp - count
+	}
+	return
+}
+
+// maxPow returns (b**n, n) such that b**n is the largest power b**n <= _M.
+// For instance maxPow(10) == (1e19, 19) for 19 decimal digits in a 64bit Word.
+// In other words, at most n digits in base b fit into a Word.
+func maxPow(b Word) (p Word, n int) {
+	p, n = b, 1 // assuming b <= _M
+	for max := _M / b; p <= max; {
+		// p == b**n && p <= max
+		p *= b
+		n++
+	}
+	// p == b**n && p <= _M
+	return
+}
+
+// pow returns x**n for n > 0, and 1 otherwise.
+func pow(x Word, n int) (p Word) {
+	// n == sum of bi * 2**i, for 0 <= i < imax, and bi is 0 or 1
+	// thus x**n == product of x**(2**i) for all i where bi == 1
+	// (Russian Peasant Method for exponentiation)
+	p = 1
+	for n > 0 {
+		if n&1 != 0 {
+			p *= x
+		}
+		x *= x
+		n >>= 1
+	}
+	return
 }
 
-/*
 // Format implements fmt.Formatter. It accepts the formats
 // 'b' (binary), 'o' (octal with 0 prefix), 'O' (octal with 0o prefix),
 // 'd' (decimal), 'x' (lowercase hexadecimal), and
@@ -60,8 +454,122 @@ func (x *Int) String() string {
 // width, space or zero padding, and '-' for left or right
 // justification.
 func (x *Int) Format(s fmt.State, ch rune) {
+	// determine base
+	var base int
+	switch ch {
+	case 'b':
+		base = 2
+	case 'o', 'O':
+		base = 8
+	case 'd', 's', 'v':
+		base = 10
+	case 'x', 'X':
+		base = 16
+	default:
+		// unknown format
+		fmt.Fprintf(s, "%%!%c(big.Int=%s)", ch, x.String())
+		return
+	}
+
+	if x == nil {
+		fmt.Fprint(s, "<nil>")
+		return
+	}
+
+	// determine sign character
+	sign := ""
+	switch {
+	case x.Sign() < 0:
+		sign = "-"
+	case s.Flag('+'): // supersedes ' ' when both specified
+		sign = "+"
+	case s.Flag(' '):
+		sign = " "
+	}
+
+	// determine prefix characters for indicating output base
+	prefix := ""
+	if s.Flag('#') {
+		switch ch {
+		case 'b': // binary
+			prefix = "0b"
+		case 'o': // octal
+			prefix = "0"
+		case 'x': // hexadecimal
+			prefix = "0x"
+		case 'X':
+			prefix = "0X"
+		}
+	}
+	if ch == 'O' {
+		prefix = "0o"
+	}
+
+	digits := x.Append(nil, base)
+	if sign == "-" {
+		digits = digits[1:]
+	}
+	if ch == 'X' {
+		// faster than bytes.ToUpper
+		for i, d := range digits {
+			if 'a' <= d && d <= 'z' {
+				digits[i] = 'A' + (d - 'a')
+			}
+		}
+	}
+
+	// number of characters for the three classes of number padding
+	var left int  // space characters to left of digits for right justification ("%8d")
+	var zeros int // zero characters (actually cs[0]) as left-most digits ("%.8d")
+	var right int // space characters to right of digits for left justification ("%-8d")
+
+	// determine number padding from precision: the least number of digits to output
+	precision, precisionSet := s.Precision()
+	if precisionSet {
+		switch {
+		case len(digits) < precision:
+			zeros = precision - len(digits) // count of zero padding
+		case len(digits) == 1 && digits[0] == '0' && precision == 0:
+			return // print nothing if zero value (x == 0) and zero precision ("." or ".0")
+		}
+	}
+
+	// determine field pad from width: the least number of characters to output
+	length := len(sign) + len(prefix) + zeros + len(digits)
+	if width, widthSet := s.Width(); widthSet && length < width { // pad as specified
+		switch d := width - length; {
+		case s.Flag('-'):
+			// pad on the right with spaces; supersedes '0' when both specified
+			right = d
+		case s.Flag('0') && !precisionSet:
+			// pad with zeros unless precision also specified
+			zeros = d
+		default:
+			// pad on the left with spaces
+			left = d
+		}
+	}
+
+	// print number as [left pad][sign][prefix][zero pad][digits][right pad]
+	writeMultiple(s, " ", left)
+	writeMultiple(s, sign, 1)
+	writeMultiple(s, prefix, 1)
+	writeMultiple(s, "0", zeros)
+	s.Write(digits)
+	writeMultiple(s, " ", right)
 }
 
+// write count copies of text to s
+func writeMultiple(s fmt.State, text string, count int) {
+	if len(text) > 0 {
+		b := []byte(text)
+		for ; count > 0; count-- {
+			s.Write(b)
+		}
+	}
+}
+
+/*
 // Scan is a support routine for fmt.Scanner; it sets z to the value of
 // the scanned number. It accepts the formats 'b' (binary), 'o' (octal),
 // 'd' (decimal), 'x' (lowercase hexadecimal), and 'X' (uppercase hexadecimal).
@@ -0,0 +1,188 @@
+//go:build !math_big_pure_go && !gmp
+
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package big
+
+import (
+	"math"
+	"sync/atomic"
+	"unsafe"
+
+	c "github.com/goplus/llgo/runtime/internal/clite"
+	"github.com/goplus/llgo/runtime/internal/clite/openssl"
+)
+
+// itoa appends the digits of x in the given base to buf.
+//
+// Numbers of up to leafWords words are converted by dividing a copy of |x| by
+// bb, the largest power of base fitting in a Word, converting each remainder
+// to that many digits. Larger ones are split by divide and conquer first: see
+// convertDigits.
+func (x *Int) itoa(buf []byte, base int) []byte {
+	if base < 2 || base > MaxBase {
+		panic("invalid base")
+	}
+	a := x.bn()
+	if a.IsZero() != 0 {
+		return append(buf, '0')
+	}
+	if a.IsNegative() != 0 {
+		buf = append(buf, '-')
+	}
+
+	t := openssl.BNNew()
+	defer t.Free()
+	t.Copy(a)
+	t.SetNegative(0)
+
+	// Write |x| right-aligned and zero-padded into s, which is large enough
+	// for all its digits, then strip the padding.
+	n := int(float64(a.NumBits())/math.Log2(float64(base))) + 1
+	s := make([]byte, n)
+	var table []*openssl.BIGNUM
+	if a.NumBits() > leafWords*_W {
+		table = divisors(Word(base), int(a.NumBits()))
+		defer func() {
+			for _, d := range table {
+				d.Free()
+			}
+		}()
+	}
+	ctx := ctxGet()
+	defer ctxPut(ctx)
+	convertDigits(s, t, Word(base), table, ctx)
+	i := 0
+	for s[i] == '0' {
+		i++
+	}
+	return append(buf, s[i:]...)
+}
+
+// leafWords is the size in words of the numbers itoa converts directly,
+// rather than by splitting them first.
+const leafWords = 8
+
+// divisors returns the table used by convertDigits for numbers of up to
+// nbits bits: table[k] is bb**(leafWords * 2**k), for as long as that isn't
+// larger than such a number.
+func divisors(b Word, nbits int) []*openssl.BIGNUM {
+	bb, _ := maxPow(b)
+	ctx := ctxGet()
+	defer ctxPut(ctx)
+	d := openssl.BNNew()
+	d.SetWord(openssl.BN_ULONG(bb))
+	for i := 1; i < leafWords; i <<= 1 {
+		d.Sqr(d, ctx)
+	}
+	table := []*openssl.BIGNUM{d}
+	for 2*int(d.NumBits()) <= nbits {
+		next := openssl.BNNew()
+		next.Sqr(d, ctx)
+		table = append(table, next)
+		d = next
+	}
+	return table
+}
+
+// convertDigits writes the digits of t in base b into s, right-aligned and
+// padded with zeros; t must have at most len(s) digits and is consumed.
+//
+// As long as t is larger than table[0], it is divided by the table[k] closest
+// to its square root. Being a power of bb, table[k] has leafWords * 2**k *
+// ndigits digits, so the remainder gives exactly that many low-order digits:
+// they are converted recursively with the smaller divisors, and the quotient
+// takes the place of t. This replaces the quadratic number of word divisions
+// of converting t directly by a few multi-word divisions of balanced sizes,
+// as math/big does.
+func convertDigits(s []byte, t *openssl.BIGNUM, b Word, table []*openssl.BIGNUM, ctx *openssl.BN_CTX) {
+	bb, ndigits := maxPow(b)
+	if len(table) > 0 && t.Ucmp(table[0]) >= 0 {
+		q, r := openssl.BNNew(), openssl.BNNew()
+		for t.Ucmp(table[0]) >= 0 {
+			nbits := int(t.NumBits())
+			k := len(table) - 1
+			for k > 0 && int(table[k-1].NumBits()) > nbits/2 {
+				k--
+			}
+			if t.Ucmp(table[k]) < 0 {
+				k-- // >= 0 as t >= table[0]
+			}
+			q.Div(r, t, table[k], ctx)
+			h := len(s) - leafWords<<k*ndigits
+			convertDigits(s[h:], r, b, table[:k], ctx)
+			s = s[:h]
+			t.Swap(q)
+		}
+		q.Free()
+		r.Free()
+	}
+
+	i := len(s)
+	for t.IsZero() == 0 {
+		r := Word(t.DivWord(openssl.BN_ULONG(bb)))
+		for j := 0; j < ndigits && i > 0; j++ {
+			i--
+			s[i] = digits[r%b]
+			r /= b
+		}
+	}
+	for i > 0 {
+		i--
+		s[i] = '0'
+	}
+}
+
+// String returns the decimal representation of x as generated by
+// x.Text(10).
+//
+// The text is memoized on x until its next mutation, so formatting an
+// unchanged value repeatedly is cheap. Concurrent calls are safe as long as
+// x isn't being modified at the same time.
+func (x *Int) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	gen := atomic.LoadUint64(&x.gen)
+	if t := (*intText)(atomic.LoadPointer(&x.text)); t != nil && t.gen == gen {
+		return t.text
+	}
+	var ret string
+	if a := x.bn(); a.NumBits() > leafWords*_W {
+		ret = string(x.itoa(nil, 10)) // BN_bn2dec is quadratic
+	} else {
+		cstr := a.CStr()
+		ret = c.GoString(cstr)
+		openssl.FreeCStr(cstr)
+	}
+	if !x.IsSecure() {
+		atomic.StorePointer(&x.text, unsafe.Pointer(&intText{gen, ret}))
+	}
+	return ret
+}
+
+// mulAddWord sets z = z*m + d, for scanAbs.
+func (z *Int) mulAddWord(m, d Word) error {
+	a := z.mut()
+	if a.MulWord(openssl.BN_ULONG(m)) == 0 {
+		return newError("BN_mul_word")
+	}
+	if a.AddWord(openssl.BN_ULONG(d)) == 0 {
+		return newError("BN_add_word")
+	}
+	return nil
+}
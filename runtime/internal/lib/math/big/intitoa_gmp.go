@@ -0,0 +1,89 @@
+//go:build gmp && !math_big_pure_go
+
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package big
+
+import (
+	"sync/atomic"
+	"unsafe"
+
+	c "github.com/goplus/llgo/runtime/internal/clite"
+	"github.com/goplus/llgo/runtime/internal/clite/gmp"
+)
+
+// itoa appends the digits of x in the given base to buf.
+//
+// mpz_get_str converts subquadratically, by divide and conquer, like
+// math/big. For bases > 36 it uses the upper-case letters for the digit
+// values 10 to 35, the other way round from math/big, so the case of the
+// letters is swapped.
+func (x *Int) itoa(buf []byte, base int) []byte {
+	if base < 2 || base > MaxBase {
+		panic("invalid base")
+	}
+	a := x.mpz()
+	// Room for a sign and the terminating NUL: mpz_sizeinbase may exceed the
+	// number of digits by one, not fall short.
+	s := make([]byte, a.Sizeinbase(c.Int(base))+2)
+	gmp.GetStr((*c.Char)(unsafe.Pointer(unsafe.SliceData(s))), c.Int(base), a)
+	n := 0
+	for s[n] != 0 {
+		n++
+	}
+	s = s[:n]
+	if base > maxBaseSmall {
+		for i, d := range s {
+			switch {
+			case 'a' <= d && d <= 'z':
+				s[i] = 'A' + (d - 'a')
+			case 'A' <= d && d <= 'Z':
+				s[i] = 'a' + (d - 'A')
+			}
+		}
+	}
+	return append(buf, s...)
+}
+
+// String returns the decimal representation of x as generated by
+// x.Text(10).
+//
+// The text is memoized on x until its next mutation, so formatting an
+// unchanged value repeatedly is cheap. Concurrent calls are safe as long as
+// x isn't being modified at the same time.
+func (x *Int) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	gen := atomic.LoadUint64(&x.gen)
+	if t := (*intText)(atomic.LoadPointer(&x.text)); t != nil && t.gen == gen {
+		return t.text
+	}
+	ret := string(x.itoa(nil, 10))
+	if !x.IsSecure() {
+		atomic.StorePointer(&x.text, unsafe.Pointer(&intText{gen, ret}))
+	}
+	return ret
+}
+
+// mulAddWord sets z = z*m + d, for scanAbs. GMP aborts the program rather
+// than fail to grow z, so it never returns an error.
+func (z *Int) mulAddWord(m, d Word) error {
+	a := z.mut()
+	a.MulUi(a, c.Ulong(m))
+	a.AddUi(a, c.Ulong(d))
+	return nil
+}
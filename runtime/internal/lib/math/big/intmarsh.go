@@ -0,0 +1,106 @@
+//go:build !math_big_pure_go
+
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package big
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Gob codec version. Permits backward-compatible changes to the encoding.
+const intGobVersion byte = 1
+
+// GobEncode implements the [encoding/gob.GobEncoder] interface.
+//
+// The encoding is a version/sign byte followed by the big-endian magnitude,
+// so it does not depend on the word size or byte order of the machine and is
+// compatible with the standard library.
+func (x *Int) GobEncode() ([]byte, error) {
+	if x == nil {
+		return nil, nil
+	}
+	buf := make([]byte, 1+(x.BitLen()+7)/8) // extra byte for version and sign bit
+	x.FillBytes(buf[1:])
+	b := intGobVersion << 1 // make space for sign bit
+	if x.Sign() < 0 {
+		b |= 1
+	}
+	buf[0] = b
+	return buf, nil
+}
+
+// GobDecode implements the [encoding/gob.GobDecoder] interface.
+func (z *Int) GobDecode(buf []byte) error {
+	if len(buf) == 0 {
+		// Other side sent a nil or default value.
+		z.SetInt64(0)
+		return nil
+	}
+	b := buf[0]
+	if b>>1 != intGobVersion {
+		return fmt.Errorf("Int.GobDecode: encoding version %d not supported", b>>1)
+	}
+	z.SetBytes(buf[1:])
+	if b&1 != 0 {
+		z.Neg(z)
+	}
+	return nil
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (x *Int) MarshalText() (text []byte, err error) {
+	if x == nil {
+		return []byte("<nil>"), nil
+	}
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+// Failures reported by the OpenSSL backend are wrapped as an *Error.
+func (z *Int) UnmarshalText(text []byte) error {
+	r := bytes.NewReader(text)
+	if _, _, err := z.scan(r, 0); err != nil || r.Len() != 0 {
+		if e, ok := err.(*Error); ok {
+			return fmt.Errorf("math/big: cannot unmarshal %q into a *big.Int: %w", text, e)
+		}
+		return fmt.Errorf("math/big: cannot unmarshal %q into a *big.Int", text)
+	}
+	return nil
+}
+
+// MarshalJSON implements the [encoding/json.Marshaler] interface.
+//
+// The value is encoded as a JSON number in base 10, with a leading '-' for a
+// negative value; zero is always "0", since an Int has no negative zero. A nil
+// *Int is encoded as null.
+func (x *Int) MarshalJSON() ([]byte, error) {
+	if x == nil {
+		return []byte("null"), nil
+	}
+	return []byte(x.String()), nil
+}
+
+// UnmarshalJSON implements the [encoding/json.Unmarshaler] interface.
+func (z *Int) UnmarshalJSON(text []byte) error {
+	// Ignore null, like in the main JSON package.
+	if string(text) == "null" {
+		return nil
+	}
+	return z.UnmarshalText(text)
+}
@@ -0,0 +1,76 @@
+//go:build !math_big_pure_go && !gmp
+
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package big
+
+import (
+	"runtime"
+
+	"github.com/goplus/llgo/runtime/internal/clite/openssl"
+)
+
+// A Reducer computes x mod m for many x and a fixed modulus m by Barrett
+// reduction: NewReducer precomputes a reciprocal of m once, so that each Mod
+// replaces the long division done by Int.Mod with multiplications. It is
+// backed by OpenSSL's BN_RECP_CTX and BN_div_recp.
+//
+// A Reducer must not be used concurrently: OpenSSL recomputes the reciprocal
+// in place to reduce a value more than twice the size of m.
+type Reducer struct {
+	m    Int // |m|
+	recp *openssl.BN_RECP_CTX
+}
+
+// NewReducer returns a Reducer for the modulus |m|.
+// If m == 0, a division-by-zero run-time panic occurs.
+func NewReducer(m *Int) *Reducer {
+	if m.Sign() == 0 {
+		panic("division by zero")
+	}
+	r := &Reducer{recp: openssl.BN_RECP_CTXNew()}
+	r.m.Abs(m)
+	ctx := ctxGet()
+	r.recp.Set(r.m.bn(), ctx)
+	ctxPut(ctx)
+	runtime.SetFinalizer(r, (*Reducer).Free)
+	return r
+}
+
+// Mod returns x mod |m| as a new Int. Like Int.Mod, it implements Euclidean
+// modulus: the result is in the range [0, |m|) even for a negative x.
+func (r *Reducer) Mod(x *Int) *Int {
+	z := new(Int)
+	a := z.mut()
+	ctx := ctxGet()
+	(*openssl.BIGNUM)(nil).DivRecp(a, x.bn(), r.recp, ctx) // quotient not needed
+	ctxPut(ctx)
+	if a.IsNegative() != 0 {
+		a.Add(a, r.m.bn()) // BN_div_recp truncates like BN_div
+	}
+	return z
+}
+
+// Free releases the memory held by r right away instead of when r becomes
+// unreachable, as Int.Free does. r must not be used afterwards.
+func (r *Reducer) Free() {
+	if p := r.recp; p != nil {
+		r.recp = nil
+		p.Free()
+		r.m.Free()
+	}
+}
@@ -0,0 +1,53 @@
+//go:build gmp && !math_big_pure_go
+
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package big
+
+// A Reducer computes x mod m for many x and a fixed modulus m. NewReducer
+// precomputes a reciprocal of m with the OpenSSL backend; GMP exposes no
+// reusable reciprocal for an mpz_t, so with the GMP backend a Reducer only
+// holds |m| and each Mod is an mpz_mod.
+//
+// A Reducer must not be used concurrently, as with the OpenSSL backend.
+type Reducer struct {
+	m Int // |m|
+}
+
+// NewReducer returns a Reducer for the modulus |m|.
+// If m == 0, a division-by-zero run-time panic occurs.
+func NewReducer(m *Int) *Reducer {
+	if m.Sign() == 0 {
+		panic("division by zero")
+	}
+	r := new(Reducer)
+	r.m.Abs(m)
+	return r
+}
+
+// Mod returns x mod |m| as a new Int. Like Int.Mod, it implements Euclidean
+// modulus: the result is in the range [0, |m|) even for a negative x.
+func (r *Reducer) Mod(x *Int) *Int {
+	z := new(Int)
+	z.mut().Mod(x.mpz(), r.m.mpz())
+	return z
+}
+
+// Free releases the memory held by r right away instead of when r becomes
+// unreachable, as Int.Free does. r must not be used afterwards.
+func (r *Reducer) Free() {
+	r.m.Free()
+}
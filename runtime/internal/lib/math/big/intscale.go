@@ -0,0 +1,107 @@
+//go:build !math_big_pure_go && !gmp
+
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package big
+
+import (
+	"sync"
+
+	"github.com/goplus/llgo/runtime/internal/clite/openssl"
+)
+
+// Scale10 sets z to x * 10**n if n >= 0, or to x / 10**-n if n < 0, and
+// returns z. The division truncates toward zero, like Quo, so that
+// Scale10(x, -k) drops the last k decimal digits of x.
+//
+// The powers of ten up to 10**19 fit in a Word and take a single word
+// multiplication or division. Those up to 10**127 are computed once and
+// shared, so that scaling repeatedly, as fixed-point decimal arithmetic
+// does, doesn't build the power with Exp each time.
+func (z *Int) Scale10(x *Int, n int) *Int {
+	k := n
+	if k < 0 {
+		k = -k
+	}
+	a := z.mut()
+	if k < len(pow10Words) {
+		a.Copy(x.bn())
+		if n > 0 {
+			a.MulWord(openssl.BN_ULONG(pow10Words[k]))
+		} else if n < 0 {
+			a.DivWord(openssl.BN_ULONG(pow10Words[k]))
+		}
+		return z
+	}
+
+	ctx := ctxGet()
+	defer ctxPut(ctx)
+	var p *openssl.BIGNUM
+	if k < pow10Cached {
+		p = pow10(k)
+	} else {
+		ten, e := openssl.BNNew(), openssl.BNNew()
+		ten.SetWord(10)
+		e.SetWord(openssl.BN_ULONG(k))
+		p = openssl.BNNew()
+		p.Exp(ten, e, ctx)
+		ten.Free()
+		e.Free()
+		defer p.Free()
+	}
+	if n > 0 {
+		a.Mul(x.bn(), p, ctx)
+	} else {
+		a.Div(nil, x.bn(), p, ctx)
+	}
+	return z
+}
+
+// pow10Words holds the powers of ten that fit in a Word.
+var pow10Words = func() (t [20]Word) {
+	t[0] = 1
+	for i := 1; i < len(t); i++ {
+		t[i] = t[i-1] * 10
+	}
+	return
+}()
+
+// pow10Cached is the number of powers of ten, from 10**0, cached by pow10.
+const pow10Cached = 128
+
+var (
+	pow10Once  sync.Once
+	pow10Table [pow10Cached]*openssl.BIGNUM
+)
+
+// pow10 returns 10**k for 0 <= k < pow10Cached. The BIGNUM is shared and
+// must not be modified or freed.
+func pow10(k int) *openssl.BIGNUM {
+	pow10Once.Do(func() {
+		p := openssl.BNNew()
+		p.SetWord(1)
+		pow10Table[0] = p
+		for i := 1; i < pow10Cached; i++ {
+			next := openssl.BNNew()
+			next.Copy(p)
+			next.MulWord(10)
+			pow10Table[i] = next
+			p = next
+		}
+	})
+	return pow10Table[k]
+}
@@ -0,0 +1,96 @@
+//go:build gmp && !math_big_pure_go
+
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package big
+
+import (
+	"sync"
+
+	c "github.com/goplus/llgo/runtime/internal/clite"
+	"github.com/goplus/llgo/runtime/internal/clite/gmp"
+)
+
+// Scale10 sets z to x * 10**n if n >= 0, or to x / 10**-n if n < 0, and
+// returns z. The division truncates toward zero, like Quo, so that
+// Scale10(x, -k) drops the last k decimal digits of x.
+//
+// The powers of ten up to 10**19 fit in a Word and take a single word
+// multiplication or division. Those up to 10**127 are computed once and
+// shared, so that scaling repeatedly, as fixed-point decimal arithmetic
+// does, doesn't build the power with mpz_ui_pow_ui each time.
+func (z *Int) Scale10(x *Int, n int) *Int {
+	k := n
+	if k < 0 {
+		k = -k
+	}
+	a := z.mut()
+	if k < len(pow10Words) {
+		if n >= 0 {
+			a.MulUi(x.mpz(), c.Ulong(pow10Words[k]))
+		} else {
+			a.TdivQUi(x.mpz(), c.Ulong(pow10Words[k]))
+		}
+		return z
+	}
+
+	var p *gmp.Int
+	if k < pow10Cached {
+		p = pow10(k)
+	} else {
+		var t gmp.Int
+		t.Init()
+		t.UiPowUi(10, c.Ulong(k))
+		defer t.Clear()
+		p = &t
+	}
+	if n > 0 {
+		a.Mul(x.mpz(), p)
+	} else {
+		a.TdivQ(x.mpz(), p)
+	}
+	return z
+}
+
+// pow10Words holds the powers of ten that fit in a Word.
+var pow10Words = func() (t [20]Word) {
+	t[0] = 1
+	for i := 1; i < len(t); i++ {
+		t[i] = t[i-1] * 10
+	}
+	return
+}()
+
+// pow10Cached is the number of powers of ten, from 10**0, cached by pow10.
+const pow10Cached = 128
+
+var (
+	pow10Once  sync.Once
+	pow10Table [pow10Cached]gmp.Int
+)
+
+// pow10 returns 10**k for 0 <= k < pow10Cached. The mpz_t is shared and
+// must not be modified or cleared.
+func pow10(k int) *gmp.Int {
+	pow10Once.Do(func() {
+		pow10Table[0].InitSetSi(1)
+		for i := 1; i < pow10Cached; i++ {
+			pow10Table[i].Init()
+			pow10Table[i].MulUi(&pow10Table[i-1], 10)
+		}
+	})
+	return &pow10Table[k]
+}
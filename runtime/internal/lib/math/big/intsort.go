@@ -0,0 +1,44 @@
+//go:build !math_big_pure_go
+
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package big
+
+import "sort"
+
+// IntSlice attaches the methods of sort.Interface to []*Int, sorting in
+// increasing order by Cmp. Nil elements sort before all others.
+type IntSlice []*Int
+
+func (x IntSlice) Len() int { return len(x) }
+
+func (x IntSlice) Less(i, j int) bool {
+	a, b := x[i], x[j]
+	if a == nil || b == nil {
+		return a == nil && b != nil
+	}
+	return a.Cmp(b) < 0
+}
+
+func (x IntSlice) Swap(i, j int) { x[i], x[j] = x[j], x[i] }
+
+// Sort is a convenience method: x.Sort() calls sort.Sort(x).
+func (x IntSlice) Sort() { sort.Sort(x) }
+
+// SortInts sorts a slice of *Int in increasing order, with nil elements
+// first.
+func SortInts(x []*Int) { sort.Sort(IntSlice(x)) }
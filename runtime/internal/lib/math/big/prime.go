@@ -0,0 +1,287 @@
+//go:build !math_big_pure_go && !gmp
+
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package big
+
+import (
+	c "github.com/goplus/llgo/runtime/internal/clite"
+	"github.com/goplus/llgo/runtime/internal/clite/openssl"
+)
+
+// ProbablyPrime reports whether x is probably prime, applying BN_check_prime:
+// trial division by small primes, then as many Miller-Rabin rounds with
+// random bases as bring the chance of a composite passing below 2⁻¹²⁸ (64
+// rounds, or 128 beyond 2048 bits). Unlike math/big, it runs no Baillie-PSW
+// test, and n, the number of Miller-Rabin rounds asked for, only has to be
+// non-negative: the rounds of BN_check_prime already exceed any practical n.
+//
+// ProbablyPrime returns false for x <= 1, and is not suitable for judging
+// primes that an adversary may have crafted to fool the test: use
+// ProbablyPrimeBPSW for those.
+//
+// ProbablyPrime panics if n < 0.
+func (x *Int) ProbablyPrime(n int) bool {
+	if n < 0 {
+		panic("negative n for ProbablyPrime")
+	}
+	ctx := ctxGet()
+	defer ctxPut(ctx)
+	switch x.bn().CheckPrime(ctx, nil) {
+	case 0:
+		return false
+	case 1:
+		return true
+	}
+	panic(newError("BN_check_prime"))
+}
+
+// ProbablyPrimeBPSW reports whether x is probably prime by the Baillie-PSW
+// test, which math/big's ProbablyPrime includes and BN_check_prime lacks:
+// after ProbablyPrime(0), x must pass a strong Miller-Rabin test to base 2
+// and a strong Lucas test with Selfridge's parameters. No composite is known
+// to pass both, and none exists below 2⁶⁴, so unlike ProbablyPrime it is fit
+// for numbers of unknown origin, such as primes supplied by a peer.
+//
+// ProbablyPrimeBPSW returns false for x <= 1. It is an llgo extension with
+// no counterpart in math/big.
+func (x *Int) ProbablyPrimeBPSW() bool {
+	if !x.ProbablyPrime(0) {
+		return false
+	}
+	n := x.bn()
+	if n.IsWord(2) != 0 {
+		return true
+	}
+	ctx := ctxGet()
+	defer ctxPut(ctx)
+	return strongBase2(n, ctx) && strongLucas(n, ctx)
+}
+
+// strongBase2 reports whether the odd n > 2 is a strong probable prime to
+// base 2: with n-1 = d * 2**s and d odd, 2**d = 1 or 2**(d * 2**r) = -1 mod n
+// for some r < s.
+func strongBase2(n *openssl.BIGNUM, ctx *openssl.BN_CTX) bool {
+	nm1, d, y, t := openssl.BNNew(), openssl.BNNew(), openssl.BNNew(), openssl.BNNew()
+	defer func() {
+		nm1.Free()
+		d.Free()
+		y.Free()
+		t.Free()
+	}()
+	nm1.Copy(n)
+	nm1.SubWord(1)
+	s := 0
+	for nm1.IsBitSet(c.Int(s)) == 0 {
+		s++
+	}
+	d.Rshift(nm1, c.Int(s))
+	t.SetWord(2)
+	y.ModExp(t, d, n, ctx)
+	if y.IsOne() != 0 || y.Cmp(nm1) == 0 {
+		return true
+	}
+	for r := 1; r < s; r++ {
+		t.Sqr(y, ctx)
+		y.Nnmod(t, n, ctx)
+		if y.Cmp(nm1) == 0 {
+			return true
+		}
+		if y.IsOne() != 0 {
+			return false
+		}
+	}
+	return false
+}
+
+// strongLucas reports whether the odd n > 2 is a strong Lucas probable prime
+// with Selfridge's parameters: D is the first of 5, -7, 9, -11, ... with
+// Jacobi(D/n) = -1, P = 1 and Q = (1-D)/4. With n+1 = d * 2**s and d odd,
+// U(d) = 0 or V(d * 2**r) = 0 mod n for some r < s.
+//
+// The Lucas sequences are computed left to right over the bits of d with
+// U(2k) = U(k)V(k), V(2k) = V(k)² - 2Q**k and, as P = 1,
+// U(k+1) = (U(k) + V(k))/2, V(k+1) = (D U(k) + V(k))/2.
+func strongLucas(n *openssl.BIGNUM, ctx *openssl.BN_CTX) bool {
+	D := int64(5)
+	for i := 0; ; i++ {
+		j := jacobi(D, n)
+		if j == -1 {
+			break
+		}
+		if j == 0 && n.AbsIsWord(openssl.BN_ULONG(abs64(D))) == 0 {
+			return false // a proper factor of D divides n
+		}
+		// No D exists for a square, which only has to be ruled out once
+		// the search takes longer than it does for most n.
+		if i == 4 && isSquare(n, ctx) {
+			return false
+		}
+		if D > 0 {
+			D = -D - 2
+		} else {
+			D = -D + 2
+		}
+	}
+
+	var bns [9]*openssl.BIGNUM
+	for i := range bns {
+		bns[i] = openssl.BNNew()
+	}
+	defer func() {
+		for _, b := range bns {
+			b.Free()
+		}
+	}()
+	d, dm, qm, qk, u, v, u2, t, t2 := bns[0], bns[1], bns[2], bns[3], bns[4], bns[5], bns[6], bns[7], bns[8]
+	setMod := func(z *openssl.BIGNUM, w int64) {
+		t.SetWord(openssl.BN_ULONG(abs64(w)))
+		if w < 0 {
+			t.SetNegative(1)
+		}
+		z.Nnmod(t, n, ctx)
+	}
+	mulMod := func(z, a, b *openssl.BIGNUM) {
+		t.Mul(a, b, ctx)
+		z.Nnmod(t, n, ctx)
+	}
+	// z = a/2 mod n for 0 <= a < 2n.
+	halfMod := func(z, a *openssl.BIGNUM) {
+		z.Nnmod(a, n, ctx)
+		if z.IsOdd() != 0 {
+			z.Add(z, n)
+		}
+		z.Rshift1(z)
+	}
+	// v = v² - 2qk mod n.
+	sqrSub := func() {
+		t.Sqr(v, ctx)
+		t.Sub(t, qk)
+		t.Sub(t, qk)
+		v.Nnmod(t, n, ctx)
+	}
+
+	setMod(dm, D)
+	setMod(qm, (1-D)/4)
+	d.Copy(n)
+	d.AddWord(1)
+	s := 0
+	for d.IsBitSet(c.Int(s)) == 0 {
+		s++
+	}
+	d.Rshift(d, c.Int(s))
+
+	u.SetWord(1)
+	v.SetWord(1)
+	qk.Copy(qm)
+	for i := int(d.NumBits()) - 2; i >= 0; i-- {
+		mulMod(u, u, v)
+		sqrSub()
+		mulMod(qk, qk, qk)
+		if d.IsBitSet(c.Int(i)) != 0 {
+			t2.Add(u, v)
+			mulMod(u2, dm, u)
+			u2.Add(u2, v)
+			halfMod(u, t2)
+			halfMod(v, u2)
+			mulMod(qk, qk, qm)
+		}
+	}
+	if u.IsZero() != 0 || v.IsZero() != 0 {
+		return true
+	}
+	for r := 1; r < s; r++ {
+		sqrSub()
+		if v.IsZero() != 0 {
+			return true
+		}
+		mulMod(qk, qk, qk)
+	}
+	return false
+}
+
+// jacobi returns the Jacobi symbol (a/n) for the odd n > 0, reducing it by
+// quadratic reciprocity to symbols of word-sized numbers.
+func jacobi(a int64, n *openssl.BIGNUM) int {
+	j := 1
+	n8 := uint64(n.ModWord(8))
+	if a < 0 {
+		a = -a
+		if n8%4 == 3 { // (-1/n)
+			j = -j
+		}
+	}
+	for a != 0 && a%2 == 0 {
+		a /= 2
+		if n8 == 3 || n8 == 5 { // (2/n)
+			j = -j
+		}
+	}
+	if a == 0 {
+		return 0
+	}
+	// (a/n) = (n/a) for odd a, unless both are 3 mod 4.
+	if a%4 == 3 && n8%4 == 3 {
+		j = -j
+	}
+	x, y := uint64(n.ModWord(openssl.BN_ULONG(a))), uint64(a)
+	for x != 0 {
+		for x%2 == 0 {
+			x /= 2
+			if y%8 == 3 || y%8 == 5 {
+				j = -j
+			}
+		}
+		x, y = y, x
+		if x%4 == 3 && y%4 == 3 {
+			j = -j
+		}
+		x %= y
+	}
+	if y != 1 {
+		return 0
+	}
+	return j
+}
+
+// isSquare reports whether n > 0 is a perfect square, computing its integer
+// square root by Newton's method from 2**ceil(bits/2), which is above it.
+func isSquare(n *openssl.BIGNUM, ctx *openssl.BN_CTX) bool {
+	x, y := openssl.BNNew(), openssl.BNNew()
+	defer x.Free()
+	defer y.Free()
+	x.SetWord(1)
+	x.Lshift(x, (n.NumBits()+1)/2)
+	for {
+		y.Div(nil, n, x, ctx)
+		y.Add(y, x)
+		y.Rshift1(y)
+		if y.Cmp(x) >= 0 {
+			break
+		}
+		x.Swap(y)
+	}
+	y.Sqr(x, ctx)
+	return y.Cmp(n) == 0
+}
+
+func abs64(x int64) int64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,59 @@
+//go:build gmp && !math_big_pure_go
+
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package big
+
+import c "github.com/goplus/llgo/runtime/internal/clite"
+
+// ProbablyPrime reports whether x is probably prime, applying
+// mpz_probab_prime_p: trial division by small primes, then the Baillie-PSW
+// test and n Miller-Rabin rounds with pseudorandom bases, like math/big.
+// GMP runs the Baillie-PSW test since version 6.2; older versions run 24
+// more Miller-Rabin rounds instead.
+//
+// ProbablyPrime returns false for x <= 1. With GMP 6.2 or later it is, like
+// math/big's, 100% accurate for inputs less than 2⁶⁴.
+//
+// ProbablyPrime panics if n < 0.
+func (x *Int) ProbablyPrime(n int) bool {
+	if n < 0 {
+		panic("negative n for ProbablyPrime")
+	}
+	if x.Sign() <= 0 {
+		return false // mpz_probab_prime_p tests |x|
+	}
+	// mpz_probab_prime_p counts the Baillie-PSW test as 24 of its reps.
+	const maxReps = 1 << 30
+	reps := maxReps
+	if n < maxReps-24 {
+		reps = n + 24
+	}
+	return x.mpz().ProbabPrimeP(c.Int(reps)) != 0
+}
+
+// ProbablyPrimeBPSW reports whether x is probably prime by the Baillie-PSW
+// test: a strong Miller-Rabin test to base 2 and a strong Lucas test. No
+// composite is known to pass both, and none exists below 2⁶⁴, so it is fit
+// for numbers of unknown origin, such as primes supplied by a peer. With the
+// GMP backend it is ProbablyPrime(0), which runs no other test than the
+// Baillie-PSW one after trial division, given GMP 6.2 or later.
+//
+// ProbablyPrimeBPSW returns false for x <= 1. It is an llgo extension with
+// no counterpart in math/big.
+func (x *Int) ProbablyPrimeBPSW() bool {
+	return x.ProbablyPrime(0)
+}
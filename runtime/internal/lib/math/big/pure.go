@@ -0,0 +1,45 @@
+//go:build math_big_pure_go
+
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package big is backed by OpenSSL's BIGNUM by default, or by GMP's mpz_t
+// when built with the gmp tag, which selects the *_gmp.go files and links
+// libgmp instead of OpenSSL; both backends have the same API. Building with
+// the math_big_pure_go tag (the same tag the standard library uses to disable
+// its assembly) drops the native backends: this file is then the only one in
+// the patch, it declares nothing and doesn't skip anything, so the standard
+// pure-Go math/big is compiled unchanged and no library needs to be linked.
+// The llgo-specific additions that need a native backend, such as
+// ExpModBatch and Int.BitField and Int.Free, are not available in this mode.
+// Conversely, the native backends only provide Int and Rat, so programs using
+// Float need this mode for now.
+//
+// The tests of the standard API in test/bigint_test.go and test/bigrat_test.go
+// run against all the backends, those of the additions against OpenSSL and
+// GMP:
+//
+//	llgo test ./test
+//	llgo test -tags gmp ./test
+//	llgo test -tags math_big_pure_go ./test
+//
+// BenchmarkIntLarge in test/bigint_bench_test.go compares the backends on
+// operands of up to a million bits, where GMP's algorithms pay off.
+//
+// Comparison demos in _cmptest can be run against either backend too, e.g.
+//
+//	llgo cmptest -tags math_big_pure_go ./_cmptest/bigintdemo
+package big
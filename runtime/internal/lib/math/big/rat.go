@@ -0,0 +1,466 @@
+//go:build !math_big_pure_go && !gmp
+
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package big
+
+import (
+	"math"
+
+	c "github.com/goplus/llgo/runtime/internal/clite"
+	"github.com/goplus/llgo/runtime/internal/clite/openssl"
+)
+
+// A Rat represents a quotient a/b of arbitrary precision.
+// The zero value for a Rat represents the value 0.
+//
+// Operations always take pointer arguments (*Rat) rather
+// than Rat values, and each unique Rat value requires
+// its own unique *Rat pointer. To "copy" a Rat value,
+// an existing (or newly allocated) Rat must be set to
+// a new value using the Rat.Set method; shallow copies
+// of Rats are not supported and may lead to errors.
+type Rat struct {
+	// To make zero values for Rat work w/o initialization,
+	// a zero value of b acts like b == 1. At the earliest
+	// opportunity (when an assignment to the Rat is made),
+	// such uninitialized denominators are set to 1.
+	// a determines the sign of the Rat, b is never negative.
+	a, b Int
+}
+
+// bnOne stands in for the denominator of Rats whose b is still 0. It is only
+// ever read.
+var bnOne = func() *openssl.BIGNUM {
+	one := openssl.BNNew()
+	one.SetWord(1)
+	return one
+}()
+
+// denom returns the BIGNUM holding the denominator of x.
+func (x *Rat) denom() *openssl.BIGNUM {
+	if b := x.b.bn(); b.IsZero() == 0 {
+		return b
+	}
+	return bnOne
+}
+
+// NewRat creates a new Rat with numerator a and denominator b.
+func NewRat(a, b int64) *Rat {
+	return new(Rat).SetFrac64(a, b)
+}
+
+// SetFrac sets z to a/b and returns z.
+// If b == 0, SetFrac panics.
+func (z *Rat) SetFrac(a, b *Int) *Rat {
+	if b.Sign() == 0 {
+		panic("division by zero")
+	}
+	if b == &z.a {
+		b = new(Int).Set(b) // b is overwritten below
+	}
+	neg := b.Sign() < 0
+	z.a.Set(a)
+	z.b.Abs(b)
+	if neg {
+		z.a.Neg(&z.a)
+	}
+	return z.norm()
+}
+
+// SetFrac64 sets z to a/b and returns z.
+// If b == 0, SetFrac64 panics.
+func (z *Rat) SetFrac64(a, b int64) *Rat {
+	if b == 0 {
+		panic("division by zero")
+	}
+	z.a.SetInt64(a)
+	if b < 0 {
+		b = -b // -math.MinInt64 is still negative, but its uint64 is right
+		z.a.Neg(&z.a)
+	}
+	z.b.SetUint64(uint64(b))
+	return z.norm()
+}
+
+// SetInt sets z to x (by making a copy of x) and returns z.
+func (z *Rat) SetInt(x *Int) *Rat {
+	z.a.Set(x)
+	z.b.SetInt64(1)
+	return z
+}
+
+// SetInt64 sets z to x and returns z.
+func (z *Rat) SetInt64(x int64) *Rat {
+	z.a.SetInt64(x)
+	z.b.SetInt64(1)
+	return z
+}
+
+// SetUint64 sets z to x and returns z.
+func (z *Rat) SetUint64(x uint64) *Rat {
+	z.a.SetUint64(x)
+	z.b.SetInt64(1)
+	return z
+}
+
+// Set sets z to x (by making a copy of x) and returns z.
+func (z *Rat) Set(x *Rat) *Rat {
+	if z != x {
+		z.a.Set(&x.a)
+		z.b.Set(&x.b)
+	}
+	if z.b.Sign() == 0 {
+		z.b.SetInt64(1)
+	}
+	return z
+}
+
+// SetFloat64 sets z to exactly f and returns z.
+// If f is not finite, SetFloat64 returns nil.
+//
+// Every finite float64 is a dyadic rational m·2**e: the 52-bit mantissa
+// field, with the implicit leading 1 of normal numbers, over a power of two,
+// so no rounding is involved.
+func (z *Rat) SetFloat64(f float64) *Rat {
+	const expMask = 1<<11 - 1
+	bits := math.Float64bits(f)
+	mantissa := bits & (1<<52 - 1)
+	exp := int((bits >> 52) & expMask)
+	switch exp {
+	case expMask: // non-finite
+		return nil
+	case 0: // denormal
+		exp -= 1022
+	default: // normal
+		mantissa |= 1 << 52
+		exp -= 1023
+	}
+
+	shift := 52 - exp
+
+	// Optimization (?): partially pre-normalise.
+	for mantissa&1 == 0 && shift > 0 {
+		mantissa >>= 1
+		shift--
+	}
+
+	a, b := z.a.mut(), z.b.mut()
+	a.SetWord(openssl.BN_ULONG(mantissa))
+	if f < 0 {
+		a.SetNegative(1)
+	}
+	b.SetWord(1)
+	if shift > 0 {
+		b.Lshift(b, c.Int(shift))
+	} else if shift < 0 {
+		a.Lshift(a, c.Int(-shift))
+	}
+	return z.norm()
+}
+
+// quotToFloat returns the non-negative float with msize explicit mantissa
+// bits and esize exponent bits that is nearest to |a|/b, rounding half to
+// even, as a mantissa of msize+1 bits and the exponent of its lowest bit.
+// The caller builds the float with math.Ldexp, which may overflow to an
+// infinity. exact reports whether there was no rounding.
+func quotToFloat(a, b *openssl.BIGNUM, msize, esize int) (mantissa uint64, exp int, exact bool) {
+	msize1 := msize + 1 // incl. implicit 1
+	msize2 := msize1 + 1
+	ebias := 1<<(esize-1) - 1
+	emin := 1 - ebias
+
+	alen := int(a.NumBits())
+	if alen == 0 {
+		return 0, 0, true
+	}
+	blen := int(b.NumBits())
+	if blen == 0 {
+		panic("division by zero")
+	}
+
+	// 1. Left-shift A or B such that quotient A/B is in [1<<msize1, 1<<(msize2+1)
+	// (msize2 bits if A < B when they are left-aligned, msize2+1 bits if A >= B).
+	// This is 2 or 3 more than the float mantissa field width of msize:
+	// - the optional extra bit is shifted away in step 3 below.
+	// - the high-order 1 is omitted in "normal" representation;
+	// - the low-order 1 will be used during rounding then discarded.
+	exp = alen - blen
+	a2, b2 := openssl.BNNew(), openssl.BNNew()
+	defer a2.Free()
+	defer b2.Free()
+	a2.Copy(a)
+	a2.SetNegative(0)
+	b2.Copy(b)
+	if shift := msize2 - exp; shift > 0 {
+		a2.Lshift(a2, c.Int(shift))
+	} else if shift < 0 {
+		b2.Lshift(b2, c.Int(-shift))
+	}
+
+	// 2. Compute quotient and remainder (q, r).  NB: due to the
+	// extra shift, the low-order bit of q is logically the
+	// high-order bit of r.
+	ctx := ctxGet()
+	q, r := openssl.BNNew(), openssl.BNNew()
+	q.Div(r, a2, b2, ctx)
+	ctxPut(ctx)
+	mantissa = uint64(q.GetWord()) // q has at most msize2+1 bits
+	haveRem := r.IsZero() == 0     // mantissa&1 && !haveRem => remainder is exactly half
+	q.Free()
+	r.Free()
+
+	// 3. If quotient didn't fit in msize2 bits, redo division by b2<<1
+	// (in effect---we accomplish this incrementally).
+	if mantissa>>msize2 == 1 {
+		if mantissa&1 == 1 {
+			haveRem = true
+		}
+		mantissa >>= 1
+		exp++
+	}
+	if mantissa>>msize1 != 1 {
+		panic("big: quotToFloat: expected exactly msize2 bits of result")
+	}
+
+	// 4. Rounding.
+	if emin-msize <= exp && exp <= emin {
+		// Denormal case; lose 'shift' bits of precision.
+		shift := uint(emin - (exp - 1)) // [1..esize1)
+		lostbits := mantissa & (1<<shift - 1)
+		haveRem = haveRem || lostbits != 0
+		mantissa >>= shift
+		exp = 2 - ebias // == exp + shift
+	}
+	// Round q using round-half-to-even.
+	exact = !haveRem
+	if mantissa&1 != 0 {
+		exact = false
+		if haveRem || mantissa&2 != 0 {
+			if mantissa++; mantissa >= 1<<msize2 {
+				// Complete rollover 11...1 => 100...0, so shift is safe
+				mantissa >>= 1
+				exp++
+			}
+		}
+	}
+	mantissa >>= 1 // discard rounding bit.  Mantissa now scaled by 1<<msize1.
+	return mantissa, exp - msize1, exact
+}
+
+// Float32 returns the nearest float32 value for x and a bool indicating
+// whether f represents x exactly. If the magnitude of x is too large to
+// be represented by a float32, f is an infinity and exact is false.
+// The sign of f always matches the sign of x, even if f == 0.
+func (x *Rat) Float32() (f float32, exact bool) {
+	mantissa, exp, exact := quotToFloat(x.a.bn(), x.denom(), 23, 8)
+	f = float32(math.Ldexp(float64(mantissa), exp))
+	if math.IsInf(float64(f), 0) {
+		exact = false
+	}
+	if x.a.Sign() < 0 {
+		f = -f
+	}
+	return
+}
+
+// Float64 returns the nearest float64 value for x and a bool indicating
+// whether f represents x exactly. If the magnitude of x is too large to
+// be represented by a float64, f is an infinity and exact is false.
+// The sign of f always matches the sign of x, even if f == 0.
+func (x *Rat) Float64() (f float64, exact bool) {
+	mantissa, exp, exact := quotToFloat(x.a.bn(), x.denom(), 52, 11)
+	f = math.Ldexp(float64(mantissa), exp)
+	if math.IsInf(f, 0) {
+		exact = false
+	}
+	if x.a.Sign() < 0 {
+		f = -f
+	}
+	return
+}
+
+// Abs sets z to |x| (the absolute value of x) and returns z.
+func (z *Rat) Abs(x *Rat) *Rat {
+	z.Set(x)
+	z.a.Abs(&z.a)
+	return z
+}
+
+// Neg sets z to -x and returns z.
+func (z *Rat) Neg(x *Rat) *Rat {
+	z.Set(x)
+	z.a.Neg(&z.a)
+	return z
+}
+
+// Inv sets z to 1/x and returns z.
+// If x == 0, Inv panics.
+func (z *Rat) Inv(x *Rat) *Rat {
+	if x.a.Sign() == 0 {
+		panic("division by zero")
+	}
+	z.Set(x)
+	z.a, z.b = z.b, z.a
+	if z.b.Sign() < 0 {
+		z.a.Neg(&z.a)
+		z.b.Neg(&z.b)
+	}
+	return z
+}
+
+// Sign returns:
+//   - -1 if x < 0;
+//   - 0 if x == 0;
+//   - +1 if x > 0.
+func (x *Rat) Sign() int {
+	return x.a.Sign()
+}
+
+// IsInt reports whether the denominator of x is 1.
+func (x *Rat) IsInt() bool {
+	return x.denom().IsOne() != 0
+}
+
+// Num returns the numerator of x; it may be <= 0.
+// The result is a reference to x's numerator; it
+// may change if a new value is assigned to x, and vice versa.
+// The sign of the numerator corresponds to the sign of x.
+func (x *Rat) Num() *Int {
+	return &x.a
+}
+
+// Denom returns the denominator of x; it is always > 0.
+// The result is a reference to x's denominator, unless
+// x is an uninitialized (zero value) Rat, in which case
+// the result is a new Int of value 1. (To initialize x,
+// any operation that sets x will do, including x.Set(x).)
+// If the result is a reference to x's denominator it
+// may change if a new value is assigned to x, and vice versa.
+func (x *Rat) Denom() *Int {
+	if x.b.Sign() == 0 {
+		return NewInt(1)
+	}
+	return &x.b
+}
+
+// norm reduces z to lowest terms, with a positive denominator.
+func (z *Rat) norm() *Rat {
+	a, b := z.a.bn(), z.b.bn()
+	switch {
+	case a.IsZero() != 0, b.IsZero() != 0:
+		// z is 0 or an integer; normalize denominator
+		z.b.mut().SetWord(1)
+	case b.IsOne() == 0:
+		// z is fraction; normalize numerator and denominator
+		ctx := ctxGet()
+		defer ctxPut(ctx)
+		g := openssl.BNNew()
+		defer g.Free()
+		g.Gcd(a, b, ctx)
+		if g.IsOne() == 0 {
+			a, b = z.a.mut(), z.b.mut()
+			a.Div(nil, a, g, ctx)
+			b.Div(nil, b, g, ctx)
+		}
+	}
+	return z
+}
+
+// Cmp compares x and y and returns:
+//   - -1 if x < y;
+//   - 0 if x == y;
+//   - +1 if x > y.
+func (x *Rat) Cmp(y *Rat) int {
+	ctx := ctxGet()
+	defer ctxPut(ctx)
+	a1, a2 := openssl.BNNew(), openssl.BNNew()
+	defer a1.Free()
+	defer a2.Free()
+	a1.Mul(x.a.bn(), y.denom(), ctx)
+	a2.Mul(y.a.bn(), x.denom(), ctx)
+	return int(a1.Cmp(a2))
+}
+
+// Add sets z to the sum x+y and returns z.
+func (z *Rat) Add(x, y *Rat) *Rat {
+	return z.add(x, y, false)
+}
+
+// Sub sets z to the difference x-y and returns z.
+func (z *Rat) Sub(x, y *Rat) *Rat {
+	return z.add(x, y, true)
+}
+
+// add sets z to x+y, or to x-y if sub is set, and returns z.
+func (z *Rat) add(x, y *Rat, sub bool) *Rat {
+	ctx := ctxGet()
+	defer ctxPut(ctx)
+	xb, yb := x.denom(), y.denom()
+	a1, a2 := openssl.BNNew(), openssl.BNNew()
+	defer a1.Free()
+	defer a2.Free()
+	a1.Mul(x.a.bn(), yb, ctx)
+	a2.Mul(y.a.bn(), xb, ctx)
+	// z may alias x or y, whose numerators aren't read after this.
+	if sub {
+		z.a.mut().Sub(a1, a2)
+	} else {
+		z.a.mut().Add(a1, a2)
+	}
+	z.b.mut().Mul(xb, yb, ctx)
+	return z.norm()
+}
+
+// Mul sets z to the product x*y and returns z.
+func (z *Rat) Mul(x, y *Rat) *Rat {
+	ctx := ctxGet()
+	defer ctxPut(ctx)
+	xb, yb := x.denom(), y.denom()
+	if x == y {
+		// a squared Rat is in lowest terms already
+		z.a.mut().Sqr(x.a.bn(), ctx)
+		z.b.mut().Sqr(xb, ctx)
+		return z
+	}
+	z.a.mut().Mul(x.a.bn(), y.a.bn(), ctx)
+	z.b.mut().Mul(xb, yb, ctx)
+	return z.norm()
+}
+
+// Quo sets z to the quotient x/y and returns z.
+// If y == 0, Quo panics.
+func (z *Rat) Quo(x, y *Rat) *Rat {
+	if y.a.Sign() == 0 {
+		panic("division by zero")
+	}
+	ctx := ctxGet()
+	defer ctxPut(ctx)
+	a, b := openssl.BNNew(), openssl.BNNew()
+	defer a.Free()
+	defer b.Free()
+	a.Mul(x.a.bn(), y.denom(), ctx)
+	b.Mul(x.denom(), y.a.bn(), ctx)
+	if b.IsNegative() != 0 {
+		b.SetNegative(0)
+		a.SetNegative(1 - a.IsNegative())
+	}
+	// z may alias x or y, which aren't read after this.
+	z.a.mut().Copy(a)
+	z.b.mut().Copy(b)
+	return z.norm()
+}
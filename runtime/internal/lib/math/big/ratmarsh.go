@@ -0,0 +1,102 @@
+//go:build !math_big_pure_go
+
+/*
+ * Copyright (c) 2024 The GoPlus Authors (goplus.org). All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package big
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Gob codec version. Permits backward-compatible changes to the encoding.
+const ratGobVersion byte = 1
+
+// GobEncode implements the [encoding/gob.GobEncoder] interface.
+//
+// The encoding is a version/sign byte, the big-endian length of the
+// numerator in 4 bytes, and the big-endian magnitudes of the numerator and
+// the denominator, compatible with the standard library.
+func (x *Rat) GobEncode() ([]byte, error) {
+	if x == nil {
+		return nil, nil
+	}
+	num, den := x.a.Bytes(), x.b.Bytes()
+	if int(uint32(len(num))) != len(num) {
+		// this should never happen
+		return nil, errors.New("Rat.GobEncode: numerator too large")
+	}
+	buf := make([]byte, 1+4, 1+4+len(num)+len(den)) // extra bytes for version and sign bit (1), and numerator length (4)
+	b := ratGobVersion << 1                         // make space for sign bit
+	if x.a.Sign() < 0 {
+		b |= 1
+	}
+	buf[0] = b
+	binary.BigEndian.PutUint32(buf[1:], uint32(len(num)))
+	buf = append(buf, num...)
+	return append(buf, den...), nil
+}
+
+// GobDecode implements the [encoding/gob.GobDecoder] interface.
+func (z *Rat) GobDecode(buf []byte) error {
+	if len(buf) == 0 {
+		// Other side sent a nil or default value.
+		z.a.SetInt64(0)
+		z.b.SetInt64(1)
+		return nil
+	}
+	if len(buf) < 5 {
+		return errors.New("Rat.GobDecode: buffer too small")
+	}
+	b := buf[0]
+	if b>>1 != ratGobVersion {
+		return fmt.Errorf("Rat.GobDecode: encoding version %d not supported", b>>1)
+	}
+	const j = 1 + 4
+	ln := binary.BigEndian.Uint32(buf[j-4 : j])
+	if uint64(ln) > math.MaxInt-j {
+		return errors.New("Rat.GobDecode: invalid length")
+	}
+	i := j + int(ln)
+	if len(buf) < i {
+		return errors.New("Rat.GobDecode: buffer too small")
+	}
+	z.a.SetBytes(buf[j:i])
+	if b&1 != 0 {
+		z.a.Neg(&z.a)
+	}
+	z.b.SetBytes(buf[i:])
+	return nil
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (x *Rat) MarshalText() (text []byte, err error) {
+	if x.IsInt() {
+		return x.a.MarshalText()
+	}
+	return x.marshal(), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (z *Rat) UnmarshalText(text []byte) error {
+	if _, ok := z.SetString(string(text)); !ok {
+		return fmt.Errorf("math/big: cannot unmarshal %q into a *big.Rat", text)
+	}
+	return nil
+}
@@ -6,7 +6,59 @@
 
 package runtime
 
+import (
+	"unsafe"
+
+	"github.com/goplus/llgo/runtime/abi"
+)
+
+type eface struct {
+	_type *abi.Type
+	data  unsafe.Pointer
+}
+
+// SetFinalizer sets the finalizer associated with obj to the provided
+// finalizer function. When the garbage collector finds an unreachable block
+// with an associated finalizer, it clears the association and runs
+// finalizer(obj). SetFinalizer(obj, nil) clears any finalizer associated
+// with obj.
+//
+// obj must be a pointer to an object allocated by calling new, by taking the
+// address of a composite literal, or by taking the address of a local
+// variable. finalizer must be a function that takes a single pointer
+// argument, to which obj's type can be assigned, and has no results.
+//
+// The finalizers are run by bdwgc, on the thread whose allocation triggered
+// the collection, and in dependency order: if A points at B and both have
+// finalizers, A's finalizer runs first, and B's once A has been freed.
+// Without the garbage collector, as with -tags nogc, they never run.
 func SetFinalizer(obj any, finalizer any) {
-	// TODO(xsw):
-	// panic("todo: runtime.SetFinalizer")
+	e := (*eface)(unsafe.Pointer(&obj))
+	etyp := e._type
+	if etyp == nil {
+		panic("runtime.SetFinalizer: first argument is nil")
+	}
+	if etyp.Kind() != abi.Pointer {
+		panic("runtime.SetFinalizer: first argument is " + etyp.String() + ", not pointer")
+	}
+	if e.data == nil {
+		panic("runtime.SetFinalizer: pointer is nil")
+	}
+	f := (*eface)(unsafe.Pointer(&finalizer))
+	ftyp := f._type
+	if ftyp == nil {
+		setFinalizer(e.data, nil)
+		return
+	}
+	if !ftyp.IsClosure() {
+		panic("runtime.SetFinalizer: second argument is " + ftyp.String() + ", not a function")
+	}
+	// A func value is a closure, whose function takes the context of the
+	// closure before the arguments: all the finalizers of a pointer with no
+	// results can be called as a func(unsafe.Pointer).
+	ft := ftyp.StructType().Fields[0].Typ.FuncType()
+	if len(ft.In) != 1 || len(ft.Out) != 0 || ft.In[0].Kind() != abi.Pointer && ft.In[0].Kind() != abi.UnsafePointer {
+		panic("runtime.SetFinalizer: cannot pass " + etyp.String() + " to finalizer " + ftyp.String())
+	}
+	setFinalizer(e.data, (*func(unsafe.Pointer))(f.data))
 }
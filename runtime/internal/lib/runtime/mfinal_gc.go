@@ -0,0 +1,25 @@
+//go:build !nogc
+
+package runtime
+
+import (
+	"unsafe"
+
+	c "github.com/goplus/llgo/runtime/internal/clite"
+	"github.com/goplus/llgo/runtime/internal/clite/bdwgc"
+)
+
+// setFinalizer registers fn to run with obj once the collector finds obj
+// unreachable, or unregisters the finalizer of obj if fn is nil. fn is the
+// client data of the bdwgc finalizer, which the collector keeps alive.
+func setFinalizer(obj unsafe.Pointer, fn *func(unsafe.Pointer)) {
+	if fn == nil {
+		bdwgc.RegisterFinalizer(obj, nil, nil, nil, nil)
+		return
+	}
+	bdwgc.RegisterFinalizer(obj, finalize, c.Pointer(fn), nil, nil)
+}
+
+func finalize(obj, cd c.Pointer) {
+	(*(*func(unsafe.Pointer))(cd))(obj)
+}
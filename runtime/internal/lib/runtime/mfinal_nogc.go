@@ -0,0 +1,9 @@
+//go:build nogc
+
+package runtime
+
+import "unsafe"
+
+// setFinalizer does nothing without the garbage collector, which never finds
+// obj unreachable.
+func setFinalizer(obj unsafe.Pointer, fn *func(unsafe.Pointer)) {}
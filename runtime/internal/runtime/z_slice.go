@@ -122,6 +122,17 @@ func SliceCopy(dst Slice, data unsafe.Pointer, num int, etSize int) int {
 	return n
 }
 
+// BytesFrom returns a new byte slice holding a copy of the n bytes at data.
+// The result is never nil, even if n is 0. It implements c.GoBytes.
+func BytesFrom(data unsafe.Pointer, n int) Slice {
+	if n == 0 {
+		return Slice{unsafe.Pointer(&ZeroVal[0]), 0, 0}
+	}
+	s := Slice{AllocU(uintptr(n)), n, n}
+	c.Memcpy(s.data, data, uintptr(n))
+	return s
+}
+
 func MakeSlice(len, cap int, etSize int) Slice {
 	mem, overflow := math.MulUintptr(uintptr(etSize), uintptr(cap))
 	if overflow || mem > maxAlloc || len < 0 || len > cap {
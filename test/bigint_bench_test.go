@@ -0,0 +1,214 @@
+//go:build llgo
+// +build llgo
+
+package test
+
+import (
+	"math/big"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+// Operand sizes, in bits, of the size-sweeping benchmarks below.
+var benchBits = []int{64, 256, 1024, 4096, 16384}
+
+// benchInt returns an Int of exactly bits bits, a multiple of 8, derived
+// from seed alone so that every run benchmarks the same operands.
+func benchInt(seed int64, bits int) *big.Int {
+	buf := make([]byte, bits/8)
+	rand.New(rand.NewSource(seed)).Read(buf)
+	buf[0] |= 0x80
+	return new(big.Int).SetBytes(buf)
+}
+
+func BenchmarkIntMulBits(b *testing.B) {
+	for _, bits := range benchBits {
+		x, y := benchInt(1, bits), benchInt(2, bits)
+		z := new(big.Int)
+		b.Run(strconv.Itoa(bits)+"bits", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				z.Mul(x, y)
+			}
+		})
+	}
+}
+
+// BenchmarkIntMulSchoolbook is the baseline for BenchmarkIntMulBits: the
+// quadratic multiplication of 32-bit limbs in Go, with no Karatsuba step.
+func BenchmarkIntMulSchoolbook(b *testing.B) {
+	for _, bits := range benchBits {
+		x, y := benchInt(1, bits), benchInt(2, bits)
+		if schoolbookMul(x, y).Cmp(new(big.Int).Mul(x, y)) != 0 {
+			b.Fatalf("%d bits: schoolbook product doesn't match Mul", bits)
+		}
+		b.Run(strconv.Itoa(bits)+"bits", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				schoolbookMul(x, y)
+			}
+		})
+	}
+}
+
+// schoolbookMul returns |x|*|y|.
+func schoolbookMul(x, y *big.Int) *big.Int {
+	a, c := limbs(x), limbs(y)
+	z := make([]uint32, len(a)+len(c))
+	for i, ai := range a {
+		var carry uint64
+		for j, cj := range c {
+			t := uint64(ai)*uint64(cj) + uint64(z[i+j]) + carry
+			z[i+j] = uint32(t)
+			carry = t >> 32
+		}
+		z[i+len(c)] = uint32(carry)
+	}
+	buf := make([]byte, 4*len(z))
+	for i, w := range z {
+		n := len(buf) - 4*i
+		buf[n-4], buf[n-3], buf[n-2], buf[n-1] = byte(w>>24), byte(w>>16), byte(w>>8), byte(w)
+	}
+	return new(big.Int).SetBytes(buf)
+}
+
+// limbs returns |x| as little-endian 32-bit limbs.
+func limbs(x *big.Int) []uint32 {
+	buf := x.Bytes()
+	w := make([]uint32, (len(buf)+3)/4)
+	for i := range buf {
+		b := buf[len(buf)-1-i]
+		w[i/4] |= uint32(b) << (8 * (i % 4))
+	}
+	return w
+}
+
+// BenchmarkIntExpBits computes x**y mod m with all three of the given size;
+// m is odd, as for RSA and DH, so that OpenSSL uses Montgomery reduction.
+func BenchmarkIntExpBits(b *testing.B) {
+	for _, bits := range benchBits {
+		x, y, m := benchInt(1, bits), benchInt(2, bits), benchInt(3, bits)
+		m.Or(m, big.NewInt(1))
+		x.Mod(x, m)
+		z := new(big.Int)
+		b.Run(strconv.Itoa(bits)+"bits", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				z.Exp(x, y, m)
+			}
+		})
+	}
+}
+
+// BenchmarkIntDivBits divides a dividend of twice the given size by a
+// divisor of that size, as when reducing a product.
+func BenchmarkIntDivBits(b *testing.B) {
+	for _, bits := range benchBits {
+		x, y := benchInt(1, 2*bits), benchInt(2, bits)
+		q, r := new(big.Int), new(big.Int)
+		b.Run(strconv.Itoa(bits)+"bits", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				q.QuoRem(x, y, r)
+			}
+		})
+	}
+}
+
+// Shift counts of BenchmarkIntLsh and BenchmarkIntRsh: whole words, which
+// only move words, and counts that also shift bits within the words.
+var benchShifts = []uint{64, 1024, 1000, 4097}
+
+func BenchmarkIntLsh(b *testing.B) {
+	for _, bits := range benchBits[2:] {
+		x := benchInt(1, bits)
+		z := new(big.Int)
+		for _, n := range benchShifts {
+			b.Run(strconv.Itoa(bits)+"bits/"+strconv.Itoa(int(n)), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					z.Lsh(x, n)
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkIntRsh also shifts negative operands, which Rsh rounds towards
+// -Inf: the result is one less than the negated shift of |x| whenever a
+// 1 bit is shifted out.
+func BenchmarkIntRsh(b *testing.B) {
+	for _, bits := range benchBits[2:] {
+		x := benchInt(1, bits)
+		neg := new(big.Int).Neg(x)
+		z := new(big.Int)
+		for _, n := range benchShifts {
+			// Div rounds towards -Inf too for a positive divisor.
+			if want := new(big.Int).Div(neg, new(big.Int).Lsh(big.NewInt(1), n)); z.Rsh(neg, n).Cmp(want) != 0 {
+				b.Fatalf("%d bits: -x>>%d = %v, want %v", bits, n, z, want)
+			}
+			b.Run(strconv.Itoa(bits)+"bits/"+strconv.Itoa(int(n)), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					z.Rsh(x, n)
+				}
+			})
+			b.Run(strconv.Itoa(bits)+"bits/neg/"+strconv.Itoa(int(n)), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					z.Rsh(neg, n)
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkIntCmp compares values of wildly different sizes and signs, as a
+// sort of mixed values does, and equal values, which BN_cmp compares word by
+// word.
+func BenchmarkIntCmp(b *testing.B) {
+	var xs []*big.Int
+	for i, bits := range benchBits {
+		x := benchInt(int64(i), bits)
+		xs = append(xs, x, new(big.Int).Neg(x))
+	}
+	b.Run("mixed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			xs[i%len(xs)].Cmp(xs[(i*7+3)%len(xs)])
+		}
+	})
+	x := benchInt(1, 4096)
+	y := new(big.Int).Set(x)
+	b.Run("equal", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x.Cmp(y)
+		}
+	})
+}
+
+// Operand sizes, in bits, of BenchmarkIntLarge.
+var benchLargeBits = []int{1 << 14, 1 << 17, 1 << 20}
+
+// BenchmarkIntLarge times the operations whose cost grows fastest with the
+// size of the operands, where the subquadratic algorithms of GMP set the
+// backends apart: compare them by running it with the default backend and
+// with -tags gmp (or math_big_pure_go). The divisor has half the bits of
+// the dividend.
+func BenchmarkIntLarge(b *testing.B) {
+	for _, bits := range benchLargeBits {
+		x, y := benchInt(1, bits), benchInt(2, bits)
+		d := benchInt(3, bits/2)
+		z, r := new(big.Int), new(big.Int)
+		name := strconv.Itoa(bits) + "bits"
+		b.Run("Mul/"+name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				z.Mul(x, y)
+			}
+		})
+		b.Run("QuoRem/"+name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				z.QuoRem(x, d, r)
+			}
+		})
+		b.Run("String/"+name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				z.Set(x) // a new value each time, as String memoizes its text
+				_ = z.String()
+			}
+		})
+	}
+}
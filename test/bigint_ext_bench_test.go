@@ -0,0 +1,130 @@
+//go:build llgo && !math_big_pure_go
+// +build llgo,!math_big_pure_go
+
+package test
+
+import (
+	"math/big"
+	"strconv"
+	"testing"
+)
+
+// BenchmarkIntScale10 scales a 128-bit fixed-point value by the powers of
+// ten it takes from the cache, against building each power with Exp.
+func BenchmarkIntScale10(b *testing.B) {
+	x := benchInt(1, 128)
+	z := new(big.Int)
+	for _, n := range []int{6, -6, 38, -38} {
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				z.Scale10(x, n)
+			}
+		})
+		b.Run(strconv.Itoa(n)+"/Exp", func(b *testing.B) {
+			ten, p := big.NewInt(10), new(big.Int)
+			k := big.NewInt(int64(n))
+			k.Abs(k)
+			for i := 0; i < b.N; i++ {
+				p.Exp(ten, k, nil)
+				if n > 0 {
+					z.Mul(x, p)
+				} else {
+					z.Quo(x, p)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkIntAndInt64 masks the low 32 bits of a large value, with AndInt64
+// and with And on an Int mask.
+func BenchmarkIntAndInt64(b *testing.B) {
+	x := benchInt(1, 4096)
+	z := new(big.Int)
+	b.Run("AndInt64", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			z.AndInt64(x, 0xffffffff)
+		}
+	})
+	b.Run("And", func(b *testing.B) {
+		b.ReportAllocs()
+		m := big.NewInt(0xffffffff)
+		for i := 0; i < b.N; i++ {
+			z.And(x, m)
+		}
+	})
+}
+
+// BenchmarkIntExpWindow computes x**y mod m for 2048-bit operands with each
+// window of ExpWindow, against Exp and its sliding window.
+// BenchmarkExpModBatch raises 64 bases to a power modulo the same 2048-bit
+// odd modulus, with ExpModBatch and with Exp for each base: for the public
+// exponent 65537, as when verifying RSA signatures, and for a 2048-bit one.
+func BenchmarkExpModBatch(b *testing.B) {
+	m := benchInt(3, 2048)
+	m.Or(m, big.NewInt(1))
+	bases := make([]*big.Int, 64)
+	for i := range bases {
+		bases[i] = benchInt(int64(10+i), 2048)
+		bases[i].Mod(bases[i], m)
+	}
+	for _, e := range []*big.Int{big.NewInt(65537), benchInt(2, 2048)} {
+		name := "e" + strconv.Itoa(e.BitLen()) + "bits"
+		b.Run(name+"/Exp", func(b *testing.B) {
+			z := new(big.Int)
+			for i := 0; i < b.N; i++ {
+				for _, x := range bases {
+					z.Exp(x, e, m)
+				}
+			}
+		})
+		b.Run(name+"/ExpModBatch", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				big.ExpModBatch(bases, e, m)
+			}
+		})
+	}
+}
+
+func BenchmarkIntExpWindow(b *testing.B) {
+	x, y, m := benchInt(1, 2048), benchInt(2, 2048), benchInt(3, 2048)
+	m.Or(m, big.NewInt(1))
+	x.Mod(x, m)
+	z := new(big.Int)
+	b.Run("Exp", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z.Exp(x, y, m)
+		}
+	})
+	for window := 1; window <= 8; window++ {
+		b.Run("window"+strconv.Itoa(window), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				z.ExpWindow(x, y, m, window)
+			}
+		})
+	}
+}
+
+// BenchmarkBatchModInverse inverts 256 elements modulo 2**255-19, with one
+// ModInverse each and with BatchModInverse.
+func BenchmarkBatchModInverse(b *testing.B) {
+	p := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+	xs := make([]*big.Int, 256)
+	for i := range xs {
+		xs[i] = benchInt(int64(i), 248)
+	}
+	b.Run("ModInverse", func(b *testing.B) {
+		z := new(big.Int)
+		for i := 0; i < b.N; i++ {
+			for _, x := range xs {
+				z.ModInverse(x, p)
+			}
+		}
+	})
+	b.Run("Batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			big.BatchModInverse(xs, p)
+		}
+	})
+}
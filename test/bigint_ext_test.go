@@ -0,0 +1,988 @@
+//go:build llgo && !math_big_pure_go
+// +build llgo,!math_big_pure_go
+
+// Tests of the llgo additions to math/big, which the OpenSSL and GMP backends
+// provide. The tests of the standard API in bigint_test.go run against all
+// the backends.
+
+package test
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestIntSetSecure(t *testing.T) {
+	key, _ := new(big.Int).SetString("123456789012345678901234567890123456789", 10)
+	z := new(big.Int).SetSecure(true)
+	if !z.IsSecure() {
+		t.Fatal("IsSecure() = false after SetSecure(true)")
+	}
+	if z.Set(key); !z.IsSecure() || z.Cmp(key) != 0 {
+		t.Fatalf("Set: got %v, secure %v", z, z.IsSecure())
+	}
+	if z.Add(z, key); !z.IsSecure() {
+		t.Fatal("Add into a secure Int cleared the mark")
+	}
+	if y := new(big.Int).Add(z, key); y.IsSecure() {
+		t.Fatal("result of reading a secure Int is secure")
+	}
+	if s1, s2 := z.String(), z.String(); s1 != s2 {
+		t.Fatalf("String() = %s, then %s", s1, s2)
+	}
+
+	// The scrubbing itself happens inside BN_clear_free and can't be
+	// observed without reading freed memory; check what Free leaves behind.
+	z.Free()
+	if z.Sign() != 0 || !z.IsSecure() {
+		t.Fatalf("after Free: got %v, secure %v", z, z.IsSecure())
+	}
+	z.SetInt64(42)
+	if z.SetSecure(false); z.IsSecure() || z.Cmp(big.NewInt(42)) != 0 {
+		t.Fatalf("SetSecure(false): got %v, secure %v", z, z.IsSecure())
+	}
+}
+
+func TestIntZero(t *testing.T) {
+	secret := func() *big.Int {
+		x := new(big.Int).Lsh(big.NewInt(1), 320)
+		x.Sub(x, big.NewInt(1)) // five words of all ones
+		_ = x.String()          // memoized
+		return x
+	}
+	nonzero := func(w []uint64) (n int) {
+		for _, v := range w {
+			if v != 0 {
+				n++
+			}
+		}
+		return n
+	}
+
+	// The words are read from the BIGNUM or mpz_t itself: SetInt64(0)
+	// leaves all but the lowest of them, which Zero must not.
+	x := secret()
+	w := valueWords(x)
+	if len(w) < 5 || nonzero(w) < 5 {
+		t.Fatalf("2**320-1 has %d of %d words set", nonzero(w), len(w))
+	}
+	x.SetInt64(0)
+	if nonzero(w) != 4 {
+		t.Fatalf("SetInt64(0) left %d nonzero words, want 4: the layout isn't the expected one", nonzero(w))
+	}
+
+	x = secret()
+	w = valueWords(x)
+	if z := x.Zero(); z != x || x.Sign() != 0 || x.String() != "0" {
+		t.Fatalf("Zero() = %v, %v", z, x)
+	}
+	if n := nonzero(w); n != 0 {
+		t.Errorf("Zero left %d of %d words nonzero", n, len(w))
+	}
+	if &valueWords(x)[0] != &w[0] {
+		t.Error("Zero reallocated the words")
+	}
+	if x.Add(x, big.NewInt(7)); x.Cmp(big.NewInt(7)) != 0 {
+		t.Errorf("Add after Zero = %v", x)
+	}
+
+	if z := new(big.Int).Zero(); z.Sign() != 0 {
+		t.Errorf("Zero of a new Int = %v", z)
+	}
+	x = secret().SetSecure(true)
+	if x.Zero(); x.Sign() != 0 || !x.IsSecure() {
+		t.Errorf("Zero of a secure Int: %v, secure %v", x, x.IsSecure())
+	}
+}
+
+func TestSortInts(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	negHuge := new(big.Int).Neg(huge)
+	x := []*big.Int{huge, big.NewInt(3), nil, big.NewInt(0), negHuge, big.NewInt(-7), nil, big.NewInt(3)}
+	big.SortInts(x)
+	want := []string{"<nil>", "<nil>", negHuge.String(), "-7", "0", "3", "3", huge.String()}
+	for i, v := range x {
+		if got := v.String(); got != want[i] {
+			t.Fatalf("x[%d] = %s, want %s", i, got, want[i])
+		}
+	}
+	if !sort.IsSorted(big.IntSlice(x)) {
+		t.Fatal("IsSorted = false after SortInts")
+	}
+}
+
+func TestIntKey(t *testing.T) {
+	zero := new(big.Int)
+	negZero := new(big.Int).Neg(zero)
+	if zero.Key() != negZero.Key() || zero.Key() != big.NewInt(5).Sub(big.NewInt(5), big.NewInt(5)).Key() {
+		t.Fatalf("keys of 0 differ: %q, %q", zero.Key(), negZero.Key())
+	}
+	negMinus, _ := new(big.Int).SetString("-0", 10)
+	if negMinus.Key() != zero.Key() {
+		t.Fatalf(`key of "-0" is %q, want %q`, negMinus.Key(), zero.Key())
+	}
+
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	values := []*big.Int{
+		zero, big.NewInt(1), big.NewInt(-1), big.NewInt(255), big.NewInt(256),
+		big.NewInt(-256), huge, new(big.Int).Neg(huge), new(big.Int).Add(huge, big.NewInt(1)),
+	}
+	seen := make(map[string]*big.Int)
+	for _, x := range values {
+		if y := seen[x.Key()]; y != nil {
+			t.Fatalf("%v and %v have the same key %q", x, y, x.Key())
+		}
+		seen[x.Key()] = x
+		if y, _ := new(big.Int).SetString(x.String(), 10); y.Key() != x.Key() {
+			t.Fatalf("key of %v changed after a round trip through String", x)
+		}
+	}
+}
+
+func TestAccumulator(t *testing.T) {
+	var acc big.Accumulator
+	want := new(big.Int)
+	rnd := int64(1)
+	for i := 0; i < 10000; i++ {
+		rnd = rnd*6364136223846793005 + 1442695040888963407 // wraps: any int64 is fair game
+		acc.AddInt64(rnd)
+		want.Add(want, big.NewInt(rnd))
+	}
+	for _, v := range []int64{math.MaxInt64, math.MinInt64, math.MinInt64, -1, 0} {
+		acc.AddInt64(v)
+		want.Add(want, big.NewInt(v))
+	}
+	if got := acc.Sum(); got.Cmp(want) != 0 {
+		t.Fatalf("Sum() = %v, want %v", got, want)
+	}
+	if got := new(big.Accumulator).Sum(); got.Sign() != 0 {
+		t.Fatalf("empty Sum() = %v, want 0", got)
+	}
+}
+
+func BenchmarkAccumulator(b *testing.B) {
+	const n = 10000000
+	for i := 0; i < b.N; i++ {
+		var acc big.Accumulator
+		for v := int64(0); v < n; v++ {
+			acc.AddInt64(math.MaxInt64 - v)
+		}
+		_ = acc.Sum()
+	}
+}
+
+func TestIntPowerOfTwo(t *testing.T) {
+	one := big.NewInt(1)
+	pow := func(n uint) *big.Int { return new(big.Int).Lsh(one, n) }
+	tests := []struct {
+		x    *big.Int
+		is   bool
+		next *big.Int
+	}{
+		{big.NewInt(-8), false, one},
+		{big.NewInt(0), false, one},
+		{one, true, one},
+		{big.NewInt(2), true, big.NewInt(2)},
+		{big.NewInt(3), false, big.NewInt(4)},
+		{big.NewInt(1023), false, big.NewInt(1024)},
+		{big.NewInt(1024), true, big.NewInt(1024)},
+		{big.NewInt(1025), false, big.NewInt(2048)},
+		{pow(200), true, pow(200)},
+		{new(big.Int).Add(pow(200), one), false, pow(201)},
+		{new(big.Int).Sub(pow(200), one), false, pow(200)},
+	}
+	for _, tt := range tests {
+		if got := tt.x.IsPowerOfTwo(); got != tt.is {
+			t.Errorf("IsPowerOfTwo(%v) = %v, want %v", tt.x, got, tt.is)
+		}
+		if got := new(big.Int).NextPowerOfTwo(tt.x); got.Cmp(tt.next) != 0 {
+			t.Errorf("NextPowerOfTwo(%v) = %v, want %v", tt.x, got, tt.next)
+		}
+	}
+	x := big.NewInt(5)
+	if x.NextPowerOfTwo(x); x.Cmp(big.NewInt(8)) != 0 {
+		t.Errorf("in place NextPowerOfTwo(5) = %v, want 8", x)
+	}
+}
+
+func TestBitSet(t *testing.T) {
+	var a, b big.BitSet
+	for _, i := range []uint{0, 3, 64, 65, 1000} {
+		a.Add(i)
+	}
+	for _, i := range []uint{3, 65, 70, 5000} {
+		b.Add(i)
+	}
+	if !a.Contains(64) || a.Contains(63) || a.Count() != 5 {
+		t.Fatalf("Contains(64) = %v, Contains(63) = %v, Count() = %d", a.Contains(64), a.Contains(63), a.Count())
+	}
+	elems := func(s *big.BitSet) (r []uint) {
+		s.Iterate(func(i uint) bool { r = append(r, i); return true })
+		return
+	}
+	eq := func(name string, got, want []uint) {
+		if len(got) != len(want) {
+			t.Fatalf("%s = %v, want %v", name, got, want)
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("%s = %v, want %v", name, got, want)
+			}
+		}
+	}
+	var u, in, diff big.BitSet
+	u.Union(&a)
+	u.Union(&b)
+	eq("union", elems(&u), []uint{0, 3, 64, 65, 70, 1000, 5000})
+	in.Union(&a)
+	in.Intersect(&b)
+	eq("intersection", elems(&in), []uint{3, 65})
+	diff.Union(&a)
+	diff.Difference(&b)
+	eq("difference", elems(&diff), []uint{0, 64, 1000})
+
+	var first []uint
+	u.Iterate(func(i uint) bool { first = append(first, i); return len(first) < 2 })
+	eq("stopped iteration", first, []uint{0, 3})
+	if n := new(big.BitSet).Count(); n != 0 {
+		t.Fatalf("empty Count() = %d", n)
+	}
+}
+
+func BenchmarkBitSetUnion(b *testing.B) {
+	const n = 1000000
+	var x, y big.BitSet
+	for i := uint(0); i < n; i += 3 {
+		x.Add(i)
+	}
+	for i := uint(1); i < n; i += 5 {
+		y.Add(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var u big.BitSet
+		u.Union(&x)
+		u.Union(&y)
+	}
+}
+
+func TestIntTextUpper(t *testing.T) {
+	x, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	for base := 2; base <= big.MaxBase; base++ {
+		want := x.Text(base)
+		if base <= 36 {
+			want = strings.ToUpper(want)
+		}
+		if got := x.TextUpper(base); got != want {
+			t.Errorf("TextUpper(%d) = %q, want %q", base, got, want)
+		}
+	}
+	if got := x.TextUpper(16); got != "-18EE90FF6C373E0EE4E3F0AD2" {
+		t.Errorf("TextUpper(16) = %q", got)
+	}
+	for _, base := range []int{1, 0, 63, -16} {
+		func() {
+			defer func() {
+				if r := recover(); r != "invalid base" {
+					t.Errorf("TextUpper(%d): recovered %v, want \"invalid base\"", base, r)
+				}
+			}()
+			x.TextUpper(base)
+		}()
+	}
+}
+
+// Without a modulus, an exponent of more than 64 bits only has a power that
+// fits in memory for |x| <= 1; the native backends panic for any other x
+// rather than truncate the exponent.
+func TestIntExpHugeExponent(t *testing.T) {
+	y := new(big.Int).Lsh(big.NewInt(1), 64)
+	y1 := new(big.Int).Add(y, big.NewInt(1))
+	for _, tt := range []struct {
+		x, y *big.Int
+		want int64
+	}{
+		{big.NewInt(0), y, 0}, {big.NewInt(1), y, 1}, {big.NewInt(-1), y, 1}, {big.NewInt(-1), y1, -1},
+	} {
+		if got := new(big.Int).Exp(tt.x, tt.y, nil); got.Cmp(big.NewInt(tt.want)) != 0 {
+			t.Errorf("Exp(%v, %v, nil) = %v, want %d", tt.x, tt.y, got, tt.want)
+		}
+	}
+	for _, x := range []int64{2, -2, 3} {
+		func() {
+			defer func() {
+				if r := recover(); r != "math/big: Exp result too large" {
+					t.Errorf("Exp(%d, %v, nil) recovered %v", x, y1, r)
+				}
+			}()
+			new(big.Int).Exp(big.NewInt(x), y1, nil)
+		}()
+	}
+}
+
+func TestIntExpConstTime(t *testing.T) {
+	p, _ := new(big.Int).SetString("ffffffffffffffffc90fdaa22168c234c4c6628b80dc1cd129024e088a67cc74020bbea63b139b22514a08798e3404ddef9519b3cd3a431b302b0a6df25f14374fe1356d6d51c245e485b576625e7ec6f44c42e9a637ed6b0bff5cb6f406b7edee386bfb5a899fa5ae9f24117c4b1fe649286651ece65381ffffffffffffffff", 16)
+	secret, _ := new(big.Int).SetString("1f2e3d4c5b6a79887766554433221100ffeeddccbbaa99887766554433221100", 16)
+	tests := []struct{ x, y, m *big.Int }{
+		{big.NewInt(2), secret, p},
+		{new(big.Int).Neg(secret), secret, p},
+		{new(big.Int).Add(p, big.NewInt(3)), big.NewInt(65537), p},
+		{big.NewInt(4), big.NewInt(13), big.NewInt(497)},
+		{big.NewInt(-4), big.NewInt(13), big.NewInt(-497)},
+		{big.NewInt(7), big.NewInt(0), big.NewInt(9)},
+		{big.NewInt(0), big.NewInt(5), big.NewInt(9)},
+		{big.NewInt(5), big.NewInt(3), big.NewInt(1)},
+	}
+	for _, tt := range tests {
+		want := new(big.Int).Exp(tt.x, tt.y, tt.m)
+		if got := new(big.Int).ExpConstTime(tt.x, tt.y, tt.m); got.Cmp(want) != 0 {
+			t.Errorf("ExpConstTime(%v, %v, %v) = %v, want %v", tt.x, tt.y, tt.m, got, want)
+		}
+	}
+
+	// z may alias the arguments.
+	x, y, m := big.NewInt(4), big.NewInt(13), big.NewInt(497)
+	if x.ExpConstTime(x, y, m); x.Cmp(big.NewInt(445)) != 0 {
+		t.Errorf("aliased ExpConstTime = %v, want 445", x)
+	}
+	if y.ExpConstTime(big.NewInt(4), y, m); y.Cmp(big.NewInt(445)) != 0 {
+		t.Errorf("aliased ExpConstTime = %v, want 445", y)
+	}
+
+	for _, tt := range []struct {
+		y, m *big.Int
+		want string
+	}{
+		{big.NewInt(-1), big.NewInt(9), "math/big: ExpConstTime with negative exponent"},
+		{big.NewInt(3), big.NewInt(10), "math/big: ExpConstTime with even modulus"},
+		{big.NewInt(3), big.NewInt(0), "math/big: ExpConstTime with even modulus"},
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != tt.want {
+					t.Errorf("ExpConstTime(2, %v, %v) recovered %v, want %q", tt.y, tt.m, r, tt.want)
+				}
+			}()
+			new(big.Int).ExpConstTime(big.NewInt(2), tt.y, tt.m)
+		}()
+	}
+}
+
+func TestIntLCM(t *testing.T) {
+	huge, _ := new(big.Int).SetString("340282366920938463463374607431768211456", 10) // 2**128
+	hugeTimes3, _ := new(big.Int).SetString("1020847100762815390390123822295304634368", 10)
+	tests := []struct {
+		x, y *big.Int
+		want string
+	}{
+		{big.NewInt(4), big.NewInt(6), "12"},
+		{big.NewInt(21), big.NewInt(6), "42"},
+		{big.NewInt(7), big.NewInt(13), "91"},
+		{big.NewInt(12), big.NewInt(12), "12"},
+		{big.NewInt(1), big.NewInt(99), "99"},
+		{big.NewInt(-4), big.NewInt(6), "12"},
+		{big.NewInt(-4), big.NewInt(-6), "12"},
+		{big.NewInt(0), big.NewInt(6), "0"},
+		{big.NewInt(6), big.NewInt(0), "0"},
+		{big.NewInt(0), big.NewInt(0), "0"},
+		{huge, big.NewInt(3), hugeTimes3.String()},
+		{huge, big.NewInt(1 << 40), huge.String()},
+		{hugeTimes3, big.NewInt(6), hugeTimes3.String()},
+	}
+	for _, tt := range tests {
+		if got := new(big.Int).LCM(tt.x, tt.y); got.String() != tt.want {
+			t.Errorf("LCM(%v, %v) = %v, want %s", tt.x, tt.y, got, tt.want)
+		}
+	}
+
+	x, y := big.NewInt(4), big.NewInt(6)
+	if x.LCM(x, y); x.String() != "12" {
+		t.Errorf("aliased LCM = %v, want 12", x)
+	}
+	if y.LCM(big.NewInt(4), y); y.String() != "12" {
+		t.Errorf("aliased LCM = %v, want 12", y)
+	}
+}
+
+func TestReducer(t *testing.T) {
+	m, _ := new(big.Int).SetString("fffffffffffffffffffffffffffffffeffffffffffffffff", 16)
+	huge := new(big.Int).Lsh(m, 200)
+	huge.Add(huge, big.NewInt(12345))
+	values := []*big.Int{
+		big.NewInt(0), big.NewInt(1), big.NewInt(-1), big.NewInt(1 << 40),
+		new(big.Int).Set(m), new(big.Int).Neg(m), new(big.Int).Add(m, big.NewInt(1)),
+		huge, new(big.Int).Neg(huge),
+	}
+	for _, mod := range []*big.Int{m, new(big.Int).Neg(m), big.NewInt(7), big.NewInt(1)} {
+		r := big.NewReducer(mod)
+		for _, x := range values {
+			if got, want := r.Mod(x), new(big.Int).Mod(x, mod); got.Cmp(want) != 0 {
+				t.Errorf("Reducer(%v).Mod(%v) = %v, want %v", mod, x, got, want)
+			}
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("NewReducer(0) didn't panic")
+		}
+	}()
+	big.NewReducer(new(big.Int))
+}
+
+// BenchmarkReducer reduces a million random values of twice the size of a
+// 1024-bit modulus, with a Reducer and with Int.Mod, after checking that both
+// agree.
+func BenchmarkReducer(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	buf := make([]byte, 256)
+	rnd.Read(buf[:128])
+	buf[0] |= 0x80
+	m := new(big.Int).SetBytes(buf[:128])
+	xs := make([]*big.Int, 1_000_000)
+	for i := range xs {
+		rnd.Read(buf)
+		xs[i] = new(big.Int).SetBytes(buf)
+	}
+	r := big.NewReducer(m)
+	z := new(big.Int)
+	for _, x := range xs[:1000] {
+		if r.Mod(x).Cmp(z.Mod(x, m)) != 0 {
+			b.Fatalf("Reducer.Mod(%v) doesn't match Int.Mod", x)
+		}
+	}
+
+	b.Run("Reducer", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, x := range xs {
+				r.Mod(x)
+			}
+		}
+	})
+	b.Run("IntMod", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, x := range xs {
+				z.Mod(x, m)
+			}
+		}
+	})
+}
+
+func TestIntFillBytesSigned(t *testing.T) {
+	tests := []struct {
+		x    string
+		want string // hex, its length sets the buffer size
+	}{
+		{"-1", "ffffffff"},
+		{"0", "00000000"},
+		{"1", "00000001"},
+		{"-2", "fffe"},
+		{"127", "7f"},
+		{"-128", "80"},
+		{"2147483647", "7fffffff"},
+		{"-2147483648", "80000000"},
+		{"-2147483649", "ffffffff7fffffff"},
+		{"-1208925819614629174706176", "ff00000000000000000000"}, // -2**80
+		{"0", ""},
+	}
+	for _, tt := range tests {
+		x, _ := new(big.Int).SetString(tt.x, 10)
+		buf := make([]byte, len(tt.want)/2)
+		if got := fmt.Sprintf("%x", x.FillBytesSigned(buf)); got != tt.want {
+			t.Errorf("FillBytesSigned(%s) = %s, want %s", tt.x, got, tt.want)
+		}
+	}
+
+	// The magnitude must fit in the bits left after the sign bit.
+	overflows := []struct {
+		x string
+		n int
+	}{
+		{"128", 1}, {"-129", 1}, {"255", 1}, {"2147483648", 4}, {"-2147483649", 4}, {"1", 0},
+	}
+	for _, tt := range overflows {
+		x, _ := new(big.Int).SetString(tt.x, 10)
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("FillBytesSigned(%s) into %d bytes didn't panic", tt.x, tt.n)
+				}
+			}()
+			x.FillBytesSigned(make([]byte, tt.n))
+		}()
+	}
+}
+
+func TestIntReadText(t *testing.T) {
+	tests := []struct {
+		in   string
+		base int
+		want string // "" for an error
+		n    int64
+		rest string
+	}{
+		{"12345 rest", 10, "12345", 5, " rest"},
+		{"-42", 10, "-42", 3, ""},
+		{"+7\n8", 10, "7", 2, "\n8"},
+		{"0x1f_ff;", 0, "8191", 7, ";"},
+		{"-0b101x", 0, "-5", 6, "x"},
+		{"0778", 0, "63", 3, "8"},
+		{"ff.", 16, "255", 2, "."},
+		{"Zz!", 62, "3817", 2, "!"},
+		{"abc", 10, "", 0, "abc"},
+		{"-", 10, "", 1, ""},
+		{"0x_", 0, "", 3, ""},
+		{"1__0", 0, "", 4, ""},
+	}
+	for _, tt := range tests {
+		r := strings.NewReader(tt.in)
+		z := new(big.Int)
+		n, err := z.ReadText(r, tt.base)
+		rest, _ := io.ReadAll(r)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("ReadText(%q, %d) = %v, want an error", tt.in, tt.base, z)
+			}
+		} else if err != nil || z.String() != tt.want {
+			t.Errorf("ReadText(%q, %d) = %v, %v; want %s", tt.in, tt.base, z, err, tt.want)
+		}
+		if n != tt.n || string(rest) != tt.rest {
+			t.Errorf("ReadText(%q, %d): n = %d, rest %q; want %d, %q", tt.in, tt.base, n, rest, tt.n, tt.rest)
+		}
+	}
+
+	if n, err := new(big.Int).ReadText(strings.NewReader(""), 10); n != 0 || err != io.EOF {
+		t.Errorf("ReadText of empty input = %d, %v; want 0, EOF", n, err)
+	}
+	readErr := errors.New("read failed")
+	r := io.MultiReader(strings.NewReader("123"), iotest.ErrReader(readErr))
+	if n, err := new(big.Int).ReadText(r, 10); n != 3 || err != readErr {
+		t.Errorf("ReadText of failing reader = %d, %v; want 3, %v", n, err, readErr)
+	}
+}
+
+// A number many times longer than the reader's buffer parses as one,
+// whichever way the reader splits it.
+func TestIntReadTextStream(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteByte('-')
+	for i := 0; i < 5000; i++ {
+		sb.WriteByte(byte('0' + (i*7+3)%10))
+	}
+	digits := sb.String()
+	want, _ := new(big.Int).SetString(digits, 10)
+	in := digits + "\nnext"
+
+	tests := []struct {
+		name string
+		r    io.Reader
+		rest string // left in r once the number is read
+	}{
+		{"bufio", bufio.NewReaderSize(strings.NewReader(in), 16), "\nnext"},
+		{"OneByteReader", iotest.OneByteReader(strings.NewReader(in)), "next"},
+		{"HalfReader", iotest.HalfReader(strings.NewReader(in)), "next"},
+		{"DataErrReader", iotest.DataErrReader(strings.NewReader(digits)), ""},
+	}
+	for _, tt := range tests {
+		z := new(big.Int)
+		n, err := z.ReadText(tt.r, 10)
+		if err != nil || z.Cmp(want) != 0 {
+			t.Errorf("%s: ReadText = %v, want the %d-digit number", tt.name, err, len(digits)-1)
+		}
+		if n != int64(len(digits)) {
+			t.Errorf("%s: n = %d, want %d", tt.name, n, len(digits))
+		}
+		if rest, _ := io.ReadAll(tt.r); string(rest) != tt.rest {
+			t.Errorf("%s: rest = %q, want %q", tt.name, rest, tt.rest)
+		}
+	}
+}
+
+func TestIntScale10(t *testing.T) {
+	tests := []struct {
+		x    string
+		n    int
+		want string
+	}{
+		{"0", 5, "0"},
+		{"0", -5, "0"},
+		{"7", 0, "7"},
+		{"7", 3, "7000"},
+		{"-7", 19, "-70000000000000000000"},
+		{"12300", -2, "123"},   // exact
+		{"12345", -2, "123"},   // truncated
+		{"-12345", -2, "-123"}, // toward zero, unlike Div
+		{"-99", -2, "0"},       // no negative zero
+		{"99", -3, "0"},        // shorter than the scale
+		{"5", -19, "0"},
+		{"123456789012345678901", -20, "1"},
+	}
+	for _, tt := range tests {
+		x, _ := new(big.Int).SetString(tt.x, 10)
+		if got := new(big.Int).Scale10(x, tt.n); got.String() != tt.want {
+			t.Errorf("Scale10(%s, %d) = %s, want %s", tt.x, tt.n, got, tt.want)
+		}
+	}
+
+	// Each path, from word-sized powers to ones beyond the cache, against
+	// Mul and Quo with the power built by Exp, aliased or not.
+	xs := []*big.Int{
+		big.NewInt(1), big.NewInt(-987654321),
+		new(big.Int).Lsh(big.NewInt(3), 500),
+		new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(5), 1000)),
+	}
+	ten := big.NewInt(10)
+	for _, n := range []int{1, 18, 19, 20, 21, 64, 126, 127, 128, 129, 300} {
+		p := new(big.Int).Exp(ten, big.NewInt(int64(n)), nil)
+		for _, x := range xs {
+			want := new(big.Int).Mul(x, p)
+			if got := new(big.Int).Scale10(x, n); got.Cmp(want) != 0 {
+				t.Errorf("Scale10(%v, %d) = %v, want %v", x, n, got, want)
+			}
+			want.Quo(x, p)
+			z := new(big.Int).Set(x)
+			if z.Scale10(z, -n); z.Cmp(want) != 0 {
+				t.Errorf("Scale10(%v, %d) = %v, want %v", x, -n, z, want)
+			}
+			if z.Scale10(x, n).Scale10(z, -n); z.Cmp(x) != 0 {
+				t.Errorf("Scale10(Scale10(%v, %d), %d) = %v", x, n, -n, z)
+			}
+		}
+	}
+}
+
+// AndInt64 and OrInt64 agree with And and Or on an Int mask, for both signs
+// of x and mask, across the word boundary and in place.
+func TestIntAndOrInt64(t *testing.T) {
+	masks := []int64{
+		0, 1, -1, 0xff, 0xffffffff, -0x100000000, math.MaxInt64, math.MinInt64,
+		-2, 0x5555555555555555, -0x5555555555555556, 1 << 40,
+	}
+	rnd := rand.New(rand.NewSource(183))
+	for i := 0; i < 20; i++ {
+		masks = append(masks, int64(rnd.Uint64()))
+	}
+	var xs []*big.Int
+	for _, bits := range []uint{0, 1, 31, 63, 64, 65, 100, 300} {
+		buf := make([]byte, bits/8+1)
+		rnd.Read(buf)
+		x := new(big.Int).SetBytes(buf)
+		x.Rsh(x, uint(len(buf)*8)-bits)
+		x.Or(x, new(big.Int).Lsh(big.NewInt(1), bits))
+		xs = append(xs, x, new(big.Int).Neg(x))
+	}
+	// Negative values with low words of all zeros or all ones.
+	xs = append(xs, new(big.Int).Lsh(big.NewInt(-3), 128), new(big.Int).Lsh(big.NewInt(-1), 64))
+	xs = append(xs, new(big.Int).Neg(new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 130), big.NewInt(1))))
+
+	for _, x := range xs {
+		for _, mask := range masks {
+			m := big.NewInt(mask)
+			want := new(big.Int).And(x, m)
+			if got := new(big.Int).AndInt64(x, mask); got.Cmp(want) != 0 {
+				t.Errorf("AndInt64(%v, %#x) = %v, want %v", x, mask, got, want)
+			}
+			if got := new(big.Int).Set(x); got.AndInt64(got, mask).Cmp(want) != 0 {
+				t.Errorf("AndInt64(%v, %#x) in place = %v, want %v", x, mask, got, want)
+			}
+			want.Or(x, m)
+			if got := new(big.Int).OrInt64(x, mask); got.Cmp(want) != 0 {
+				t.Errorf("OrInt64(%v, %#x) = %v, want %v", x, mask, got, want)
+			}
+			if got := new(big.Int).Set(x); got.OrInt64(got, mask).Cmp(want) != 0 {
+				t.Errorf("OrInt64(%v, %#x) in place = %v, want %v", x, mask, got, want)
+			}
+		}
+	}
+}
+
+func TestIntBytes32(t *testing.T) {
+	var want32 [32]byte
+	want32[30], want32[31] = 0x12, 0x34
+	if got := big.NewInt(0x1234).Bytes32(); got != want32 {
+		t.Errorf("Bytes32(0x1234) = %x", got)
+	}
+	if got := big.NewInt(-0x1234).Bytes32(); got != want32 {
+		t.Errorf("Bytes32(-0x1234) = %x, want the absolute value", got)
+	}
+	if got := new(big.Int).Bytes32(); got != [32]byte{} {
+		t.Errorf("Bytes32(0) = %x", got)
+	}
+	var want64 [64]byte
+	want64[63] = 7
+	if got := big.NewInt(7).Bytes64(); got != want64 {
+		t.Errorf("Bytes64(7) = %x", got)
+	}
+
+	// The largest values that fit, and their round trips.
+	max256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	b := max256.Bytes32()
+	for i, v := range b {
+		if v != 0xff {
+			t.Fatalf("Bytes32(2**256-1)[%d] = %#x", i, v)
+		}
+	}
+	if z := new(big.Int).SetBytes32(b); z.Cmp(max256) != 0 {
+		t.Errorf("SetBytes32(Bytes32(2**256-1)) = %v", z)
+	}
+	max512 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 512), big.NewInt(1))
+	if z := new(big.Int).SetBytes64(max512.Bytes64()); z.Cmp(max512) != 0 {
+		t.Errorf("SetBytes64(Bytes64(2**512-1)) = %v", z)
+	}
+	if z := big.NewInt(-5).SetBytes32(want32); z.Cmp(big.NewInt(0x1234)) != 0 {
+		t.Errorf("SetBytes32(0x1234) = %v", z)
+	}
+
+	mustPanic := func(name string, f func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s didn't panic", name)
+			}
+		}()
+		f()
+	}
+	mustPanic("Bytes32(2**256)", func() { new(big.Int).Lsh(big.NewInt(1), 256).Bytes32() })
+	mustPanic("Bytes32(-2**256)", func() { new(big.Int).Lsh(big.NewInt(-1), 256).Bytes32() })
+	mustPanic("Bytes64(2**512)", func() { new(big.Int).Lsh(big.NewInt(1), 512).Bytes64() })
+}
+
+func TestIntTextGrouped(t *testing.T) {
+	tests := []struct {
+		x     string
+		base  int
+		sep   byte
+		group int
+		want  string
+	}{
+		{"1234567", 10, ',', 3, "1,234,567"},
+		{"-1234567", 10, ',', 3, "-1,234,567"},
+		{"123456", 10, ',', 3, "123,456"},
+		{"-123456", 10, ',', 3, "-123,456"},
+		{"12", 10, ',', 3, "12"},
+		{"-12", 10, ',', 3, "-12"},
+		{"-1", 10, ',', 3, "-1"},
+		{"0", 10, ',', 3, "0"},
+		{"1000", 10, '_', 3, "1_000"},
+		{"1234567", 10, ',', 1, "1,2,3,4,5,6,7"},
+		{"1234567", 10, ',', 0, "1234567"},
+		{"-1234567", 10, ',', -2, "-1234567"},
+		{"-4294967295", 16, ' ', 4, "-ffff ffff"},
+		{"65535", 16, ' ', 4, "ffff"},
+		{"255", 2, '.', 4, "1111.1111"},
+	}
+	for _, tt := range tests {
+		x, _ := new(big.Int).SetString(tt.x, 10)
+		if got := x.TextGrouped(tt.base, tt.sep, tt.group); got != tt.want {
+			t.Errorf("%s.TextGrouped(%d, %q, %d) = %q, want %q", tt.x, tt.base, tt.sep, tt.group, got, tt.want)
+		}
+	}
+	if s := (*big.Int)(nil).TextGrouped(10, ',', 3); s != "<nil>" {
+		t.Errorf("nil TextGrouped = %q", s)
+	}
+}
+
+// ExpModBatch gives the results of Exp for each base, on its Montgomery path
+// and on the inputs where it falls back to Exp: an even, zero or nil modulus
+// and an exponent <= 0.
+func TestExpModBatch(t *testing.T) {
+	one := big.NewInt(1)
+	odd := new(big.Int).Or(benchInt(3, 1024), one)
+	mods := []*big.Int{
+		odd, new(big.Int).Neg(odd), big.NewInt(97), big.NewInt(-97), one, big.NewInt(-1),
+		new(big.Int).Lsh(benchInt(4, 512), 1), big.NewInt(1 << 40), big.NewInt(-10), new(big.Int), nil,
+	}
+	exps := []*big.Int{one, big.NewInt(2), big.NewInt(65537), benchInt(5, 256), new(big.Int), big.NewInt(-1), big.NewInt(-3)}
+	for _, m := range mods {
+		for _, e := range exps {
+			if (m == nil || m.Sign() == 0) && e.BitLen() > 2 {
+				continue // far too large without a modulus
+			}
+			bases := []*big.Int{
+				new(big.Int), one, big.NewInt(-1), big.NewInt(2), big.NewInt(-12345), big.NewInt(97),
+				benchInt(1, 1024), new(big.Int).Neg(benchInt(2, 2048)),
+			}
+			var want []*big.Int
+			for _, x := range bases {
+				want = append(want, new(big.Int).Exp(x, e, m))
+			}
+			got := big.ExpModBatch(bases, e, m)
+			if len(got) != len(bases) {
+				t.Fatalf("mod %v, exp %v: %d results for %d bases", m, e, len(got), len(bases))
+			}
+			for i := range bases {
+				if (got[i] == nil) != (want[i] == nil) || got[i] != nil && got[i].Cmp(want[i]) != 0 {
+					t.Errorf("%v**%v mod %v = %v, want %v", bases[i], e, m, got[i], want[i])
+				}
+			}
+		}
+	}
+	if got := big.ExpModBatch(nil, big.NewInt(3), odd); len(got) != 0 {
+		t.Errorf("no bases: %v", got)
+	}
+}
+
+func TestIntExpWindow(t *testing.T) {
+	m := new(big.Int).Lsh(benchInt(3, 256), 100)
+	m.Add(m, big.NewInt(12345)) // odd, with a run of zero words
+	tests := []struct{ x, y, m *big.Int }{
+		{benchInt(1, 256), benchInt(2, 256), m},
+		{benchInt(1, 512), benchInt(2, 520), m},
+		{new(big.Int).Neg(benchInt(1, 64)), big.NewInt(65537), m},
+		{new(big.Int).Neg(benchInt(1, 64)), big.NewInt(65537), new(big.Int).Neg(m)},
+		{big.NewInt(0), big.NewInt(0), m},
+		{big.NewInt(7), big.NewInt(0), m},
+		{big.NewInt(7), big.NewInt(1), m},
+		{new(big.Int).Set(m), big.NewInt(3), m},
+		{big.NewInt(7), big.NewInt(100), big.NewInt(1)},
+		{big.NewInt(7), big.NewInt(100), big.NewInt(1000)}, // even: Exp
+		{big.NewInt(7), big.NewInt(100), nil},              // no modulus: Exp
+		{big.NewInt(3), big.NewInt(-1), big.NewInt(7)},     // negative: Exp
+	}
+	for i, tt := range tests {
+		want := new(big.Int).Exp(tt.x, tt.y, tt.m)
+		for window := -1; window <= 14; window++ {
+			if got := new(big.Int).ExpWindow(tt.x, tt.y, tt.m, window); got.Cmp(want) != 0 {
+				t.Errorf("%d: ExpWindow with window %d = %v, want %v", i, window, got, want)
+			}
+		}
+	}
+
+	// The result may alias the operands.
+	x, y := benchInt(1, 256), benchInt(2, 256)
+	want := new(big.Int).Exp(x, y, m)
+	z := new(big.Int).Set(x)
+	if z.ExpWindow(z, y, m, 5); z.Cmp(want) != 0 {
+		t.Errorf("aliased x: %v, want %v", z, want)
+	}
+	z.Set(y)
+	if z.ExpWindow(x, z, m, 5); z.Cmp(want) != 0 {
+		t.Errorf("aliased y: %v, want %v", z, want)
+	}
+	z.Set(m)
+	if z.ExpWindow(x, y, z, 5); z.Cmp(want) != 0 {
+		t.Errorf("aliased m: %v, want %v", z, want)
+	}
+}
+
+func TestIntProbablyPrimeBPSW(t *testing.T) {
+	parse := func(s string) *big.Int {
+		x, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			t.Fatalf("bad number %q", s)
+		}
+		return x
+	}
+	m127 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 127), big.NewInt(1))
+	primes := []*big.Int{
+		big.NewInt(2), big.NewInt(3), big.NewInt(5), big.NewInt(7), big.NewInt(65537), m127,
+		parse("18699199384836356663"),
+		parse("57896044618658097711785492504343953926634992332820282019728792003956564819949"), // 2**255-19
+	}
+	for _, x := range primes {
+		if !x.ProbablyPrimeBPSW() {
+			t.Errorf("ProbablyPrimeBPSW(%v) = false", x)
+		}
+	}
+
+	composites := []string{
+		"-7", "0", "1", "4", "561",
+		// Strong pseudoprimes to base 2, https://oeis.org/A001262.
+		"2047", "3277", "4033", "4681", "8321", "15841", "29341", "42799", "49141", "52633",
+		// Strong Lucas pseudoprimes, https://oeis.org/A217255.
+		"5459", "5777", "10877", "16109", "18971", "22499", "24569", "25199", "40309", "58519",
+		// Strong pseudoprimes to all prime bases up to 23, 37 and 41.
+		"3825123056546413051",
+		"318665857834031151167461",
+		"3317044064679887385961981",
+		// Arnault, "Rabin-Miller Primality Test: Composite Numbers Which Pass
+		// It", Mathematics of Computation 64(209), 1995: strong pseudoprimes to
+		// all prime bases up to 29 and up to 200.
+		"1195068768795265792518361315725116351898245581",
+		"80383745745363949125707961434194210813883768828755814583748891752229" +
+			"74273765333652186502336163960045457915042023603208766569966760987284" +
+			"0439654082329287387918508691668573282677617710293896977394701670823" +
+			"0428687109997439976544144845341155872450633409279022275296229414984" +
+			"2306881685404326457534018329786111298960644845216191652872597534901",
+	}
+	for _, s := range composites {
+		if parse(s).ProbablyPrimeBPSW() {
+			t.Errorf("ProbablyPrimeBPSW(%s) = true", s)
+		}
+	}
+	// Squares have no D with Jacobi(D/n) = -1 for the Lucas test.
+	for _, p := range []*big.Int{big.NewInt(65537), m127} {
+		if sq := new(big.Int).Mul(p, p); sq.ProbablyPrimeBPSW() {
+			t.Errorf("ProbablyPrimeBPSW(%v²) = true", p)
+		}
+	}
+
+	// Every n below 20000 against a sieve.
+	const limit = 20000
+	composite := make([]bool, limit)
+	for i := 2; i < limit; i++ {
+		for j := 2 * i; j < limit; j += i {
+			composite[j] = true
+		}
+	}
+	for i := 2; i < limit; i++ {
+		if got := big.NewInt(int64(i)).ProbablyPrimeBPSW(); got == composite[i] {
+			t.Fatalf("ProbablyPrimeBPSW(%d) = %v", i, got)
+		}
+	}
+}
+
+func TestBatchModInverse(t *testing.T) {
+	p := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+	composite := new(big.Int).Mul(benchInt(7, 128), big.NewInt(3*5*7))
+	composite.Or(composite, big.NewInt(1))
+	for _, m := range []*big.Int{p, new(big.Int).Neg(p), composite, big.NewInt(1)} {
+		var xs []*big.Int
+		for i := 0; len(xs) < 100; i++ {
+			x := benchInt(int64(i), 64+8*(i%40)) // some larger than m
+			if i%3 == 0 {
+				x.Neg(x)
+			}
+			if new(big.Int).ModInverse(x, m) != nil {
+				xs = append(xs, x)
+			}
+		}
+		xs = append(xs, big.NewInt(1), new(big.Int).Sub(new(big.Int).Abs(m), big.NewInt(1)))
+		got, err := big.BatchModInverse(xs, m)
+		if err != nil {
+			t.Fatalf("mod %v: %v", m, err)
+		}
+		if len(got) != len(xs) {
+			t.Fatalf("mod %v: %d results for %d elements", m, len(got), len(xs))
+		}
+		for i, x := range xs {
+			if want := new(big.Int).ModInverse(x, m); got[i].Cmp(want) != 0 {
+				t.Fatalf("mod %v: inverse of %v = %v, want %v", m, x, got[i], want)
+			}
+		}
+	}
+
+	// The first element without an inverse is named.
+	xs := []*big.Int{big.NewInt(2), big.NewInt(4), new(big.Int).Mul(benchInt(1, 64), big.NewInt(11)), big.NewInt(22)}
+	if _, err := big.BatchModInverse(xs, big.NewInt(11*13)); err == nil || !strings.Contains(err.Error(), "element 2 ") {
+		t.Errorf("factor of m: err = %v, want one naming element 2", err)
+	}
+	if _, err := big.BatchModInverse([]*big.Int{big.NewInt(3), new(big.Int)}, p); err == nil || !strings.Contains(err.Error(), "element 1 ") {
+		t.Errorf("zero element: err = %v, want one naming element 1", err)
+	}
+	if _, err := big.BatchModInverse(xs, new(big.Int)); err == nil {
+		t.Error("zero modulus succeeded")
+	}
+	if got, err := big.BatchModInverse(nil, p); err != nil || len(got) != 0 {
+		t.Errorf("no elements: %v, %v", got, err)
+	}
+}
@@ -0,0 +1,40 @@
+//go:build llgo && !math_big_pure_go && !nogc
+// +build llgo,!math_big_pure_go,!nogc
+
+package test
+
+import (
+	"math/big"
+	"runtime"
+	"testing"
+)
+
+// The BIGNUMs of unreachable Ints are freed by their finalizers: the memory of
+// a loop allocating Ints stays bounded, although the BIGNUMs live outside the
+// memory managed by the garbage collector.
+func TestIntFinalizerMemoryBounded(t *testing.T) {
+	if residentKB(t) < 0 {
+		t.Skip("no /proc/self/status")
+	}
+	one := big.NewInt(1)
+	churn := func(n int) {
+		for i := 0; i < n; i++ {
+			x := new(big.Int).Lsh(one, 32768) // a 4 KiB BIGNUM
+			if x.Sign() <= 0 {
+				t.Fatal("x <= 0")
+			}
+			if i%1024 == 0 {
+				runtime.GC()
+			}
+		}
+		runtime.GC()
+	}
+	churn(1 << 12) // warm up the malloc arenas
+	before := residentKB(t)
+
+	const n = 1 << 16 // 256 MiB of BIGNUMs if they leaked
+	churn(n)
+	if grown := residentKB(t) - before; grown > 64<<10 {
+		t.Errorf("resident memory grew by %d KiB over %d Ints of 4 KiB", grown, n)
+	}
+}
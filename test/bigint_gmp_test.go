@@ -0,0 +1,20 @@
+//go:build llgo && gmp && !math_big_pure_go
+// +build llgo,gmp,!math_big_pure_go
+
+package test
+
+import (
+	"math/big"
+	"unsafe"
+)
+
+// valueWords returns the limbs allocated for the mpz_t of x. It relies on
+// the first field of an Int pointing to a box whose first field is the
+// mpz_t, and on the layout of GMP's __mpz_struct: int _mp_alloc and _mp_size,
+// then _mp_d.
+func valueWords(x *big.Int) []uint64 {
+	box := *(*unsafe.Pointer)(unsafe.Pointer(x))
+	alloc := *(*int32)(box)
+	d := *(**uint64)(unsafe.Add(box, 8))
+	return unsafe.Slice(d, alloc)
+}
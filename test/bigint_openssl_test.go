@@ -0,0 +1,48 @@
+//go:build llgo && !math_big_pure_go && !gmp
+// +build llgo,!math_big_pure_go,!gmp
+
+package test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"unsafe"
+)
+
+// valueWords returns the words allocated for the BIGNUM of x. It relies on
+// the first field of an Int pointing to a box whose first field is the
+// BIGNUM, and on the layout of OpenSSL's struct bignum_st: d, then int top
+// and dmax.
+func valueWords(x *big.Int) []uint64 {
+	box := *(*unsafe.Pointer)(unsafe.Pointer(x))
+	bn := *(*unsafe.Pointer)(box)
+	d := *(**uint64)(bn)
+	dmax := *(*int32)(unsafe.Add(bn, unsafe.Sizeof(uintptr(0))+4))
+	return unsafe.Slice(d, dmax)
+}
+
+// The elements of a BitSet are C int bit indices on OpenSSL: larger ones
+// panic rather than wrap around to a smaller element.
+func TestBitSetElementRange(t *testing.T) {
+	var s big.BitSet
+	s.Add(3)
+	if s.Contains(math.MaxInt32) {
+		t.Fatal("Contains(math.MaxInt32) = true")
+	}
+	for _, i := range []uint{math.MaxInt32 + 1, 1<<32 + 3} {
+		for name, f := range map[string]func(uint){"Add": func(i uint) { s.Add(i) }, "Contains": func(i uint) { s.Contains(i) }} {
+			func() {
+				defer func() {
+					if r := recover(); r != "math/big: BitSet element out of range" {
+						t.Errorf("%s(%d) recovered %v", name, i, r)
+					}
+				}()
+				f(i)
+			}()
+		}
+	}
+	if s.Count() != 1 {
+		t.Errorf("Count() = %d after the panics, want 1", s.Count())
+	}
+}
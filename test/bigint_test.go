@@ -0,0 +1,925 @@
+//go:build llgo
+// +build llgo
+
+package test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// FuzzIntSetString checks that accepted inputs round-trip through Text and
+// agree with refParse, a straightforward restatement of the SetString rules.
+func FuzzIntSetString(f *testing.F) {
+	seeds := []struct {
+		s    string
+		base int
+	}{
+		{"0", 0}, {"-0", 10}, {"+12", 0}, {"-123456789012345678901234567890", 10},
+		{"0x1f", 0}, {"0X_1F", 0}, {"0b101", 0}, {"0B1_0", 0}, {"0o17", 0},
+		{"017", 0}, {"0_17", 0}, {"08", 0}, {"0x", 0}, {"0_x1", 0},
+		{"1_000", 0}, {"1__0", 0}, {"_1", 0}, {"1_", 0}, {"1_000", 10},
+		{"ff", 16}, {"FF", 16}, {"zz", 36}, {"ZZ", 62}, {"zz", 62},
+		{"", 10}, {"+", 10}, {"-", 10}, {"+-1", 10}, {"12a", 10},
+		{"123456789abcdef123456789abcdef", 16}, {"10", 2}, {"-0b_1010", 0},
+	}
+	for _, seed := range seeds {
+		f.Add(seed.s, seed.base)
+	}
+	f.Fuzz(func(t *testing.T, s string, base int) {
+		if base < 0 || base == 1 || base > big.MaxBase || len(s) > 1000 {
+			return
+		}
+		want, wantOK := refParse(s, base)
+		x, ok := new(big.Int).SetString(s, base)
+		if ok != wantOK {
+			t.Fatalf("SetString(%q, %d): ok = %v, want %v", s, base, ok, wantOK)
+		}
+		if !ok {
+			return
+		}
+		if got := x.String(); got != want {
+			t.Fatalf("SetString(%q, %d) = %s, want %s", s, base, got, want)
+		}
+		if base == 0 {
+			base = 10
+		}
+		text := x.Text(base)
+		y, ok := new(big.Int).SetString(text, base)
+		if !ok || y.Cmp(x) != 0 {
+			t.Fatalf("SetString(%q, %d) = %v, %v after Text of %s", text, base, y, ok, want)
+		}
+	})
+}
+
+// refParse returns the decimal text of s parsed like math/big's SetString,
+// and whether s is accepted.
+func refParse(s string, base int) (string, bool) {
+	neg := false
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	underscores, prefixed := base == 0, false
+	if base == 0 {
+		base = 10
+		if len(s) >= 2 && s[0] == '0' {
+			switch s[1] {
+			case 'x', 'X':
+				base, prefixed = 16, true
+			case 'b', 'B':
+				base, prefixed = 2, true
+			case 'o', 'O':
+				base, prefixed = 8, true
+			default:
+				base = 8 // the leading 0 is a digit too
+			}
+		}
+		if prefixed {
+			s = s[2:]
+		}
+		// '_' must separate successive digits, or the prefix and a digit.
+		for i := 0; i < len(s); i++ {
+			if s[i] != '_' {
+				continue
+			}
+			if i == 0 && !prefixed || i > 0 && s[i-1] == '_' || i+1 == len(s) || s[i+1] == '_' {
+				return "", false
+			}
+		}
+	}
+
+	var limbs []uint64 // little-endian base 1e9
+	count := 0
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if ch == '_' && underscores {
+			continue
+		}
+		d := digitVal(ch, base)
+		if d < 0 {
+			return "", false
+		}
+		count++
+		carry := uint64(d)
+		for j := range limbs {
+			v := limbs[j]*uint64(base) + carry
+			limbs[j], carry = v%1e9, v/1e9
+		}
+		for carry != 0 {
+			limbs = append(limbs, carry%1e9)
+			carry /= 1e9
+		}
+	}
+	if count == 0 {
+		return "", false
+	}
+
+	for len(limbs) > 0 && limbs[len(limbs)-1] == 0 {
+		limbs = limbs[:len(limbs)-1]
+	}
+	if len(limbs) == 0 {
+		return "0", true
+	}
+	text := ""
+	if neg {
+		text = "-"
+	}
+	text += strconv.FormatUint(limbs[len(limbs)-1], 10)
+	for i := len(limbs) - 2; i >= 0; i-- {
+		part := strconv.FormatUint(limbs[i], 10)
+		text += strings.Repeat("0", 9-len(part)) + part
+	}
+	return text, true
+}
+
+// digitVal returns the value of the digit ch in base, or -1 if it isn't one.
+func digitVal(ch byte, base int) int {
+	d := int(big.MaxBase)
+	switch {
+	case '0' <= ch && ch <= '9':
+		d = int(ch - '0')
+	case 'a' <= ch && ch <= 'z':
+		d = int(ch-'a') + 10
+	case 'A' <= ch && ch <= 'Z':
+		if base <= 36 {
+			d = int(ch-'A') + 10
+		} else {
+			d = int(ch-'A') + 36
+		}
+	}
+	if d >= base {
+		return -1
+	}
+	return d
+}
+
+func TestIntModInverse(t *testing.T) {
+	z := big.NewInt(42)
+	if got := z.ModInverse(big.NewInt(6), big.NewInt(9)); got != nil {
+		t.Fatalf("ModInverse(6, 9) = %v, want nil", got)
+	}
+	if z.Cmp(big.NewInt(42)) != 0 {
+		t.Fatalf("z = %v after a failed ModInverse, want it unchanged", z)
+	}
+	tests := []struct{ g, n, want int64 }{
+		{3, 7, 5}, {-3, 7, 2}, {3, -7, 5}, {10, 1, 0}, {1, 0, 1},
+	}
+	for _, tt := range tests {
+		got := new(big.Int).ModInverse(big.NewInt(tt.g), big.NewInt(tt.n))
+		if got == nil || got.Cmp(big.NewInt(tt.want)) != 0 {
+			t.Errorf("ModInverse(%d, %d) = %v, want %d", tt.g, tt.n, got, tt.want)
+		}
+	}
+}
+
+// The cofactors are those of math/big, which are the smallest ones: see the
+// GCD documentation for the zero cases.
+func TestIntGCD(t *testing.T) {
+	tests := []struct{ a, b, z, x, y string }{
+		{"0", "0", "0", "0", "0"},
+		{"0", "-7", "7", "0", "-1"},
+		{"12", "0", "12", "1", "0"},
+		{"-12", "0", "12", "-1", "0"},
+		{"12", "18", "6", "-1", "1"},
+		{"-12", "18", "6", "1", "1"},
+		{"12", "-18", "6", "-1", "-1"},
+		{"18", "12", "6", "1", "-1"},
+		{"7", "7", "7", "0", "1"},
+		{"-7", "7", "7", "0", "1"},
+		{"1", "1000", "1", "1", "0"},
+		{"240", "46", "2", "-9", "47"},
+		{"0x1fffffffffffffffffffffffffffffff", "0xfffffffffffffffffff", "1", "-24373505008823931544874", "13721063509431546990739845534719452457"},
+		{"123456789012345678901234567890", "-987654321098765432109876543210", "9000000000900000000090", "-8", "-1"},
+	}
+	for _, tt := range tests {
+		a, _ := new(big.Int).SetString(tt.a, 0)
+		b, _ := new(big.Int).SetString(tt.b, 0)
+		x, y := new(big.Int), new(big.Int)
+		z := new(big.Int).GCD(x, y, a, b)
+		if z.String() != tt.z || x.String() != tt.x || y.String() != tt.y {
+			t.Errorf("GCD(%v, %v) = %v, %v, %v, want %s, %s, %s", a, b, z, x, y, tt.z, tt.x, tt.y)
+		}
+		if z := new(big.Int).GCD(nil, nil, a, b); z.String() != tt.z {
+			t.Errorf("GCD(nil, nil, %v, %v) = %v, want %s", a, b, z, tt.z)
+		}
+		if y := new(big.Int); new(big.Int).GCD(nil, y, a, b).String() != tt.z || y.String() != tt.y {
+			t.Errorf("GCD(nil, y, %v, %v): y = %v, want %s", a, b, y, tt.y)
+		}
+
+		// The results may overwrite the operands.
+		xa, yb := new(big.Int).Set(a), new(big.Int).Set(b)
+		if new(big.Int).GCD(xa, yb, xa, yb); xa.String() != tt.x || yb.String() != tt.y {
+			t.Errorf("GCD(a, b, a, b) of %v, %v: x = %v, y = %v, want %s, %s", a, b, xa, yb, tt.x, tt.y)
+		}
+	}
+}
+
+func TestIntInt64(t *testing.T) {
+	tests := []struct {
+		x        string
+		i64      int64
+		u64      uint64
+		isI, isU bool
+	}{
+		{"0", 0, 0, true, true},
+		{"-1", -1, 1, true, false},
+		{"9223372036854775807", 1<<63 - 1, 1<<63 - 1, true, true},
+		{"9223372036854775808", -1 << 63, 1 << 63, false, true},
+		{"-9223372036854775808", -1 << 63, 1 << 63, true, false},
+		{"-9223372036854775809", 1<<63 - 1, 1<<63 + 1, false, false},
+		{"18446744073709551615", -1, 1<<64 - 1, false, true},
+		{"18446744073709551616", 0, 0, false, false},
+		{"0x1234567890abcdef00000000000000005", 5, 5, false, false},
+		{"-0x1234567890abcdef00000000000000005", -5, 5, false, false},
+	}
+	for _, tt := range tests {
+		x, _ := new(big.Int).SetString(tt.x, 0)
+		if got := x.Int64(); got != tt.i64 {
+			t.Errorf("(%s).Int64() = %d, want %d", tt.x, got, tt.i64)
+		}
+		if got := x.Uint64(); got != tt.u64 {
+			t.Errorf("(%s).Uint64() = %d, want %d", tt.x, got, tt.u64)
+		}
+		if got := x.IsInt64(); got != tt.isI {
+			t.Errorf("(%s).IsInt64() = %v, want %v", tt.x, got, tt.isI)
+		}
+		if got := x.IsUint64(); got != tt.isU {
+			t.Errorf("(%s).IsUint64() = %v, want %v", tt.x, got, tt.isU)
+		}
+	}
+}
+
+// Bit and SetBit treat negative numbers in two's complement, like And and Or,
+// which they are checked against.
+func TestIntBit(t *testing.T) {
+	one := big.NewInt(1)
+	for _, s := range []string{"0", "1", "-1", "12", "-12", "-256", "0xff00000000000000ff", "-0x10000000000000000", "-0xff00000000000000ff"} {
+		x, _ := new(big.Int).SetString(s, 0)
+		for _, i := range []int{0, 1, 2, 3, 7, 8, 63, 64, 65, 71, 72, 200} {
+			m := new(big.Int).Lsh(one, uint(i))
+			want := uint(0)
+			if new(big.Int).And(x, m).Sign() != 0 {
+				want = 1
+			}
+			if got := x.Bit(i); got != want {
+				t.Errorf("(%s).Bit(%d) = %d, want %d", s, i, got, want)
+			}
+			if got, want := new(big.Int).SetBit(x, i, 1), new(big.Int).Or(x, m); got.Cmp(want) != 0 {
+				t.Errorf("SetBit(%s, %d, 1) = %v, want %v", s, i, got, want)
+			}
+			if got, want := new(big.Int).SetBit(x, i, 0), new(big.Int).AndNot(x, m); got.Cmp(want) != 0 {
+				t.Errorf("SetBit(%s, %d, 0) = %v, want %v", s, i, got, want)
+			}
+		}
+		z := new(big.Int).Set(x)
+		if z.SetBit(z, 70, 1); z.Cmp(new(big.Int).Or(x, new(big.Int).Lsh(one, 70))) != 0 {
+			t.Errorf("SetBit(z, 70, 1) in place of %s = %v", s, z)
+		}
+	}
+}
+
+func TestIntSqrt(t *testing.T) {
+	for _, s := range []string{"0", "1", "2", "3", "4", "15", "16", "17", "18446744073709551615", "18446744073709551616",
+		"340282366920938463463374607431768211455", "340282366920938463463374607431768211456", "123456789012345678901234567890123456789012345678901234567890"} {
+		x, _ := new(big.Int).SetString(s, 10)
+		r := new(big.Int).Sqrt(x)
+		r1 := new(big.Int).Add(r, big.NewInt(1))
+		if new(big.Int).Mul(r, r).Cmp(x) > 0 || new(big.Int).Mul(r1, r1).Cmp(x) <= 0 {
+			t.Errorf("Sqrt(%s) = %v", s, r)
+		}
+		if x.Sqrt(x); x.Cmp(r) != 0 {
+			t.Errorf("Sqrt(%s) in place = %v, want %v", s, x, r)
+		}
+	}
+}
+
+func TestJacobi(t *testing.T) {
+	tests := []struct {
+		x, y string
+		want int
+	}{
+		{"0", "1", 1}, {"1", "1", 1}, {"2", "3", -1}, {"5", "7", -1}, {"-5", "7", 1},
+		{"5", "-7", -1}, {"-5", "-7", -1}, {"6", "9", 0}, {"1001", "9907", -1}, {"-1", "-1", -1},
+		{"123456789012345678901234567890", "1000000000000000000000000000057", -1},
+		{"-123456789012345678901234567890", "-1000000000000000000000000000057", 1},
+	}
+	for _, tt := range tests {
+		x, _ := new(big.Int).SetString(tt.x, 10)
+		y, _ := new(big.Int).SetString(tt.y, 10)
+		if got := big.Jacobi(x, y); got != tt.want {
+			t.Errorf("Jacobi(%s, %s) = %d, want %d", tt.x, tt.y, got, tt.want)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Jacobi with an even y didn't panic")
+		}
+	}()
+	big.Jacobi(big.NewInt(3), big.NewInt(10))
+}
+
+// naiveText converts x by repeated division by base, the quadratic method
+// Text uses for small numbers only.
+func naiveText(x *big.Int, base int) string {
+	if x.Sign() == 0 {
+		return "0"
+	}
+	var digits []byte
+	b := big.NewInt(int64(base))
+	q, r := new(big.Int).Abs(x), new(big.Int)
+	for q.Sign() != 0 {
+		q.QuoRem(q, b, r)
+		d := 0
+		if b := r.Bytes(); len(b) > 0 {
+			d = int(b[0])
+		}
+		digits = append(digits, "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"[d])
+	}
+	if x.Sign() < 0 {
+		digits = append(digits, '-')
+	}
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		digits[i], digits[j] = digits[j], digits[i]
+	}
+	return string(digits)
+}
+
+func TestIntTextLarge(t *testing.T) {
+	one := big.NewInt(1)
+	for _, bits := range []uint{600, 4096, 20000} {
+		x := new(big.Int).Lsh(one, bits)
+		x.Sub(x, big.NewInt(12345))
+		values := []*big.Int{x, new(big.Int).Neg(x), new(big.Int).Lsh(one, bits)}
+		for _, v := range values {
+			for _, base := range []int{2, 3, 10, 16, 36, 62} {
+				if got, want := v.Text(base), naiveText(v, base); got != want {
+					t.Fatalf("%d bits, base %d: Text differs from the naive conversion", bits, base)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkIntText(b *testing.B) {
+	for _, bits := range []uint{10000, 100000, 1000000} {
+		x := new(big.Int).Lsh(big.NewInt(1), bits)
+		x.Sub(x, big.NewInt(1))
+		zero := new(big.Int)
+		b.Run(strconv.Itoa(int(bits)), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				x.Add(x, zero) // defeat the memoized String text
+				_ = x.Text(10)
+			}
+		})
+	}
+}
+
+// BenchmarkIntString formats a value of about 3000 digits over and over, as a
+// read-heavy workload does: unchanged, when String can return the memoized
+// text, from several goroutines at once, and after a mutation each time,
+// when the text has to be computed again.
+func BenchmarkIntString(b *testing.B) {
+	x := new(big.Int).Lsh(big.NewInt(1), 10000)
+	x.Sub(x, big.NewInt(1))
+	zero := new(big.Int)
+	b.Run("unchanged", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = x.String()
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_ = x.String()
+			}
+		})
+	})
+	b.Run("mutated", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x.Add(x, zero)
+			_ = x.String()
+		}
+	})
+}
+
+// Every way of changing an Int is seen by the next String, even though the
+// text of an unchanged value is memoized.
+func TestIntStringAfterMutation(t *testing.T) {
+	x := new(big.Int)
+	check := func(op string, want int64) {
+		t.Helper()
+		if got := x.String(); got != strconv.FormatInt(want, 10) {
+			t.Errorf("after %s: String() = %s, want %d", op, got, want)
+		}
+	}
+	check("zero value", 0)
+	x.SetInt64(42)
+	check("SetInt64", 42)
+	x.Add(x, big.NewInt(1))
+	check("Add", 43)
+	x.Neg(x)
+	check("Neg", -43)
+	x.Lsh(x, 2)
+	check("Lsh", -172)
+	x.Rsh(x, 1)
+	check("Rsh", -86)
+	x.Or(x, big.NewInt(1))
+	check("Or", -85)
+	x.Mul(x, x)
+	check("Mul", 7225)
+	x.Quo(x, big.NewInt(-4))
+	check("Quo", -1806)
+	x.Not(x)
+	check("Not", 1805)
+	x.Div(x, big.NewInt(43))
+	check("Div", 41)
+	x.SetString("-12", 10)
+	check("SetString", -12)
+	x.Abs(x)
+	check("Abs", 12)
+	if err := x.UnmarshalText([]byte("99")); err != nil {
+		t.Fatal(err)
+	}
+	check("UnmarshalText", 99)
+	g, _ := big.NewInt(-7).GobEncode()
+	if err := x.GobDecode(g); err != nil {
+		t.Fatal(err)
+	}
+	check("GobDecode", -7)
+	x.SetBytes([]byte{1, 0})
+	check("SetBytes", 256)
+	x.Exp(big.NewInt(3), big.NewInt(4), nil)
+	check("Exp", 81)
+	y := big.NewInt(5)
+	_ = y.String()
+	x.Set(y)
+	check("Set", 5)
+	y.SetUint64(6)
+	check("Set of a value changed since", 5)
+	x.Add(x, x)
+	check("Add to itself", 10)
+}
+
+// Concurrent reads of an Int are safe, including the first ones of a zero
+// value, which is only given its storage then.
+func TestIntConcurrentReads(t *testing.T) {
+	y := new(big.Int).Lsh(big.NewInt(1), 200)
+	want := y.Text(16)
+	for i := 0; i < 50; i++ {
+		var x big.Int
+		var wg sync.WaitGroup
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if s := x.String(); s != "0" {
+					t.Errorf("String() = %s", s)
+				}
+				if x.Sign() != 0 || x.Cmp(y) >= 0 || y.Cmp(&x) <= 0 {
+					t.Error("zero value isn't 0")
+				}
+				if s := y.Text(16); s != want {
+					t.Errorf("Text(16) = %s, want %s", s, want)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+}
+
+// TestIntFormat pins the cases of Int.Format where math/big departs from fmt's
+// formatting of machine integers, which are the easy ones to get wrong. The
+// full cross product of verbs, flags, widths and precisions is compared with
+// the standard library by _cmptest/bigfmtgrid.
+func TestIntFormat(t *testing.T) {
+	huge, _ := new(big.Int).SetString("-0x1fedcba9876543210fedcba9876543210", 0)
+	tests := []struct {
+		format string
+		x      *big.Int
+		want   string
+	}{
+		// A zero precision prints nothing for zero, ignoring prefix and width.
+		{"%.0d", big.NewInt(0), ""},
+		{"%+.0d", big.NewInt(0), ""},
+		{"%5.0x", big.NewInt(0), ""},
+		{"%#.0o", big.NewInt(0), ""},
+		{"%#.0x", big.NewInt(0), ""},
+		{"%.0d", big.NewInt(1), "1"},
+
+		// The octal prefix "0" is added even to zero.
+		{"%#o", big.NewInt(0), "00"},
+		{"%#O", big.NewInt(0), "0o0"},
+		{"%#x", big.NewInt(0), "0x0"},
+		{"%#10x", big.NewInt(0), "       0x0"},
+
+		// %O always has a prefix, and zero padding goes after it.
+		{"%0O", big.NewInt(0), "0o0"},
+		{"%010O", big.NewInt(0), "0o00000000"},
+		{"%#O", big.NewInt(-42), "-0o52"},
+		{"%010O", big.NewInt(-42), "-0o0000052"},
+
+		// %v is %d, so + shows the sign of non-negative values.
+		{"%+v", big.NewInt(0), "+0"},
+		{"%+10v", big.NewInt(0), "        +0"},
+		{"%+v", big.NewInt(-42), "-42"},
+
+		// Sign, precision and padding.
+		{"% 5d", big.NewInt(0), "    0"},
+		{"% 10d", big.NewInt(-42), "       -42"},
+		{"%010d", big.NewInt(0), "0000000000"},
+		{"%010d", big.NewInt(-42), "-000000042"},
+		{"%010.3d", big.NewInt(0), "       000"},
+		{"%-10.3d", big.NewInt(0), "000       "},
+		{"%0.8d", big.NewInt(-42), "-00000042"},
+		{"%05.8d", big.NewInt(-42), "-00000042"},
+		{"%#10.3x", big.NewInt(-42), "    -0x02a"},
+		{"%-10X", big.NewInt(-42), "-2A       "},
+		{"%#b", big.NewInt(-42), "-0b101010"},
+
+		// Values wider than a word.
+		{"%#x", huge, "-0x1fedcba9876543210fedcba9876543210"},
+		{"%+44d", new(big.Int).Neg(huge), "    +679052367766672755997699632509129863696"},
+		{"%-12.8X", big.NewInt(123456789), "075BCD15    "},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf(tt.format, tt.x); got != tt.want {
+			t.Errorf("Sprintf(%q, %v) = %q, want %q", tt.format, tt.x, got, tt.want)
+		}
+	}
+}
+
+func TestIntMarshalJSON(t *testing.T) {
+	huge, _ := new(big.Int).SetString("-123456789012345678901234567890123456789012345678901234567890", 10)
+	negZero, _ := new(big.Int).SetString("-0", 10)
+	tests := []struct {
+		x    *big.Int
+		want string
+	}{
+		{new(big.Int), "0"},
+		{negZero, "0"},
+		{new(big.Int).Neg(new(big.Int)), "0"},
+		{new(big.Int).Sub(big.NewInt(7), big.NewInt(7)), "0"},
+		{big.NewInt(-1), "-1"},
+		{huge, huge.String()},
+		{nil, "null"},
+	}
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.x)
+		if err != nil || string(got) != tt.want {
+			t.Errorf("json.Marshal(%v) = %s, %v; want %s", tt.x, got, err, tt.want)
+			continue
+		}
+		if tt.x == nil {
+			continue
+		}
+		y := new(big.Int)
+		if err := json.Unmarshal(got, y); err != nil || y.Cmp(tt.x) != 0 {
+			t.Errorf("json.Unmarshal(%s) = %v, %v; want %v", got, y, err, tt.x)
+		}
+	}
+
+	var v struct{ N, M *big.Int }
+	if err := json.Unmarshal([]byte(`{"N": -42, "M": null}`), &v); err != nil || v.N.String() != "-42" || v.M != nil {
+		t.Fatalf("json.Unmarshal into struct = %+v, %v", v, err)
+	}
+	if err := json.Unmarshal([]byte(`"-42"`), new(big.Int)); err == nil {
+		t.Fatal("json.Unmarshal accepted a JSON string")
+	}
+}
+
+func TestIntInvalidBase(t *testing.T) {
+	mustPanic := func(name string, base int, want string, f func()) {
+		t.Helper()
+		defer func() {
+			if r := recover(); r != want {
+				t.Errorf("%s with base %d: recovered %v, want %q", name, base, r, want)
+			}
+		}()
+		f()
+	}
+	x := big.NewInt(-255)
+	for _, base := range []int{1, 0, 63, 64, -1, -16} {
+		mustPanic("Text", base, "invalid base", func() { x.Text(base) })
+		mustPanic("Append", base, "invalid base", func() { x.Append([]byte("x="), base) })
+		mustPanic("Text of 0", base, "invalid base", func() { new(big.Int).Text(base) })
+		if base != 0 {
+			want := fmt.Sprintf("invalid number base %d", base)
+			mustPanic("SetString", base, want, func() { new(big.Int).SetString("-11", base) })
+		}
+	}
+
+	// Base 0 selects the base from the prefix, and the bounds are valid.
+	if y, ok := new(big.Int).SetString("-0xff", 0); !ok || y.Cmp(x) != 0 {
+		t.Errorf(`SetString("-0xff", 0) = %v, %v`, y, ok)
+	}
+	if s := x.Text(2); s != "-11111111" {
+		t.Errorf("Text(2) = %q", s)
+	}
+	if s := x.Text(big.MaxBase); s != "-47" {
+		t.Errorf("Text(MaxBase) = %q", s)
+	}
+	// A nil *Int has no digits to get wrong.
+	if s := (*big.Int)(nil).Text(1); s != "<nil>" {
+		t.Errorf("nil Text(1) = %q", s)
+	}
+}
+
+// naiveMul returns x*y by shifting and adding, one bit of y at a time.
+func naiveMul(x, y *big.Int) *big.Int {
+	z := new(big.Int)
+	ax := new(big.Int).Abs(x)
+	ay := new(big.Int).Abs(y)
+	for i := 0; i < ay.BitLen(); i++ {
+		if ay.Text(2)[ay.BitLen()-1-i] == '1' {
+			z.Add(z, new(big.Int).Lsh(ax, uint(i)))
+		}
+	}
+	if x.Sign()*y.Sign() < 0 {
+		z.Neg(z)
+	}
+	return z
+}
+
+func TestIntMul(t *testing.T) {
+	a, _ := new(big.Int).SetString("-123456789abcdef0123456789abcdef0123456789abcdef", 16)
+	b, _ := new(big.Int).SetString("fedcba9876543210fedcba98765432100f1e2d3c4b5a6978", 16)
+	values := []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(-1), big.NewInt(1 << 40), a, b}
+	for _, x := range values {
+		for _, y := range values {
+			if got, want := new(big.Int).Mul(x, y), naiveMul(x, y); got.Cmp(want) != 0 {
+				t.Errorf("%v * %v = %v, want %v", x, y, got, want)
+			}
+		}
+	}
+
+	// z may alias x and y, and x and y may be the same Int.
+	x := new(big.Int).Set(a)
+	if x.Mul(x, b); x.Cmp(naiveMul(a, b)) != 0 {
+		t.Errorf("aliased Mul = %v", x)
+	}
+	x.Set(a)
+	if x.Mul(x, x); x.Cmp(naiveMul(a, a)) != 0 {
+		t.Errorf("aliased square = %v", x)
+	}
+}
+
+// BenchmarkIntMul multiplies operands on either side of OpenSSL's Karatsuba
+// threshold of 16 words, which is fixed at build time: vary the operand size
+// rather than the threshold. Each product is first checked against
+// ((x+y)**2 - (x-y)**2) / 4, which uses squaring instead.
+func BenchmarkIntMul(b *testing.B) {
+	for _, words := range []int{4, 8, 15, 16, 17, 32, 64, 256, 1024} {
+		bits := uint(words * 64)
+		x := new(big.Int).Lsh(big.NewInt(1), bits-1)
+		x.Sub(x, big.NewInt(12345))
+		y := new(big.Int).Lsh(big.NewInt(1), bits-2)
+		y.Add(y, big.NewInt(67891))
+
+		z := new(big.Int).Mul(x, y)
+		s := new(big.Int).Add(x, y)
+		s.Mul(s, s)
+		d := new(big.Int).Sub(x, y)
+		d.Mul(d, d)
+		if s.Sub(s, d).Rsh(s, 2).Cmp(z) != 0 {
+			b.Fatalf("%d words: x*y doesn't match the identity", words)
+		}
+
+		b.Run(strconv.Itoa(words)+"words", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				z.Mul(x, y)
+			}
+		})
+	}
+}
+
+func TestIntSetStringWhitespace(t *testing.T) {
+	inputs := []string{
+		" 42", "42 ", " 42 ", "\t42", "42\n", "42\r\n", " 42", "42　",
+		"4 2", "- 42", "-\t42", "+ 42", "0x 1f", "0 x1f", "0x1f ", "1_000 ", "1 _000",
+		"", " ", "\t", "4\x002",
+	}
+	for _, base := range []int{0, 10, 16} {
+		for _, s := range inputs {
+			if z, ok := new(big.Int).SetString(s, base); ok || z != nil {
+				t.Errorf("SetString(%q, %d) = %v, %v; want nil, false", s, base, z, ok)
+			}
+		}
+	}
+	for _, s := range []string{" 42", "42 ", "4 2"} {
+		if err := new(big.Int).UnmarshalText([]byte(s)); err == nil {
+			t.Errorf("UnmarshalText(%q) succeeded", s)
+		}
+	}
+	if z, ok := new(big.Int).SetString("42", 10); !ok || z.String() != "42" {
+		t.Errorf(`SetString("42", 10) = %v, %v`, z, ok)
+	}
+}
+
+func TestIntQuoRemAliasing(t *testing.T) {
+	x0, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	y0 := big.NewInt(987654321)
+	q0, r0 := new(big.Int).QuoRem(x0, y0, new(big.Int))
+	if q0.String() != "-124999998873437499901" || r0.String() != "-574845669" {
+		t.Fatalf("QuoRem(%v, %v) = %v, %v", x0, y0, q0, r0)
+	}
+
+	// q and r name the Int that receives each result: x or y, or a
+	// separate Int.
+	tests := []struct{ q, r string }{
+		{"x", "r"}, {"y", "r"}, {"q", "x"}, {"q", "y"}, {"x", "y"}, {"y", "x"}, {"q", "r"},
+	}
+	for _, tt := range tests {
+		ints := map[string]*big.Int{
+			"x": new(big.Int).Set(x0), "y": new(big.Int).Set(y0), "q": new(big.Int), "r": new(big.Int),
+		}
+		q, r := ints[tt.q], ints[tt.r]
+		if gq, gr := q.QuoRem(ints["x"], ints["y"], r); gq != q || gr != r || q.Cmp(q0) != 0 || r.Cmp(r0) != 0 {
+			t.Errorf("q=%s, r=%s: QuoRem = %v, %v; want %v, %v", tt.q, tt.r, q, r, q0, r0)
+		}
+	}
+
+	// x == y
+	x := new(big.Int).Set(x0)
+	q, r := new(big.Int), new(big.Int)
+	if q.QuoRem(x, x, r); q.Cmp(big.NewInt(1)) != 0 || r.Sign() != 0 {
+		t.Errorf("QuoRem(x, x) = %v, %v; want 1, 0", q, r)
+	}
+
+	// Scratch results are overwritten, whatever they held before.
+	q.SetInt64(-7)
+	r.Lsh(big.NewInt(1), 500)
+	if q.QuoRem(x0, y0, r); q.Cmp(q0) != 0 || r.Cmp(r0) != 0 {
+		t.Errorf("reused QuoRem = %v, %v; want %v, %v", q, r, q0, r0)
+	}
+}
+
+// BenchmarkIntQuoRem compares dividing into reused scratch results with
+// allocating new ones for each division.
+func BenchmarkIntQuoRem(b *testing.B) {
+	x, _ := new(big.Int).SetString("123456789012345678901234567890123456789012345678901234567890", 10)
+	y, _ := new(big.Int).SetString("98765432109876543210987", 10)
+	b.Run("Reuse", func(b *testing.B) {
+		q, r := new(big.Int), new(big.Int)
+		for i := 0; i < b.N; i++ {
+			q.QuoRem(x, y, r)
+		}
+	})
+	b.Run("Alloc", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			new(big.Int).QuoRem(x, y, new(big.Int))
+		}
+	})
+}
+
+// TestIntTextBase62 checks the digit order of base 62, 0-9a-zA-Z as in Go,
+// against values produced by the standard math/big.
+func TestIntTextBase62(t *testing.T) {
+	tests := []struct{ x, want string }{
+		{"9", "9"},
+		{"10", "a"},
+		{"35", "z"},
+		{"36", "A"},
+		{"61", "Z"},
+		{"62", "10"},
+		{"-61", "-Z"},
+		{"3843", "ZZ"},
+		{"3844", "100"},
+		{"18446744073709551616", "lYGhA16ahyg"},
+		{"-170141183460469231731687303715884105727", "-3Tx16Db2JPSS4TzoryCQO3"},
+	}
+	for _, tt := range tests {
+		x, _ := new(big.Int).SetString(tt.x, 10)
+		if got := x.Text(62); got != tt.want {
+			t.Errorf("%s.Text(62) = %s, want %s", tt.x, got, tt.want)
+		}
+	}
+
+	// A thousand pseudorandom values of up to 40 bytes, from a xorshift
+	// generator rather than math/rand, whose llgo stream differs. The digest
+	// of their texts, one per line, was computed with the standard math/big.
+	const want = "95a04e2172e82b7828c126cfd63148e5372e7f0b470248ea8b73cfbfbb248a61"
+	state := uint64(0x9e3779b97f4a7c15)
+	next := func() uint64 {
+		state ^= state << 13
+		state ^= state >> 7
+		state ^= state << 17
+		return state
+	}
+	h := sha256.New()
+	for i := 0; i < 1000; i++ {
+		buf := make([]byte, 1+next()%40)
+		for j := range buf {
+			buf[j] = byte(next())
+		}
+		x := new(big.Int).SetBytes(buf)
+		if next()%2 == 1 {
+			x.Neg(x)
+		}
+		s := x.Text(62)
+		if ref := naiveText(x, 62); s != ref {
+			t.Fatalf("%v.Text(62) = %s, want %s", x, s, ref)
+		}
+		if y, ok := new(big.Int).SetString(s, 62); !ok || y.Cmp(x) != 0 {
+			t.Fatalf("SetString(%s, 62) = %v, %v; want %v", s, y, ok, x)
+		}
+		h.Write([]byte(s + "\n"))
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != want {
+		t.Errorf("digest of the base 62 texts = %s, want %s", got, want)
+	}
+}
+
+// TestIntSetStringPrefix mirrors the prefix recognition of the standard
+// SetString with base 0: 0b, 0o and 0x in either case, legacy 0 octal, and
+// underscores, which are only allowed between digits or right after a
+// prefix. An empty want means s is rejected.
+func TestIntSetStringPrefix(t *testing.T) {
+	tests := []struct{ s, want string }{
+		{"0", "0"}, {"-0", "0"}, {"+0", "0"}, {"00", "0"},
+		{"0_0", "0"}, {"010", "8"}, {"-010", "-8"}, {"0_10", "8"},
+		{"08", ""}, {"0_8", ""}, {"019", ""}, {"0o17", "15"},
+		{"0O17", "15"}, {"-0o17", "-15"}, {"0o_17", "15"}, {"0o1_7", "15"},
+		{"0o", ""}, {"0o_", ""}, {"0o8", ""}, {"0o17_", ""},
+		{"0o__17", ""}, {"0b101", "5"}, {"0B101", "5"}, {"+0b101", "5"},
+		{"0b_1_0_1", "5"}, {"0b", ""}, {"0b2", ""}, {"0b1__0", ""},
+		{"0x1f", "31"}, {"0X1F", "31"}, {"-0xFf", "-255"}, {"0x_1f", "31"},
+		{"0x", ""}, {"0xg", ""}, {"0x1_f", "31"}, {"0x_", ""},
+		{"1_000", "1000"}, {"_1000", ""}, {"1000_", ""}, {"1__000", ""},
+		{"0_x1f", ""}, {"0x1f_", ""}, {"0.1", ""}, {"0e1", ""},
+		{"0B", ""}, {"0O", ""}, {"0X", ""}, {"0b1e", ""},
+		{"00b1", ""},
+	}
+	for _, tt := range tests {
+		z, ok := new(big.Int).SetString(tt.s, 0)
+		if tt.want == "" {
+			if ok {
+				t.Errorf("SetString(%q, 0) = %v, want failure", tt.s, z)
+			}
+		} else if !ok || z.String() != tt.want {
+			t.Errorf("SetString(%q, 0) = %v, %v; want %s", tt.s, z, ok, tt.want)
+		}
+	}
+}
+
+func TestIntCmpMixedSizes(t *testing.T) {
+	xs := []*big.Int{new(big.Int), big.NewInt(1), big.NewInt(-1)}
+	for _, bits := range []int{64, 65, 128, 1024, 4096} {
+		x, y := benchInt(int64(bits), bits), benchInt(int64(bits)+1, bits)
+		xs = append(xs, x, y, new(big.Int).Neg(x), new(big.Int).Neg(y))
+	}
+	d := new(big.Int)
+	for _, x := range xs {
+		for _, y := range xs {
+			if got, want := x.Cmp(y), d.Sub(x, y).Sign(); got != want {
+				t.Errorf("Cmp(%v, %v) = %d, want %d", x, y, got, want)
+			}
+		}
+	}
+	sorted := append([]*big.Int(nil), xs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Cmp(sorted[j]) < 0 })
+	for i := 1; i < len(sorted); i++ {
+		if d.Sub(sorted[i], sorted[i-1]).Sign() < 0 {
+			t.Fatalf("sorted[%d] = %v > sorted[%d] = %v", i-1, sorted[i-1], i, sorted[i])
+		}
+	}
+}
+
+func TestIntProbablyPrime(t *testing.T) {
+	m127 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 127), big.NewInt(1))
+	f7 := new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	for _, tt := range []struct {
+		x    *big.Int
+		want bool
+	}{
+		{big.NewInt(-7), false},
+		{big.NewInt(0), false},
+		{big.NewInt(1), false},
+		{big.NewInt(2), true},
+		{big.NewInt(3), true},
+		{big.NewInt(4), false},
+		{big.NewInt(561), false}, // a Carmichael number
+		{big.NewInt(65537), true},
+		{m127, true},
+		{f7, false},
+		{new(big.Int).Mul(m127, big.NewInt(65537)), false},
+	} {
+		if got := tt.x.ProbablyPrime(0); got != tt.want {
+			t.Errorf("ProbablyPrime(%v) = %v, want %v", tt.x, got, tt.want)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("ProbablyPrime(-1) didn't panic")
+		}
+	}()
+	big.NewInt(7).ProbablyPrime(-1)
+}
@@ -0,0 +1,410 @@
+//go:build llgo
+// +build llgo
+
+package test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+)
+
+// SetFloat64 is exact: every finite float64 is a dyadic rational, and
+// Float64 converts it back without rounding.
+func TestRatSetFloat64(t *testing.T) {
+	pow2 := func(n uint) string { return new(big.Int).Lsh(big.NewInt(1), n).String() }
+	tests := []struct {
+		f    float64
+		want string
+	}{
+		// exact integers
+		{0, "0/1"},
+		{math.Copysign(0, -1), "0/1"},
+		{1, "1/1"},
+		{-3, "-3/1"},
+		{1 << 53, "9007199254740992/1"},
+		{1<<53 - 1, "9007199254740991/1"},
+
+		// values with a fraction
+		{0.5, "1/2"},
+		{-0.75, "-3/4"},
+		{0.1, "3602879701896397/36028797018963968"},
+		{-2.5e-3, "-5764607523034235/2305843009213693952"},
+		{math.SmallestNonzeroFloat64, "1/" + pow2(1074)},
+		{0x1p-1022, "1/" + pow2(1022)},                              // smallest normal
+		{0x0.fffffffffffffp-1022, "4503599627370495/" + pow2(1074)}, // largest denormal
+
+		// values above 2**64
+		{1 << 64, pow2(64) + "/1"},
+		{1e20, "100000000000000000000/1"},
+		{-0x1p70, "-" + pow2(70) + "/1"},
+		{0x1.8p100, new(big.Int).Mul(big.NewInt(3), new(big.Int).Lsh(big.NewInt(1), 99)).String() + "/1"},
+		{math.MaxFloat64, new(big.Int).Lsh(big.NewInt(1<<53-1), 971).String() + "/1"},
+	}
+	for _, tt := range tests {
+		x := new(big.Rat).SetFloat64(tt.f)
+		if x == nil {
+			t.Errorf("SetFloat64(%g) = nil", tt.f)
+			continue
+		}
+		if s := x.String(); s != tt.want {
+			t.Errorf("SetFloat64(%g) = %s, want %s", tt.f, s, tt.want)
+		}
+		if f, exact := x.Float64(); f != tt.f || !exact {
+			t.Errorf("SetFloat64(%g).Float64() = %g, %v", tt.f, f, exact)
+		}
+		want, ok := new(big.Rat).SetString(tt.want)
+		if !ok || x.Cmp(want) != 0 {
+			t.Errorf("SetFloat64(%g) != SetString(%q)", tt.f, tt.want)
+		}
+	}
+
+	// SetFloat64 overwrites all of a Rat that held a fraction.
+	x := big.NewRat(-7, 3)
+	if s := x.SetFloat64(6).String(); s != "6/1" {
+		t.Errorf("NewRat(-7, 3).SetFloat64(6) = %s", s)
+	}
+}
+
+func TestRatSetFloat64NonFinite(t *testing.T) {
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if x := new(big.Rat).SetFloat64(f); x != nil {
+			t.Errorf("SetFloat64(%g) = %s, want nil", f, x)
+		}
+	}
+}
+
+func TestRatFloat32(t *testing.T) {
+	tests := []struct {
+		x     string
+		want  float32
+		exact bool
+	}{
+		{"0", 0, true},
+		{"1/2", 0.5, true},
+		{"-3/4", -0.75, true},
+		{"1/3", 1.0 / 3, false},
+		{"-1/3", -1.0 / 3, false},
+		{"1/10", 0.1, false},
+		{"1180591620717411303424", 0x1p70, true}, // 2**70
+		{"18446744073709551617", 0x1p64, false},  // 2**64 + 1
+		{"16777217", 16777216, false},            // halfway, rounds to even
+		{"16777219", 16777220, false},            // halfway, rounds to even
+		{"1/713623846352979940529142984724747568191373312", 0x1p-149, true}, // smallest denormal
+		{"1/1427247692705959881058285969449495136382746624", 0, false},      // half of it, rounds to even
+		{"1e39", float32(math.Inf(1)), false},
+		{"-1e39", float32(math.Inf(-1)), false},
+	}
+	for _, tt := range tests {
+		x, ok := new(big.Rat).SetString(tt.x)
+		if !ok {
+			t.Fatalf("SetString(%q) failed", tt.x)
+		}
+		if f, exact := x.Float32(); f != tt.want || exact != tt.exact {
+			t.Errorf("%s.Float32() = %g, %v, want %g, %v", tt.x, f, exact, tt.want, tt.exact)
+		}
+	}
+
+	// Every float32 round-trips exactly through SetFloat64.
+	for _, f := range []float32{1, -1.5, 0.1, 3.4028235e38, 1.1754944e-38, 1e-45, 123456.79} {
+		if g, exact := new(big.Rat).SetFloat64(float64(f)).Float32(); g != f || !exact {
+			t.Errorf("SetFloat64(%g).Float32() = %g, %v", f, g, exact)
+		}
+	}
+}
+
+func TestRatFloat64(t *testing.T) {
+	tests := []struct {
+		x     string
+		want  float64
+		exact bool
+	}{
+		{"1/3", 1.0 / 3, false},
+		{"1/10", 0.1, false},
+		{"-2/3", -2.0 / 3, false},
+		{"18446744073709551617", 0x1p64, false}, // 2**64 + 1
+		{"9007199254740993", 9007199254740992, false},
+		{"1e309", math.Inf(1), false},
+		{"1e-400", 0, false},
+	}
+	for _, tt := range tests {
+		x, ok := new(big.Rat).SetString(tt.x)
+		if !ok {
+			t.Fatalf("SetString(%q) failed", tt.x)
+		}
+		if f, exact := x.Float64(); f != tt.want || exact != tt.exact {
+			t.Errorf("%s.Float64() = %g, %v, want %g, %v", tt.x, f, exact, tt.want, tt.exact)
+		}
+	}
+	if f, exact := new(big.Rat).Float64(); f != 0 || !exact {
+		t.Errorf("zero Rat Float64() = %g, %v", f, exact)
+	}
+}
+
+func TestRatArith(t *testing.T) {
+	r := func(s string) *big.Rat {
+		t.Helper()
+		x, ok := new(big.Rat).SetString(s)
+		if !ok {
+			t.Fatalf("SetString(%q) failed", s)
+		}
+		return x
+	}
+	tests := []struct {
+		x, y            string
+		sum, diff, prod string
+		quo             string
+		cmp             int
+	}{
+		{"1/2", "1/3", "5/6", "1/6", "1/6", "3/2", 1},
+		{"-1/2", "1/2", "0/1", "-1/1", "-1/4", "-1/1", -1},
+		{"3/4", "-3/4", "0/1", "3/2", "-9/16", "-1/1", 1},
+		{"2", "4/6", "8/3", "4/3", "4/3", "3/1", 1},
+		{"0", "-5/7", "-5/7", "5/7", "0/1", "0/1", 1},
+		{"7/3", "7/3", "14/3", "0/1", "49/9", "1/1", 0},
+		{"18446744073709551616/3", "1/18446744073709551616", "340282366920938463463374607431768211459/55340232221128654848", "340282366920938463463374607431768211453/55340232221128654848", "1/3", "340282366920938463463374607431768211456/3", 1},
+	}
+	for _, tt := range tests {
+		x, y := r(tt.x), r(tt.y)
+		if got := new(big.Rat).Add(x, y).String(); got != tt.sum {
+			t.Errorf("%s + %s = %s, want %s", x, y, got, tt.sum)
+		}
+		if got := new(big.Rat).Sub(x, y).String(); got != tt.diff {
+			t.Errorf("%s - %s = %s, want %s", x, y, got, tt.diff)
+		}
+		if got := new(big.Rat).Mul(x, y).String(); got != tt.prod {
+			t.Errorf("%s * %s = %s, want %s", x, y, got, tt.prod)
+		}
+		if got := new(big.Rat).Quo(x, y).String(); got != tt.quo {
+			t.Errorf("%s / %s = %s, want %s", x, y, got, tt.quo)
+		}
+		if got := x.Cmp(y); got != tt.cmp {
+			t.Errorf("%s.Cmp(%s) = %d, want %d", x, y, got, tt.cmp)
+		}
+
+		// The result may alias either operand.
+		if got := r(tt.x).Add(r(tt.x), y).String(); got != tt.sum {
+			t.Errorf("z = x; z += %s: %s, want %s", y, got, tt.sum)
+		}
+		if z := r(tt.y); z.Sub(x, z).String() != tt.diff {
+			t.Errorf("z = y; z = %s - z: %s, want %s", x, z, tt.diff)
+		}
+		if z := r(tt.y); z.Quo(x, z).String() != tt.quo {
+			t.Errorf("z = y; z = %s / z: %s, want %s", x, z, tt.quo)
+		}
+	}
+
+	x := r("-6/4")
+	if s := new(big.Rat).Mul(x, x).String(); s != "9/4" {
+		t.Errorf("x*x = %s, want 9/4", s)
+	}
+	if s := new(big.Rat).Inv(x).String(); s != "-2/3" {
+		t.Errorf("Inv(%s) = %s, want -2/3", x, s)
+	}
+	if s := new(big.Rat).Abs(x).String(); s != "3/2" {
+		t.Errorf("Abs(%s) = %s, want 3/2", x, s)
+	}
+	if s := new(big.Rat).Neg(x).String(); s != "3/2" {
+		t.Errorf("Neg(%s) = %s, want 3/2", x, s)
+	}
+	if x.Sign() != -1 || x.IsInt() || x.Num().String() != "-3" || x.Denom().String() != "2" {
+		t.Errorf("%s: Sign %d, IsInt %v, Num %s, Denom %s", x, x.Sign(), x.IsInt(), x.Num(), x.Denom())
+	}
+
+	// The zero value is 0/1, and its denominator is not a reference.
+	var z big.Rat
+	if z.Sign() != 0 || !z.IsInt() || z.String() != "0/1" || z.Denom().String() != "1" {
+		t.Errorf("zero Rat: Sign %d, IsInt %v, String %s, Denom %s", z.Sign(), z.IsInt(), z.String(), z.Denom())
+	}
+	z.Denom().SetInt64(5)
+	if z.String() != "0/1" {
+		t.Errorf("zero Rat changed to %s by its Denom", z.String())
+	}
+
+	// SetFrac normalizes the sign and the common factors away.
+	if s := new(big.Rat).SetFrac(big.NewInt(10), big.NewInt(-4)).String(); s != "-5/2" {
+		t.Errorf("SetFrac(10, -4) = %s", s)
+	}
+	if s := big.NewRat(math.MinInt64, math.MinInt64).String(); s != "1/1" {
+		t.Errorf("NewRat(MinInt64, MinInt64) = %s", s)
+	}
+	y := big.NewRat(3, 1)
+	if s := y.SetFrac(y.Num(), big.NewInt(-6)).String(); s != "-1/2" {
+		t.Errorf("SetFrac(Num, -6) = %s", s)
+	}
+	if s := y.SetFrac(big.NewInt(5), y.Num()).String(); s != "-5/1" {
+		t.Errorf("SetFrac(5, Num) = %s", s)
+	}
+
+	mustPanic := func(name string, f func()) {
+		t.Helper()
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("%s didn't panic", name)
+			}
+		}()
+		f()
+	}
+	mustPanic("Quo by 0", func() { new(big.Rat).Quo(x, new(big.Rat)) })
+	mustPanic("Inv of 0", func() { new(big.Rat).Inv(new(big.Rat)) })
+	mustPanic("NewRat(1, 0)", func() { big.NewRat(1, 0) })
+	mustPanic("SetFrac(1, 0)", func() { new(big.Rat).SetFrac(big.NewInt(1), new(big.Int)) })
+}
+
+func TestRatSetString(t *testing.T) {
+	tests := []struct {
+		in, out string
+		ok      bool
+	}{
+		{"0", "0", true},
+		{"-0", "0", true},
+		{"1", "1", true},
+		{"-1", "-1", true},
+		{"1.", "1", true},
+		{".5", "1/2", true},
+		{"-.5", "-1/2", true},
+		{"1.25", "5/4", true},
+		{"1e3", "1000", true},
+		{"1e-3", "1/1000", true},
+		{"-1.5E+2", "-150", true},
+		{"0.0000", "0", true},
+		{"010", "10", true}, // a leading 0 is decimal in a float
+		{"1_000.5", "2001/2", true},
+		{"0x10", "16", true},
+		{"0x1.8", "3/2", true},
+		{"0x1p-2", "1/4", true},
+		{"0b101.1", "11/2", true},
+		{"0o17", "15", true},
+		{"1p4", "16", true},
+		{"3/4", "3/4", true},
+		{"-6/8", "-3/4", true},
+		{"010/4", "2", true}, // a leading 0 is octal in a fraction
+		{"0x10/0b11", "16/3", true},
+		{"18446744073709551616/18446744073709551618", "9223372036854775808/9223372036854775809", true},
+		{"123456789012345678901234567890.25", "493827156049382715604938271561/4", true},
+
+		{"", "", false},
+		{"a", "", false},
+		{"1/0", "", false},
+		{"1/-2", "", false},
+		{"1/", "", false},
+		{"/2", "", false},
+		{"1.2.3", "", false},
+		{"1e", "", false},
+		{"1e_5", "", false},
+		{"1__0", "", false},
+		{"0x", "", false},
+		{"1e1000000000", "", false},
+		{" 1", "", false},
+	}
+	for _, tt := range tests {
+		x, ok := new(big.Rat).SetString(tt.in)
+		if ok != tt.ok {
+			t.Errorf("SetString(%q) ok = %v, want %v", tt.in, ok, tt.ok)
+			continue
+		}
+		if ok && x.RatString() != tt.out {
+			t.Errorf("SetString(%q) = %s, want %s", tt.in, x.RatString(), tt.out)
+		}
+	}
+}
+
+func TestRatFloatString(t *testing.T) {
+	tests := []struct {
+		x    string
+		prec int
+		want string
+	}{
+		{"0", 0, "0"},
+		{"0", 3, "0.000"},
+		{"-7", 2, "-7.00"},
+		{"1/3", 0, "0"},
+		{"2/3", 0, "1"},
+		{"2/3", 4, "0.6667"},
+		{"-2/3", 4, "-0.6667"},
+		{"1/2", 0, "1"}, // halves round away from zero
+		{"-1/2", 0, "-1"},
+		{"-1/3", 2, "-0.33"},
+		{"1/200", 2, "0.01"},
+		{"1/8", 2, "0.13"},
+		{"999/1000", 2, "1.00"},
+		{"22/7", 10, "3.1428571429"},
+		{"1/3", 30, "0.333333333333333333333333333333"},
+	}
+	for _, tt := range tests {
+		x, _ := new(big.Rat).SetString(tt.x)
+		if s := x.FloatString(tt.prec); s != tt.want {
+			t.Errorf("%s.FloatString(%d) = %q, want %q", tt.x, tt.prec, s, tt.want)
+		}
+	}
+}
+
+func TestRatFloatPrec(t *testing.T) {
+	tests := []struct {
+		x     string
+		n     int
+		exact bool
+	}{
+		{"0", 0, true},
+		{"1", 0, true},
+		{"1/2", 1, true},
+		{"1/3", 0, false},
+		{"1/4", 2, true},
+		{"1/6", 1, false},
+		{"1/80", 4, true},
+		{"3/1220703125", 13, true},             // 5**13
+		{"1/1490116119384765625", 26, true},    // 5**26
+		{"7/37252902984619140625", 28, true},   // 5**28
+		{"1/2000", 4, true},                    // 2**4 * 5**3
+		{"1/1180591620717411303424", 70, true}, // 2**70
+		{"1/1026", 1, false},                   // 2 * 513
+	}
+	for _, tt := range tests {
+		x, _ := new(big.Rat).SetString(tt.x)
+		if n, exact := x.FloatPrec(); n != tt.n || exact != tt.exact {
+			t.Errorf("%s.FloatPrec() = %d, %v, want %d, %v", tt.x, n, exact, tt.n, tt.exact)
+		}
+	}
+}
+
+func TestRatMarshal(t *testing.T) {
+	for _, s := range []string{"0", "1", "-5/3", "18446744073709551617/18446744073709551616"} {
+		x, _ := new(big.Rat).SetString(s)
+
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(x); err != nil {
+			t.Fatalf("gob Encode(%s): %v", x, err)
+		}
+		var g big.Rat
+		if err := gob.NewDecoder(&buf).Decode(&g); err != nil || g.Cmp(x) != 0 {
+			t.Errorf("gob round trip of %s = %s, %v", x, &g, err)
+		}
+
+		text, err := x.MarshalText()
+		if err != nil || string(text) != x.RatString() {
+			t.Errorf("MarshalText(%s) = %q, %v", x, text, err)
+		}
+		var u big.Rat
+		if err := u.UnmarshalText(text); err != nil || u.Cmp(x) != 0 {
+			t.Errorf("UnmarshalText(%q) = %s, %v", text, &u, err)
+		}
+
+		js, err := json.Marshal(x)
+		if err != nil {
+			t.Fatalf("json.Marshal(%s): %v", x, err)
+		}
+		var j big.Rat
+		if err := json.Unmarshal(js, &j); err != nil || j.Cmp(x) != 0 {
+			t.Errorf("json round trip of %s via %s = %s, %v", x, js, &j, err)
+		}
+	}
+	if err := new(big.Rat).UnmarshalText([]byte("1/0")); err == nil {
+		t.Error(`UnmarshalText("1/0") succeeded`)
+	}
+	if err := new(big.Rat).GobDecode([]byte{1 << 1, 0, 0}); err == nil {
+		t.Error("GobDecode of a short buffer succeeded")
+	}
+}
@@ -19,3 +19,29 @@ func TestCstr(t *testing.T) {
 		t.Fatal("cstr() returned invalid length")
 	}
 }
+
+func TestGoBytes(t *testing.T) {
+	p := (*[4]byte)(c.Malloc(4))
+	defer c.Free(c.Pointer(p))
+	copy(p[:], "abcd")
+
+	b := c.GoBytes(c.Pointer(p), 4)
+	if string(b) != "abcd" || cap(b) != 4 {
+		t.Fatalf("GoBytes = %q (cap %d), want %q", b, cap(b), "abcd")
+	}
+	p[0] = 'x'
+	if string(b) != "abcd" {
+		t.Fatalf("GoBytes result changed with the C memory: %q", b)
+	}
+	b[1] = 'y'
+	if p[1] != 'b' {
+		t.Fatalf("writing the GoBytes result changed the C memory: %q", p[:])
+	}
+
+	if b := c.GoBytes(c.Pointer(p), 0); b == nil || len(b) != 0 {
+		t.Fatalf("GoBytes(p, 0) = %#v, want a non-nil empty slice", b)
+	}
+	if b := c.GoBytes(nil, 0); b == nil || len(b) != 0 {
+		t.Fatalf("GoBytes(nil, 0) = %#v, want a non-nil empty slice", b)
+	}
+}
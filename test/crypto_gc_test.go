@@ -0,0 +1,51 @@
+//go:build llgo && !nogc
+// +build llgo,!nogc
+
+package test
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"runtime"
+	"testing"
+)
+
+// The OpenSSL contexts of unreachable AES blocks and modes are freed by
+// their finalizers: the memory of a loop setting up ciphers stays bounded,
+// although the contexts live outside the memory managed by the garbage
+// collector.
+func TestAESFinalizerMemoryBounded(t *testing.T) {
+	if residentKB(t) < 0 {
+		t.Skip("no /proc/self/status")
+	}
+	key := make([]byte, 32)
+	iv := make([]byte, aes.BlockSize)
+	buf := make([]byte, aes.BlockSize)
+	churn := func(n int) {
+		for i := 0; i < n; i++ {
+			b, err := aes.NewCipher(key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b.Encrypt(buf, buf)
+			cipher.NewCBCEncrypter(b, iv).CryptBlocks(buf, buf)
+			cipher.NewCBCDecrypter(b, iv).CryptBlocks(buf, buf)
+			cipher.NewCTR(b, iv).XORKeyStream(buf, buf)
+			if _, err := cipher.NewGCM(b); err != nil {
+				t.Fatal(err)
+			}
+			if i%1024 == 0 {
+				runtime.GC()
+			}
+		}
+		runtime.GC()
+	}
+	churn(1 << 10) // warm up the malloc arenas
+	before := residentKB(t)
+
+	const n = 1 << 15 // 7 contexts each, well over 128 MiB if they leaked
+	churn(n)
+	if grown := residentKB(t) - before; grown > 32<<10 {
+		t.Errorf("resident memory grew by %d KiB over %d rounds of AES setups", grown, n)
+	}
+}
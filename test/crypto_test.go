@@ -0,0 +1,568 @@
+//go:build llgo
+// +build llgo
+
+package test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+var msg = []byte("The fog is getting thicker!")
+
+// Sum256 hashes in one OpenSSL call, without allocating a context.
+func BenchmarkSum256(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sha256.Sum256(msg)
+	}
+}
+
+// A reused hash.Hash keeps its context across Reset, Write and Sum.
+func BenchmarkSha256Reuse(b *testing.B) {
+	b.ReportAllocs()
+	h := sha256.New()
+	buf := make([]byte, 0, sha256.Size)
+	for i := 0; i < b.N; i++ {
+		h.Reset()
+		h.Write(msg)
+		buf = h.Sum(buf[:0])
+	}
+}
+
+func TestSum256Reuse(t *testing.T) {
+	want := sha256.Sum256(msg)
+	h := sha256.New()
+	for i := 0; i < 3; i++ {
+		h.Reset()
+		h.Write(msg)
+		if got := h.Sum(nil); string(got) != string(want[:]) {
+			t.Fatalf("round %d: got %x, want %x", i, got, want)
+		}
+	}
+}
+
+// The HMAC digest keeps its key across Reset and its state across Sum, as
+// HKDF relies on.
+func TestHMACReuse(t *testing.T) {
+	const want = "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"
+	fox := []byte("The quick brown fox jumps over the lazy dog")
+	h := hmac.New(sha256.New, []byte("key"))
+	if h.Size() != sha256.Size || h.BlockSize() != sha256.BlockSize {
+		t.Errorf("Size, BlockSize = %d, %d", h.Size(), h.BlockSize())
+	}
+	h.Write([]byte("junk"))
+	h.Reset()
+	h.Write(fox[:10])
+	if got := hex.EncodeToString(h.Sum(nil)); got == want {
+		t.Errorf("Sum of a prefix = %s", got)
+	}
+	h.Write(fox[10:])
+	for i := 0; i < 2; i++ {
+		if got := hex.EncodeToString(h.Sum(nil)); got != want {
+			t.Errorf("Sum #%d = %s, want %s", i, got, want)
+		}
+	}
+}
+
+func TestHMACSHA1(t *testing.T) {
+	// RFC 2202, test case 2.
+	h := hmac.New(sha1.New, []byte("Jefe"))
+	h.Write([]byte("what do ya want for nothing?"))
+	if got, want := hex.EncodeToString(h.Sum(nil)), "effcdf6ae5eb2fa2d27416d5f184df9c259a7c79"; got != want {
+		t.Errorf("HMAC-SHA1 = %s, want %s", got, want)
+	}
+	if h.Size() != sha1.Size {
+		t.Errorf("Size = %d", h.Size())
+	}
+}
+
+// NIST SP 800-38A, appendix F: the four plaintext blocks shared by the
+// examples, and the keys of its AES-128, AES-192 and AES-256 examples.
+var (
+	sp80038aPlain = "6bc1bee22e409f96e93d7e117393172aae2d8a571e03ac9c9eb76fac45af8e51" +
+		"30c81c46a35ce411e5fbc1191a0a52eff69f2445df4f9b17ad2b417be66c3710"
+	sp80038aKeys = []string{
+		"2b7e151628aed2a6abf7158809cf4f3c",
+		"8e73b0f7da0e6452c810f32b809079e562f8ead2522c6b7b",
+		"603deb1015ca71be2b73aef0857d77811f352c073b6108d72d9810a30914dff4",
+	}
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestAESBlock(t *testing.T) {
+	// FIPS 197, appendix C.1.
+	key := mustHex(t, "000102030405060708090a0b0c0d0e0f")
+	pt := mustHex(t, "00112233445566778899aabbccddeeff")
+	ct := mustHex(t, "69c4e0d86a7b0430d8cdb78070b4c55a")
+	b, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, aes.BlockSize)
+	b.Encrypt(buf, pt)
+	if !bytes.Equal(buf, ct) {
+		t.Errorf("Encrypt = %x, want %x", buf, ct)
+	}
+	b.Decrypt(buf, buf)
+	if !bytes.Equal(buf, pt) {
+		t.Errorf("Decrypt = %x, want %x", buf, pt)
+	}
+	if _, err := aes.NewCipher(key[:15]); err == nil || err.Error() != "crypto/aes: invalid key size 15" {
+		t.Errorf("NewCipher(15 bytes) error = %v", err)
+	}
+}
+
+func TestAESCBC(t *testing.T) {
+	// NIST SP 800-38A, F.2.1 to F.2.6.
+	iv := mustHex(t, "000102030405060708090a0b0c0d0e0f")
+	cts := []string{
+		"7649abac8119b246cee98e9b12e9197d5086cb9b507219ee95db113a917678b2" +
+			"73bed6b8e3c1743b7116e69e222295163ff1caa1681fac09120eca307586e1a7",
+		"4f021db243bc633d7178183a9fa071e8b4d9ada9ad7dedf4e5e738763f69145a" +
+			"571b242012fb7ae07fa9baac3df102e008b0e27988598881d920a9e64f5615cd",
+		"f58c4c04d6e5f1ba779eabfb5f7bfbd69cfc4e967edb808d679f777bc6702c7d" +
+			"39f23369a9d9bacfa530e26304231461b2eb05e2c39be9fcda6c19078c6a9d1b",
+	}
+	pt := mustHex(t, sp80038aPlain)
+	for i, key := range sp80038aKeys {
+		b, err := aes.NewCipher(mustHex(t, key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ct := mustHex(t, cts[i])
+
+		// Two calls must chain like one.
+		buf := make([]byte, len(pt))
+		enc := cipher.NewCBCEncrypter(b, iv)
+		enc.CryptBlocks(buf[:16], pt[:16])
+		enc.CryptBlocks(buf[16:], pt[16:])
+		if !bytes.Equal(buf, ct) {
+			t.Errorf("AES-%d CBC encrypt = %x, want %x", len(key)*4, buf, ct)
+		}
+
+		// In place.
+		cipher.NewCBCDecrypter(b, iv).CryptBlocks(buf, buf)
+		if !bytes.Equal(buf, pt) {
+			t.Errorf("AES-%d CBC decrypt = %x, want %x", len(key)*4, buf, pt)
+		}
+	}
+}
+
+func TestAESCTR(t *testing.T) {
+	// NIST SP 800-38A, F.5.1 to F.5.6.
+	iv := mustHex(t, "f0f1f2f3f4f5f6f7f8f9fafbfcfdfeff")
+	cts := []string{
+		"874d6191b620e3261bef6864990db6ce9806f66b7970fdff8617187bb9fffdff" +
+			"5ae4df3edbd5d35e5b4f09020db03eab1e031dda2fbe03d1792170a0f3009cee",
+		"1abc932417521ca24f2b0459fe7e6e0b090339ec0aa6faefd5ccc2c6f4ce8e94" +
+			"1e36b26bd1ebc670d1bd1d665620abf74f78a7f6d29809585a97daec58c6b050",
+		"601ec313775789a5b7a7f504bbf3d228f443e3ca4d62b59aca84e990cacaf5c5" +
+			"2b0930daa23de94ce87017ba2d84988ddfc9c58db67aada613c2dd08457941a6",
+	}
+	pt := mustHex(t, sp80038aPlain)
+	for i, key := range sp80038aKeys {
+		b, err := aes.NewCipher(mustHex(t, key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ct := mustHex(t, cts[i])
+
+		buf := make([]byte, len(pt))
+		cipher.NewCTR(b, iv).XORKeyStream(buf, pt)
+		if !bytes.Equal(buf, ct) {
+			t.Errorf("AES-%d CTR encrypt = %x, want %x", len(key)*4, buf, ct)
+		}
+
+		// The key stream carries over between pieces not aligned to blocks.
+		s := cipher.NewCTR(b, iv)
+		for n, rest := 1, buf; len(rest) > 0; n += 6 {
+			if n > len(rest) {
+				n = len(rest)
+			}
+			s.XORKeyStream(rest[:n], rest[:n])
+			rest = rest[n:]
+		}
+		if !bytes.Equal(buf, pt) {
+			t.Errorf("AES-%d CTR decrypt = %x, want %x", len(key)*4, buf, pt)
+		}
+	}
+}
+
+// The CTR counter is the whole 128-bit IV, so it wraps from all ones to zero.
+func TestAESCTRCounterWrap(t *testing.T) {
+	b, err := aes.NewCipher(mustHex(t, sp80038aKeys[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	iv := bytes.Repeat([]byte{0xff}, aes.BlockSize)
+	got := make([]byte, 2*aes.BlockSize)
+	cipher.NewCTR(b, iv).XORKeyStream(got, got)
+	want := make([]byte, 2*aes.BlockSize)
+	b.Encrypt(want, iv)
+	b.Encrypt(want[aes.BlockSize:], make([]byte, aes.BlockSize))
+	if !bytes.Equal(got, want) {
+		t.Errorf("key stream = %x, want %x", got, want)
+	}
+}
+
+// The modes panic with the messages of crypto/cipher's generic CBC and CTR,
+// which the standard AES-specific code doesn't use in every Go release.
+func TestAESModePanics(t *testing.T) {
+	b, err := aes.NewCipher(mustHex(t, sp80038aKeys[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	iv := make([]byte, aes.BlockSize)
+	buf := make([]byte, 64)
+	tests := []struct {
+		name, want string
+		f          func()
+	}{
+		{"CBC short IV", "cipher.NewCBCEncrypter: IV length must equal block size", func() {
+			cipher.NewCBCEncrypter(b, iv[:8])
+		}},
+		{"CTR short IV", "cipher.NewCTR: IV length must equal block size", func() {
+			cipher.NewCTR(b, iv[:8])
+		}},
+		{"CBC partial block", "crypto/cipher: input not full blocks", func() {
+			cipher.NewCBCEncrypter(b, iv).CryptBlocks(buf, buf[:20])
+		}},
+		{"CBC short output", "crypto/cipher: output smaller than input", func() {
+			cipher.NewCBCDecrypter(b, iv).CryptBlocks(buf[:16], buf[16:48])
+		}},
+		{"CBC overlap", "crypto/cipher: invalid buffer overlap", func() {
+			cipher.NewCBCEncrypter(b, iv).CryptBlocks(buf[1:33], buf[:32])
+		}},
+		{"CTR short output", "crypto/cipher: output smaller than input", func() {
+			cipher.NewCTR(b, iv).XORKeyStream(buf[:5], buf[10:20])
+		}},
+		{"CTR overlap", "crypto/cipher: invalid buffer overlap", func() {
+			cipher.NewCTR(b, iv).XORKeyStream(buf[1:11], buf[:10])
+		}},
+	}
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r != tt.want {
+					t.Errorf("%s: panic %v, want %q", tt.name, r, tt.want)
+				}
+			}()
+			tt.f()
+		}()
+	}
+}
+
+func TestAESGCM(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	msg, aad := []byte("hello, gcm world!"), []byte("aad")
+	tests := []struct {
+		nonceSize, tagSize int
+		want               string
+	}{
+		{12, 16, "4580d710b634f2cbcbb151c91ebdbd60c7dcf7cead9b256a4860f7c9bdad26439e"},
+		{16, 16, "8ea3fecca751fe72a3e3ccfad37c7cc3ef604d9a11ba949fa4d48e44afe87deedd"},
+		{8, 16, "ea091141b3e6102235605ad52ec81c8ffc51b11631750c6f96e389b5029e178f73"},
+	}
+	b, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		g, err := cipher.NewGCMWithNonceSize(b, tt.nonceSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nonce := make([]byte, tt.nonceSize)
+		for i := range nonce {
+			nonce[i] = byte(i)
+		}
+		// Twice, as the contexts are reused.
+		for i := 0; i < 2; i++ {
+			ct := g.Seal([]byte("prefix"), nonce, msg, aad)
+			if got := hex.EncodeToString(ct[6:]); string(ct[:6]) != "prefix" || got != tt.want {
+				t.Errorf("nonce size %d: Seal = %q %s, want %s", tt.nonceSize, ct[:6], got, tt.want)
+			}
+			pt, err := g.Open(ct[6:6], nonce, ct[6:], aad)
+			if err != nil || !bytes.Equal(pt, msg) {
+				t.Errorf("nonce size %d: Open = %q, %v", tt.nonceSize, pt, err)
+			}
+		}
+	}
+
+	// The GCM specification, test case 2, with a 12-byte tag kept.
+	b, _ = aes.NewCipher(make([]byte, 16))
+	g, err := cipher.NewGCMWithTagSize(b, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ct := g.Seal(nil, make([]byte, 12), make([]byte, 16), nil)
+	if got, want := hex.EncodeToString(ct), "0388dace60b6a392f328c2b971b2fe78ab6e47d42cec13bdf53a67b2"; got != want {
+		t.Errorf("Seal = %s, want %s", got, want)
+	}
+}
+
+// A tampered message fails to open with an error naming the failed check
+// instead of decrypting to garbage, and leaves no plaintext in dst.
+func TestAESGCMOpenTampered(t *testing.T) {
+	b, err := aes.NewCipher(mustHex(t, sp80038aKeys[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := cipher.NewGCM(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce := make([]byte, g.NonceSize())
+	msg, aad := []byte("attack at dawn"), []byte("header")
+	sealed := g.Seal(nil, nonce, msg, aad)
+	tamper := func(i int) []byte {
+		ct := append([]byte(nil), sealed...)
+		ct[i] ^= 1
+		return ct
+	}
+	tests := []struct {
+		name    string
+		ct, aad []byte
+	}{
+		{"ciphertext", tamper(0), aad},
+		{"tag", tamper(len(sealed) - 1), aad},
+		{"additional data", sealed, []byte("Header")},
+		{"truncated", sealed[:g.Overhead()-1], aad},
+	}
+	for _, tt := range tests {
+		dst := make([]byte, 0, 64)
+		pt, err := g.Open(dst, nonce, tt.ct, tt.aad)
+		if err == nil || pt != nil {
+			t.Errorf("%s: Open = %q, %v; want an error", tt.name, pt, err)
+			continue
+		}
+		if !strings.Contains(err.Error(), "message authentication failed") {
+			t.Errorf("%s: error %q", tt.name, err)
+		}
+		if leaked := dst[:len(msg)]; !bytes.Equal(leaked, make([]byte, len(msg))) {
+			t.Errorf("%s: dst holds %q", tt.name, leaked)
+		}
+	}
+
+	// The error says which OpenSSL call failed.
+	if _, err := g.Open(nil, nonce, tamper(0), aad); !strings.Contains(err.Error(), "EVP_CipherFinal_ex") {
+		t.Errorf("error %q doesn't name EVP_CipherFinal_ex", err)
+	}
+}
+
+// A 1024-bit key generated by the crypto/rsa of the standard library, with
+// its PKCS #1 v1.5 signature of the SHA-256 of "llgo" and its PKCS #1 v1.5
+// encryption of "session key".
+const (
+	rsaN1024 = "d789a3288492994c0cfd68ace459163b1e8c276b18538b0676baa3d9499877e1" +
+		"a31e8318b77afcb44c970c1741b58984d2fca197e57625ffb35e032528236511" +
+		"7e4c6f4fc58e3b1e2c7a98d0347b1f2791072ecb36518416e18ff6ce36f35389" +
+		"3b0511ccf1a5a3fe02d18d1991a2121633ca34a014dc95288ba488711b27f199"
+	rsaD1024 = "3eec3f161a91e3aff890d848b5072efec65dbb3a40294f8a6d09f2c082044167" +
+		"3d6430787c442ad7060f08a817764475e2d47ee665c636b4adec11b5fcc04c86" +
+		"24337eee2ae292ee0e390ee1e0d3e684be013abf2c6fb3c92f6e89112448c83e" +
+		"c37dc401f2fdfbfbedd057b63aa35e69f2a6ec9f0a94e9d735b076402a79230b"
+	rsaP1024 = "edf63fcbbc5038db67ff1ae66f1c35de1a62e8b66f5f4f6df98f525e86e4b256" +
+		"756102be53b99282dc36c85c397f2d5f4485ebb491e3fb8949f52854c840d1d7"
+	rsaQ1024 = "e7e03c60f9ad784623275913ee0289051c07ba096a2cc9e7ee7d318cce2f1f9f" +
+		"46ea39bb1dddbcfc3355154b92ae4a19a25319965ce5d9395ba24db96b78ca0f"
+	rsaSig1024 = "8293fae0137c92c49e7edd96e9a4295075a2ac40ee2b82844812432a1c7830dd" +
+		"c6e48ad6e31a6de35f4431515456c427f298505e574a387dc91e836a6b6aa3e2" +
+		"7b5e95f5beed3e8b9ac59c113ddb83cf94049a92808bacaa53d221c98518317b" +
+		"97ce2dabe2b57bf31ff14f8e31382ae25154281c17c61b892f627a271f85bc25"
+	rsaCipher1024 = "b856f88e26267d29f294998fa01bf195db5c5b64b447cd17157ef7e513202f2d" +
+		"d35d2dee948c7f78e0f9e5554b2cacb669e4671379e4fbeb0f09d97962077698" +
+		"1ba708299aa5fe7112b3c4f791ed392de5e1fa0079fbf3c64e101d43edcd7cc6" +
+		"88767c8e70d4c137c067acfdc78fe5d796bf0df7965b29f3dd24ee1b9b66ff09"
+)
+
+func rsaKey1024(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	n := func(s string) *big.Int { return new(big.Int).SetBytes(mustHex(t, s)) }
+	key := &rsa.PrivateKey{
+		PublicKey: rsa.PublicKey{N: n(rsaN1024), E: 65537},
+		D:         n(rsaD1024),
+		Primes:    []*big.Int{n(rsaP1024), n(rsaQ1024)},
+	}
+	if err := key.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	key.Precompute()
+	return key
+}
+
+func TestRSAKnownAnswer(t *testing.T) {
+	key := rsaKey1024(t)
+	hashed := sha256.Sum256([]byte("llgo"))
+	want := mustHex(t, rsaSig1024)
+	sig, err := rsa.SignPKCS1v15(nil, key, crypto.SHA256, hashed[:])
+	if err != nil || !bytes.Equal(sig, want) {
+		t.Fatalf("SignPKCS1v15 = %x, %v, want %x", sig, err, want)
+	}
+	// The same signature without the CRT values, by D alone.
+	plain := &rsa.PrivateKey{PublicKey: key.PublicKey, D: key.D, Primes: key.Primes}
+	if sig, err := rsa.SignPKCS1v15(nil, plain, crypto.SHA256, hashed[:]); err != nil || !bytes.Equal(sig, want) {
+		t.Fatalf("SignPKCS1v15 without Precompute = %x, %v, want %x", sig, err, want)
+	}
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hashed[:], want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := rsa.DecryptPKCS1v15(nil, key, mustHex(t, rsaCipher1024))
+	if err != nil || string(got) != "session key" {
+		t.Fatalf("DecryptPKCS1v15 = %q, %v, want \"session key\"", got, err)
+	}
+}
+
+func TestRSARoundTrip(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.N.BitLen() != 2048 || len(key.Primes) != 2 || key.Precomputed.Dp == nil {
+		t.Fatalf("GenerateKey: %d-bit modulus, %d primes", key.N.BitLen(), len(key.Primes))
+	}
+	if err := key.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	pub := &key.PublicKey
+
+	hashed := sha256.Sum256(msg)
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hashed[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sig) != pub.Size() {
+		t.Fatalf("len(sig) = %d, want %d", len(sig), pub.Size())
+	}
+	if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, hashed[:], sig); err != nil {
+		t.Fatal(err)
+	}
+	var signer crypto.Signer = key
+	if sig2, err := signer.Sign(rand.Reader, hashed[:], crypto.SHA256); err != nil || !bytes.Equal(sig2, sig) {
+		t.Fatalf("Sign = %x, %v, want %x", sig2, err, sig)
+	}
+	sig[len(sig)-1] ^= 1
+	if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, hashed[:], sig); err != rsa.ErrVerification {
+		t.Fatalf("VerifyPKCS1v15 of a tampered signature = %v", err)
+	}
+	sig[len(sig)-1] ^= 1
+	hashed[0] ^= 1
+	if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, hashed[:], sig); err != rsa.ErrVerification {
+		t.Fatalf("VerifyPKCS1v15 of another digest = %v", err)
+	}
+
+	c1, err := rsa.EncryptPKCS1v15(rand.Reader, pub, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c2, err := rsa.EncryptPKCS1v15(rand.Reader, pub, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(c1, c2) {
+		t.Fatal("EncryptPKCS1v15 is deterministic")
+	}
+	for _, c := range [][]byte{c1, c2} {
+		if got, err := rsa.DecryptPKCS1v15(nil, key, c); err != nil || !bytes.Equal(got, msg) {
+			t.Fatalf("DecryptPKCS1v15 = %q, %v, want %q", got, err, msg)
+		}
+	}
+	if _, err := rsa.DecryptPKCS1v15(nil, key, make([]byte, pub.Size())); err != rsa.ErrDecryption {
+		t.Fatalf("DecryptPKCS1v15 of zeros = %v", err)
+	}
+	if _, err := rsa.EncryptPKCS1v15(rand.Reader, pub, make([]byte, pub.Size()-10)); err != rsa.ErrMessageTooLong {
+		t.Fatalf("EncryptPKCS1v15 of %d bytes = %v", pub.Size()-10, err)
+	}
+	if _, err := rsa.EncryptPKCS1v15(rand.Reader, pub, make([]byte, pub.Size()-11)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRSADecrypter(t *testing.T) {
+	key := rsaKey1024(t)
+	c := mustHex(t, rsaCipher1024)
+	var dec crypto.Decrypter = key
+	for _, opts := range []crypto.DecrypterOpts{nil, &rsa.PKCS1v15DecryptOptions{}, &rsa.PKCS1v15DecryptOptions{SessionKeyLen: 11}} {
+		if got, err := dec.Decrypt(rand.Reader, c, opts); err != nil || string(got) != "session key" {
+			t.Fatalf("Decrypt with %#v = %q, %v, want \"session key\"", opts, got, err)
+		}
+	}
+	// A padding error leaves a session key of the right length unchanged.
+	sk := []byte("random key!")
+	if err := rsa.DecryptPKCS1v15SessionKey(nil, key, make([]byte, key.Size()), sk); err != nil || string(sk) != "random key!" {
+		t.Fatalf("DecryptPKCS1v15SessionKey of zeros = %q, %v", sk, err)
+	}
+	if err := rsa.DecryptPKCS1v15SessionKey(nil, key, c, sk[:10]); err != nil || string(sk) != "random key!" {
+		t.Fatalf("DecryptPKCS1v15SessionKey into 10 bytes = %q, %v", sk, err)
+	}
+	if err := rsa.DecryptPKCS1v15SessionKey(nil, key, c, sk); err != nil || string(sk) != "session key" {
+		t.Fatalf("DecryptPKCS1v15SessionKey = %q, %v, want \"session key\"", sk, err)
+	}
+	if _, err := dec.Decrypt(rand.Reader, c, crypto.SHA256); err == nil {
+		t.Fatal("Decrypt accepted a crypto.Hash as options")
+	}
+}
+
+// OAEP, PSS and multi-prime key generation panic, instead of being missing
+// from the package.
+func TestRSATodo(t *testing.T) {
+	key := rsaKey1024(t)
+	hashed := sha256.Sum256(msg)
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{"SignPSS", func() {
+			rsa.SignPSS(rand.Reader, key, crypto.SHA256, hashed[:], &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		}},
+		{"VerifyPSS", func() {
+			rsa.VerifyPSS(&key.PublicKey, crypto.SHA256, hashed[:], nil, nil)
+		}},
+		{"SignPSS", func() {
+			key.Sign(rand.Reader, hashed[:], &rsa.PSSOptions{Hash: crypto.SHA256})
+		}},
+		{"EncryptOAEP", func() {
+			rsa.EncryptOAEP(sha256.New(), rand.Reader, &key.PublicKey, msg, nil)
+		}},
+		{"DecryptOAEP", func() {
+			rsa.DecryptOAEP(sha256.New(), nil, key, make([]byte, key.Size()), nil)
+		}},
+		{"DecryptOAEP", func() {
+			key.Decrypt(nil, make([]byte, key.Size()), &rsa.OAEPOptions{Hash: crypto.SHA256})
+		}},
+		{"GenerateMultiPrimeKey", func() {
+			rsa.GenerateMultiPrimeKey(rand.Reader, 3, 1024)
+		}},
+	}
+	for _, tt := range tests {
+		func() {
+			want := "todo: rsa." + tt.name
+			defer func() {
+				if r := recover(); r != want {
+					t.Errorf("panic %v, want %q", r, want)
+				}
+			}()
+			tt.f()
+		}()
+	}
+}
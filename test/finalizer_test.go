@@ -0,0 +1,80 @@
+//go:build llgo && !nogc
+// +build llgo,!nogc
+
+package test
+
+import (
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// residentKB returns the resident set size of the process in KiB, or -1 if
+// there is no /proc to read it from.
+func residentKB(t *testing.T) int {
+	t.Helper()
+	status, err := os.ReadFile("/proc/self/status")
+	if err != nil {
+		return -1
+	}
+	for _, line := range strings.Split(string(status), "\n") {
+		if f := strings.Fields(line); len(f) == 3 && f[0] == "VmRSS:" {
+			n, err := strconv.Atoi(f[1]) // in kB
+			if err != nil {
+				t.Fatalf("bad VmRSS line %q", line)
+			}
+			return n
+		}
+	}
+	return -1
+}
+
+type finalized struct {
+	buf [64]byte
+}
+
+// Finalizers run once their objects are unreachable. The collector is
+// conservative, so the test only asks for most of them to have run.
+func TestSetFinalizer(t *testing.T) {
+	const n = 1000
+	var runs, cleared int32
+	func() {
+		for i := 0; i < n; i++ {
+			p := new(finalized)
+			runtime.SetFinalizer(p, func(p *finalized) { atomic.AddInt32(&runs, 1) })
+			q := new(finalized)
+			runtime.SetFinalizer(q, func(q *finalized) { atomic.AddInt32(&cleared, 1) })
+			runtime.SetFinalizer(q, nil)
+		}
+	}()
+	for i := 0; i < 10 && atomic.LoadInt32(&runs) < n/2; i++ {
+		runtime.GC()
+	}
+	if got := atomic.LoadInt32(&runs); got < n/2 {
+		t.Errorf("%d of %d finalizers ran", got, n)
+	}
+	if got := atomic.LoadInt32(&cleared); got != 0 {
+		t.Errorf("%d cleared finalizers ran", got)
+	}
+}
+
+func TestSetFinalizerInvalid(t *testing.T) {
+	for name, f := range map[string]func(){
+		"non-pointer":   func() { runtime.SetFinalizer(finalized{}, func(*finalized) {}) },
+		"nil pointer":   func() { runtime.SetFinalizer((*finalized)(nil), func(*finalized) {}) },
+		"non-function":  func() { runtime.SetFinalizer(new(finalized), 42) },
+		"two arguments": func() { runtime.SetFinalizer(new(finalized), func(*finalized, int) {}) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("SetFinalizer with a %s didn't panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
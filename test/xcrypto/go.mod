@@ -0,0 +1,5 @@
+module xcrypto
+
+go 1.20
+
+require golang.org/x/crypto v0.33.0
@@ -0,0 +1,146 @@
+//go:build llgo
+// +build llgo
+
+// Package xcrypto tests the overlays of golang.org/x/crypto packages. It is
+// a module of its own, so that llgo itself doesn't require golang.org/x/crypto:
+//
+//	cd test/xcrypto && llgo test .
+package xcrypto
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"testing"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// Test cases of RFC 5869, Appendix A. A salt of "-" stands for a nil one.
+var hkdfVectors = []struct {
+	hash                    func() hash.Hash
+	secret, salt, info, prk string
+	okm                     string
+}{
+	{
+		sha256.New, "0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b", "000102030405060708090a0b0c", "f0f1f2f3f4f5f6f7f8f9",
+		"077709362c2e32df0ddc3f0dc47bba6390b6c73bb50f9c3122ec844ad7c2b3e5",
+		"3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865",
+	},
+	{
+		sha256.New, hkdfSeq(0x00, 0x50), hkdfSeq(0x60, 0xb0), hkdfSeq(0xb0, 0x100),
+		"06a6b88c5853361a06104c9ceb35b45cef760014904671014a193f40c15fc244",
+		"b11e398dc80327a1c8e7f78c596a49344f012eda2d4efad8a050cc4c19afa97c59045a99cac7827271cb41c65e590e09da3275600c2f09b8367793a9aca3db71cc30c58179ec3e87c14c01d5c1f3434f1d87",
+	},
+	{
+		sha256.New, "0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b", "", "",
+		"19ef24a32c717b167f33a91d6f648bdf96596776afdb6377ac434c1c293ccb04",
+		"8da4e775a563c18f715f802a063c5a31b8a11f5c5ee1879ec3454e5f3c738d2d9d201395faa4b61a96c8",
+	},
+	{
+		sha1.New, "0b0b0b0b0b0b0b0b0b0b0b", "000102030405060708090a0b0c", "f0f1f2f3f4f5f6f7f8f9",
+		"9b6c18c432a7bf8f0e71c8eb88f4b30baa2ba243",
+		"085a01ea1b10f36933068b56efa5ad81a4f14b822f5b091568a9cdd4f155fda2c22e422478d305f3f896",
+	},
+	{
+		sha1.New, hkdfSeq(0x00, 0x50), hkdfSeq(0x60, 0xb0), hkdfSeq(0xb0, 0x100),
+		"8adae09a2a307059478d309b26c4115a224cfaf6",
+		"0bd770a74d1160f7c9f12cd5912a06ebff6adcae899d92191fe4305673ba2ffe8fa3f1a4e5ad79f3f334b3b202b2173c486ea37ce3d397ed034c7f9dfeb15c5e927336d0441f4c4300e2cff0d0900b52d3b4",
+	},
+	{
+		sha1.New, "0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b", "", "",
+		"da8c8a73c7fa77288ec6f5e7c297786aa0d32d01",
+		"0ac1af7002b3d761d1e55298da9d0506b9ae52057220a306e07b6b87e8df21d0ea00033de03984d34918",
+	},
+	{
+		sha1.New, "0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c", "-", "",
+		"2adccada18779e7c2077ad2eb19d3f3e731385dd",
+		"2c91117204d745f3500d636a62f64f0ab3bae548aa53d423b0d1f27ebba6f5e5673a081d70cce7acfc48",
+	},
+}
+
+// hkdfSeq returns the hex of the bytes from..to-1.
+func hkdfSeq(from, to int) string {
+	b := make([]byte, 0, to-from)
+	for i := from; i < to; i++ {
+		b = append(b, byte(i))
+	}
+	return hex.EncodeToString(b)
+}
+
+func hkdfUnhex(s string) []byte {
+	if s == "-" {
+		return nil
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestHKDFVectors(t *testing.T) {
+	for i, v := range hkdfVectors {
+		secret, salt, info := hkdfUnhex(v.secret), hkdfUnhex(v.salt), hkdfUnhex(v.info)
+		okm := hkdfUnhex(v.okm)
+
+		if prk := hex.EncodeToString(hkdf.Extract(v.hash, secret, salt)); prk != v.prk {
+			t.Errorf("case %d: Extract = %s, want %s", i+1, prk, v.prk)
+		}
+
+		out := make([]byte, len(okm))
+		if _, err := io.ReadFull(hkdf.New(v.hash, secret, salt, info), out); err != nil || !bytes.Equal(out, okm) {
+			t.Errorf("case %d: New = %x, %v; want %x", i+1, out, err, okm)
+		}
+
+		// Reading in chunks that straddle the block boundaries yields the
+		// same stream.
+		r := hkdf.Expand(v.hash, hkdfUnhex(v.prk), info)
+		var streamed []byte
+		for n := 1; len(streamed) < len(okm); n++ {
+			if rest := len(okm) - len(streamed); n > rest {
+				n = rest
+			}
+			chunk := make([]byte, n)
+			if _, err := r.Read(chunk); err != nil {
+				t.Fatalf("case %d: Read: %v", i+1, err)
+			}
+			streamed = append(streamed, chunk...)
+		}
+		if !bytes.Equal(streamed, okm) {
+			t.Errorf("case %d: streamed Expand = %x, want %x", i+1, streamed, okm)
+		}
+	}
+}
+
+func TestHKDFLimit(t *testing.T) {
+	limit := 255 * sha256.Size
+
+	r := hkdf.New(sha256.New, []byte("secret"), nil, nil)
+	if n, err := r.Read(make([]byte, limit+1)); n != 0 || err == nil {
+		t.Errorf("reading %d bytes at once = %d, %v; want an error", limit+1, n, err)
+	}
+	// The failed read consumed nothing.
+	all := make([]byte, limit)
+	if _, err := io.ReadFull(r, all); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := r.Read(make([]byte, 1)); n != 0 || err == nil {
+		t.Errorf("reading past the limit = %d, %v; want an error", n, err)
+	}
+	if n, err := r.Read(nil); n != 0 || err != nil {
+		t.Errorf("empty read at the limit = %d, %v", n, err)
+	}
+
+	// The last bytes before the limit can be read in uneven pieces too.
+	r = hkdf.New(sha256.New, []byte("secret"), nil, nil)
+	head := make([]byte, limit-5)
+	io.ReadFull(r, head)
+	tail := make([]byte, 5)
+	if _, err := r.Read(tail); err != nil || !bytes.Equal(append(head, tail...), all) {
+		t.Errorf("split read of the whole output = %v", err)
+	}
+}
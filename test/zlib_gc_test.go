@@ -0,0 +1,55 @@
+//go:build llgo && !nogc
+// +build llgo,!nogc
+
+package test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"runtime"
+	"testing"
+)
+
+// The zlib state of a Writer or a reader dropped without a Close is
+// released by its finalizer: the memory of a loop that abandons them stays
+// bounded, although the state lives outside the memory managed by the
+// garbage collector.
+func TestZlibFinalizerMemoryBounded(t *testing.T) {
+	if residentKB(t) < 0 {
+		t.Skip("no /proc/self/status")
+	}
+	var b bytes.Buffer
+	w := zlib.NewWriter(&b)
+	io.WriteString(w, zlibText)
+	w.Close()
+	stream := b.Bytes()
+	p := make([]byte, 1)
+	churn := func(n int) {
+		for i := 0; i < n; i++ {
+			w := zlib.NewWriter(io.Discard)
+			if _, err := io.WriteString(w, zlibText); err != nil {
+				t.Fatal(err)
+			}
+			r, err := zlib.NewReader(bytes.NewReader(stream))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := r.Read(p); err != nil {
+				t.Fatal(err)
+			}
+			if i%64 == 0 {
+				runtime.GC()
+			}
+		}
+		runtime.GC()
+	}
+	churn(1 << 6) // warm up the malloc arenas
+	before := residentKB(t)
+
+	const n = 1 << 11 // over 256 KiB of deflate state each, 512 MiB if it leaked
+	churn(n)
+	if grown := residentKB(t) - before; grown > 32<<10 {
+		t.Errorf("resident memory grew by %d KiB over %d abandoned Writers and readers", grown, n)
+	}
+}
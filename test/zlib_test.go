@@ -0,0 +1,279 @@
+//go:build llgo
+// +build llgo
+
+package test
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+var zlibText = strings.Repeat("The fog is getting thicker! ", 8) + "And Leon's getting laaarger!"
+
+// Streams of zlibText written by the compress/zlib of the standard library:
+// with a Flush after 100 bytes, and with a dictionary at BestCompression.
+const (
+	zlibStdFlushed = "789c0064009bff54686520666f672069732067657474696e6720746869636b6572" +
+		"212054686520666f672069732067657474696e6720746869636b65722120546865" +
+		"20666f672069732067657474696e6720746869636b6572212054686520666f6720" +
+		"6973206765747469000000ffffca4b5728c9c84cce4e2d525408c9485548cb4f57" +
+		"c82c56484f2d29c9a4b59c635e8a824f6a7e9e3a422e273131b1283db548113000" +
+		"ca2f589e"
+	zlibStdDict = "78f9480d07590ac9485500096716c344602a141586839c635e8a824f6a7e9e3a42" +
+		"2e273131b1283db548113000ca2f589e"
+	zlibStdDictWord = "fog thicker getting"
+)
+
+func zlibCompress(t *testing.T, data []byte, level int, chunk int) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	w, err := zlib.NewWriterLevel(&b, level)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for p := data; len(p) > 0; {
+		n := chunk
+		if n > len(p) {
+			n = len(p)
+		}
+		if _, err := w.Write(p[:n]); err != nil {
+			t.Fatal(err)
+		}
+		p = p[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+func zlibDecompress(t *testing.T, r io.Reader) []byte {
+	t.Helper()
+	zr, err := zlib.NewReader(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := zr.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func TestZlibRoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(191))
+	big := make([]byte, 300<<10)
+	for i := 0; i < len(big); {
+		// Alternate runs of random bytes and of repeated text.
+		n := rnd.Intn(4096)
+		if i+n > len(big) {
+			n = len(big) - i
+		}
+		if rnd.Intn(2) == 0 {
+			rnd.Read(big[i : i+n])
+		} else {
+			copy(big[i:i+n], strings.Repeat(zlibText, n/len(zlibText)+1))
+		}
+		i += n
+	}
+	for _, data := range [][]byte{nil, []byte("x"), []byte(zlibText), big} {
+		for _, level := range []int{zlib.DefaultCompression, zlib.NoCompression, zlib.BestSpeed, zlib.BestCompression, zlib.HuffmanOnly} {
+			z := zlibCompress(t, data, level, 1000)
+			for name, r := range map[string]io.Reader{
+				"bytes":   bytes.NewReader(z),
+				"bufio":   bufio.NewReaderSize(bytes.NewReader(z), 16),
+				"onebyte": iotest.OneByteReader(bytes.NewReader(z)),
+				"half":    iotest.HalfReader(bytes.NewReader(z)),
+			} {
+				if got := zlibDecompress(t, r); !bytes.Equal(got, data) {
+					t.Fatalf("level %d, %s reader: got %d bytes, want %d", level, name, len(got), len(data))
+				}
+			}
+		}
+	}
+}
+
+func TestZlibStdStream(t *testing.T) {
+	if got := zlibDecompress(t, bytes.NewReader(mustHex(t, zlibStdFlushed))); string(got) != zlibText {
+		t.Errorf("flushed stream: got %q", got)
+	}
+
+	z := mustHex(t, zlibStdDict)
+	if _, err := zlib.NewReader(bytes.NewReader(z)); err != zlib.ErrDictionary {
+		t.Errorf("NewReader without the dictionary: %v, want ErrDictionary", err)
+	}
+	if _, err := zlib.NewReaderDict(bytes.NewReader(z), []byte("another")); err != zlib.ErrDictionary {
+		t.Errorf("NewReaderDict with another dictionary: %v, want ErrDictionary", err)
+	}
+	zr, err := zlib.NewReaderDict(bytes.NewReader(z), []byte(zlibStdDictWord))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := io.ReadAll(zr); err != nil || string(got) != zlibText {
+		t.Errorf("dictionary stream: got %q, %v", got, err)
+	}
+
+	// And a stream with a dictionary written here.
+	var b bytes.Buffer
+	w, err := zlib.NewWriterLevelDict(&b, zlib.BestCompression, []byte(zlibStdDictWord))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte(zlibText))
+	w.Close()
+	if !bytes.Equal(b.Bytes()[:6], z[:6]) {
+		t.Errorf("header %x, want %x", b.Bytes()[:6], z[:6])
+	}
+	zr, err = zlib.NewReaderDict(&b, []byte(zlibStdDictWord))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := io.ReadAll(zr); err != nil || string(got) != zlibText {
+		t.Errorf("dictionary round trip: got %q, %v", got, err)
+	}
+}
+
+// A Flush makes all the data written so far readable, before Close.
+func TestZlibFlush(t *testing.T) {
+	var b bytes.Buffer
+	w := zlib.NewWriter(&b)
+	part1, part2 := []byte(zlibText[:100]), []byte(zlibText[100:])
+	w.Write(part1)
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal("second Flush:", err)
+	}
+	flushed := append([]byte(nil), b.Bytes()...)
+	if !bytes.HasSuffix(flushed, []byte{0, 0, 0xff, 0xff}) {
+		t.Errorf("flushed output %x doesn't end with an empty stored block", flushed)
+	}
+	zr, err := zlib.NewReader(bytes.NewReader(flushed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make([]byte, len(part1))
+	if _, err := io.ReadFull(zr, got); err != nil || !bytes.Equal(got, part1) {
+		t.Fatalf("read after Flush: %q, %v", got, err)
+	}
+	if n, err := zr.Read(got); n != 0 || err != io.ErrUnexpectedEOF {
+		t.Errorf("read past the flushed data: %d, %v, want io.ErrUnexpectedEOF", n, err)
+	}
+
+	w.Write(part2)
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := zlibDecompress(t, &b); string(got) != zlibText {
+		t.Errorf("got %q", got)
+	}
+	if _, err := w.Write(part2); err == nil {
+		t.Error("Write after Close succeeded")
+	}
+	if err := w.Close(); err == nil {
+		t.Error("second Close succeeded")
+	}
+}
+
+// byteReader hides all the methods of its io.ByteReader but ReadByte and Read.
+type byteReader struct{ r *bytes.Reader }
+
+func (b byteReader) Read(p []byte) (int, error) { return b.r.Read(p) }
+func (b byteReader) ReadByte() (byte, error)    { return b.r.ReadByte() }
+
+// No byte past the end of the stream is consumed from an io.ByteReader, so
+// that the data after it can be read.
+func TestZlibReaderStopsAtEnd(t *testing.T) {
+	z := append(zlibCompress(t, []byte(zlibText), zlib.DefaultCompression, 1<<20), "tail"...)
+	for _, mk := range []func() io.Reader{
+		func() io.Reader { return bytes.NewReader(z) },
+		func() io.Reader { return bufio.NewReaderSize(bytes.NewReader(z), 16) },
+		func() io.Reader { return bufio.NewReader(bytes.NewReader(z)) },
+		func() io.Reader { return byteReader{bytes.NewReader(z)} },
+	} {
+		r := mk()
+		if got := zlibDecompress(t, r); string(got) != zlibText {
+			t.Fatalf("%T: got %q", r, got)
+		}
+		if rest, _ := io.ReadAll(r); string(rest) != "tail" {
+			t.Errorf("%T: left %q after the stream, want \"tail\"", r, rest)
+		}
+	}
+}
+
+func TestZlibErrors(t *testing.T) {
+	z := zlibCompress(t, []byte(zlibText), zlib.DefaultCompression, 1<<20)
+	if _, err := zlib.NewReader(bytes.NewReader([]byte("not zlib"))); err != zlib.ErrHeader {
+		t.Errorf("bad header: %v, want ErrHeader", err)
+	}
+	for _, n := range []int{0, 1} {
+		if _, err := zlib.NewReader(bytes.NewReader(z[:n])); err != io.ErrUnexpectedEOF {
+			t.Errorf("%d-byte stream: %v, want io.ErrUnexpectedEOF", n, err)
+		}
+	}
+
+	zr, err := zlib.NewReader(bytes.NewReader(z[:len(z)-5]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadAll(zr); err != io.ErrUnexpectedEOF {
+		t.Errorf("truncated stream: %v, want io.ErrUnexpectedEOF", err)
+	}
+	if err := zr.Close(); err != io.ErrUnexpectedEOF {
+		t.Errorf("Close of a truncated stream: %v, want io.ErrUnexpectedEOF", err)
+	}
+
+	bad := append([]byte(nil), z...)
+	bad[len(bad)-1] ^= 1
+	zr, err = zlib.NewReader(bytes.NewReader(bad))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := io.ReadAll(zr); err != zlib.ErrChecksum || string(got) != zlibText {
+		t.Errorf("bad checksum: %d bytes, %v, want ErrChecksum", len(got), err)
+	}
+
+	if _, err := zlib.NewWriterLevel(io.Discard, 10); err == nil {
+		t.Error("NewWriterLevel(10) succeeded")
+	}
+}
+
+func TestZlibReset(t *testing.T) {
+	var b1, b2 bytes.Buffer
+	w := zlib.NewWriter(&b1)
+	w.Write([]byte("first"))
+	w.Close()
+	w.Reset(&b2)
+	w.Write([]byte(zlibText))
+	w.Close()
+
+	zr, err := zlib.NewReader(&b1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := io.ReadAll(zr); string(got) != "first" {
+		t.Errorf("first stream: %q", got)
+	}
+	if err := zr.(zlib.Resetter).Reset(&b2, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := io.ReadAll(zr); string(got) != zlibText {
+		t.Errorf("stream after Reset: %q", got)
+	}
+	if _, err := zr.Read(make([]byte, 1)); err != io.EOF {
+		t.Errorf("Read after the end: %v, want io.EOF", err)
+	}
+	if err := zr.Close(); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+}